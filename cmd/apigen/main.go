@@ -0,0 +1,376 @@
+// Command apigen generates code from api.Interface, so that the types which
+// implement or wrap it don't drift as endpoints are added. It's run via go
+// generate in the api package, and writes:
+//
+//   - mock.API, a mock implementation with a function field per method;
+//   - the methods of mock.Spy, which records every call it passes on;
+//   - the methods of api.Middleware, which passes every call through a hook.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+func main() {
+	var (
+		pkgPath    = flag.String("pkg", "github.com/fastly/cli/pkg/api", "import path of the package declaring the interface")
+		name       = flag.String("interface", "Interface", "name of the interface")
+		mock       = flag.String("mock", "", "output path for mock.API")
+		spy        = flag.String("spy", "", "output path for the mock.Spy methods")
+		middleware = flag.String("middleware", "", "output path for the api.Middleware methods")
+	)
+	flag.Parse()
+
+	files, err := Generate(*pkgPath, *name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "apigen: %v\n", err)
+		os.Exit(1)
+	}
+	for path, output := range map[string]string{*mock: "mock", *spy: "spy", *middleware: "middleware"} {
+		if path == "" {
+			continue
+		}
+		if err := ioutil.WriteFile(path, files[output], 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "apigen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// Generate loads the named interface from the package at pkgPath, and returns
+// the source of each generated file, keyed by "mock", "spy" and "middleware".
+func Generate(pkgPath, name string) (map[string][]byte, error) {
+	fset := token.NewFileSet()
+	pkg, err := load(fset, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading %s: %w", pkgPath, err)
+	}
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, fmt.Errorf("%s.%s not found", pkgPath, name)
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s.%s is not an interface", pkgPath, name)
+	}
+
+	methods, err := loadMethods(fset, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for output, target := range map[string]struct {
+		pkg  string
+		self *types.Package
+		tmpl *template.Template
+	}{
+		"mock":       {"mock", nil, mockTemplate},
+		"spy":        {"mock", nil, spyTemplate},
+		"middleware": {pkg.Name(), pkg, middlewareTemplate},
+	} {
+		src, err := render(target.tmpl, target.pkg, target.self, methods)
+		if err != nil {
+			return nil, fmt.Errorf("error generating %s: %w", output, err)
+		}
+		files[output] = src
+	}
+	return files, nil
+}
+
+// load parses and type-checks the package at pkgPath. Errors that a type
+// doesn't implement the interface because of a missing method are ignored, as
+// the package's own implementations, such as api.Middleware, don't implement a
+// newly added method until it's generated. Any other error is returned.
+func load(fset *token.FileSet, pkgPath string) (*types.Package, error) {
+	bp, err := build.Import(pkgPath, ".", 0)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*ast.File, 0, len(bp.GoFiles))
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	var errs []error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error: func(err error) {
+			if te, ok := err.(types.Error); ok && strings.Contains(te.Msg, "missing method ") {
+				return
+			}
+			errs = append(errs, err)
+		},
+	}
+	pkg, _ := conf.Check(pkgPath, fset, files, nil)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return pkg, nil
+}
+
+// method is an interface method, in declaration order.
+type method struct {
+	Name      string
+	Signature *types.Signature
+	Group     bool // preceded by a blank line in the interface declaration
+
+	pos token.Position
+}
+
+func loadMethods(fset *token.FileSet, iface *types.Interface) ([]method, error) {
+	methods := make([]method, iface.NumMethods())
+	for i := range methods {
+		fn := iface.Method(i)
+		sig := fn.Type().(*types.Signature)
+		if err := checkSignature(sig); err != nil {
+			return nil, fmt.Errorf("unsupported method %s: %w", fn.Name(), err)
+		}
+		methods[i] = method{Name: fn.Name(), Signature: sig, pos: fset.Position(fn.Pos())}
+	}
+
+	// The methods of a types.Interface are sorted by name, but the order and
+	// grouping of the declaration reads better in the generated mock.
+	sort.SliceStable(methods, func(i, j int) bool {
+		return methods[i].pos.Offset < methods[j].pos.Offset
+	})
+	for i := 1; i < len(methods); i++ {
+		methods[i].Group = methods[i].pos.Line > methods[i-1].pos.Line+1
+	}
+	return methods, nil
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+// checkSignature ensures a method returns either an error, or a single value
+// and an error, which is the shape of every Fastly client method.
+func checkSignature(sig *types.Signature) error {
+	res := sig.Results()
+	if res.Len() < 1 || res.Len() > 2 || !types.Identical(res.At(res.Len()-1).Type(), errorType) {
+		return fmt.Errorf("must return error, or a value and error")
+	}
+	if sig.Variadic() {
+		return fmt.Errorf("variadic methods aren't supported")
+	}
+	return nil
+}
+
+// param is a method parameter as rendered in a particular package.
+type param struct {
+	Name string
+	Type string
+}
+
+// view is a method as rendered in a particular package.
+type view struct {
+	Name   string
+	Field  string // name of the mock's function field
+	Group  bool
+	Params []param
+	Result string // type of the non-error result, or "" if there isn't one
+}
+
+// mockFields are the names of mock function fields which don't follow the
+// usual <method>Fn form, kept from before the mock was generated so existing
+// tests don't break.
+var mockFields = map[string]string{
+	"ListOpenstack": "ListOpenstacksFn",
+}
+
+// Args returns the parameter names as a call's argument list.
+func (v view) Args() string {
+	names := make([]string, len(v.Params))
+	for i, p := range v.Params {
+		names[i] = p.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Decl returns the parameters as a declaration's parameter list.
+func (v view) Decl() string {
+	decl := make([]string, len(v.Params))
+	for i, p := range v.Params {
+		decl[i] = p.Name + " " + p.Type
+	}
+	return strings.Join(decl, ", ")
+}
+
+// Types returns the parameter types, without names.
+func (v view) Types() string {
+	ts := make([]string, len(v.Params))
+	for i, p := range v.Params {
+		ts[i] = p.Type
+	}
+	return strings.Join(ts, ", ")
+}
+
+// Results returns the method's result list.
+func (v view) Results() string {
+	if v.Result == "" {
+		return "error"
+	}
+	return "(" + v.Result + ", error)"
+}
+
+// Input returns the expression passed to hooks and spies as a call's input:
+// the sole argument, nil if there are none, or a slice of all of them.
+func (v view) Input() string {
+	switch len(v.Params) {
+	case 0:
+		return "nil"
+	case 1:
+		return v.Params[0].Name
+	default:
+		return "[]interface{}{" + v.Args() + "}"
+	}
+}
+
+func render(tmpl *template.Template, pkgName string, self *types.Package, methods []method) ([]byte, error) {
+	imports := make(map[string]bool)
+	qualifier := func(p *types.Package) string {
+		if p == self {
+			return ""
+		}
+		imports[p.Path()] = true
+		return p.Name()
+	}
+
+	views := make([]view, len(methods))
+	for i, m := range methods {
+		v := view{Name: m.Name, Field: m.Name + "Fn", Group: m.Group}
+		if field, ok := mockFields[m.Name]; ok {
+			v.Field = field
+		}
+		params := m.Signature.Params()
+		for j := 0; j < params.Len(); j++ {
+			v.Params = append(v.Params, param{
+				Name: paramName(params.At(j), j),
+				Type: types.TypeString(params.At(j).Type(), qualifier),
+			})
+		}
+		if res := m.Signature.Results(); res.Len() == 2 {
+			v.Result = types.TypeString(res.At(0).Type(), qualifier)
+		}
+		views[i] = v
+	}
+
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, struct {
+		Package string
+		Imports []string
+		Methods []view
+	}{pkgName, paths, views})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// paramName names the parameters of the generated methods: the input struct
+// of a Fastly client method is conventionally i, and the destination of a
+// method which decodes into an arbitrary value is dst.
+func paramName(v *types.Var, index int) string {
+	if v.Name() != "" && v.Name() != "_" {
+		return v.Name()
+	}
+	if iface, ok := v.Type().Underlying().(*types.Interface); ok && iface.Empty() {
+		return "dst"
+	}
+	if index == 0 {
+		return "i"
+	}
+	return fmt.Sprintf("p%d", index)
+}
+
+const header = `// Code generated by apigen. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}`
+
+var mockTemplate = template.Must(template.New("mock").Parse(header + `
+// API is a mock implementation of api.Interface that's used for testing.
+// The zero value is useful, but will panic on all methods. Provide function
+// implementations for the method(s) your test will call.
+type API struct {
+{{- range .Methods}}
+{{- if .Group}}
+{{end}}
+	{{.Field}} func({{.Types}}) {{.Results}}
+{{- end}}
+}
+{{range .Methods}}
+// {{.Name}} implements Interface.
+func (m API) {{.Name}}({{.Decl}}) {{.Results}} {
+	return m.{{.Field}}({{.Args}})
+}
+{{end}}`))
+
+var spyTemplate = template.Must(template.New("spy").Parse(header + `
+{{range .Methods}}
+// {{.Name}} implements Interface.
+func (s *Spy) {{.Name}}({{.Decl}}) {{.Results}} {
+{{- if .Result}}
+	o, err := s.API.{{.Name}}({{.Args}})
+	s.record("{{.Name}}", {{.Input}}, o, err)
+	return o, err
+{{- else}}
+	err := s.API.{{.Name}}({{.Args}})
+	s.record("{{.Name}}", {{.Input}}, nil, err)
+	return err
+{{- end}}
+}
+{{end}}`))
+
+var middlewareTemplate = template.Must(template.New("middleware").Parse(header + `
+{{range .Methods}}
+// {{.Name}} implements Interface.
+func (m *Middleware) {{.Name}}({{.Decl}}) {{.Results}} {
+{{- if .Result}}
+	o, err := m.Hook("{{.Name}}", {{.Input}}, func() (interface{}, error) {
+		return m.Client.{{.Name}}({{.Args}})
+	})
+	out, _ := o.({{.Result}})
+	return out, err
+{{- else}}
+	_, err := m.Hook("{{.Name}}", {{.Input}}, func() (interface{}, error) {
+		return nil, m.Client.{{.Name}}({{.Args}})
+	})
+	return err
+{{- end}}
+}
+{{end}}`))
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+// TestGenerated ensures the generated files are up to date with api.Interface.
+// If it fails, run go generate ./pkg/api.
+func TestGenerated(t *testing.T) {
+	files, err := Generate("github.com/fastly/cli/pkg/api", "Interface")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for output, path := range map[string]string{
+		"mock":       filepath.Join("..", "..", "pkg", "mock", "api_gen.go"),
+		"spy":        filepath.Join("..", "..", "pkg", "mock", "spy_gen.go"),
+		"middleware": filepath.Join("..", "..", "pkg", "api", "middleware_gen.go"),
+	} {
+		have, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.AssertString(t, string(files[output]), string(have))
+	}
+}
+
+// TestLoadErrors ensures that a package is only loaded despite type errors if
+// they're due to its implementations missing a method of the interface.
+func TestLoadErrors(t *testing.T) {
+	_, err := Generate("./testdata/pending", "Interface")
+	testutil.AssertNoError(t, err)
+
+	_, err = Generate("./testdata/broken", "Interface")
+	testutil.AssertErrorContains(t, err, "cannot use")
+}
@@ -0,0 +1,8 @@
+// Package broken declares an interface, but doesn't type-check.
+package broken
+
+type Interface interface {
+	Get() (string, error)
+}
+
+var count int = "one"
@@ -0,0 +1,14 @@
+// Package pending declares an interface with a method its wrapper doesn't yet
+// implement, as after a method is added but before it's generated.
+package pending
+
+type Interface interface {
+	Get() (string, error)
+	Put(string) error
+}
+
+type Wrapper struct{}
+
+func (Wrapper) Get() (string, error) { return "", nil }
+
+var _ Interface = Wrapper{}
@@ -11,6 +11,7 @@ import (
 	"github.com/fastly/cli/pkg/common"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/recording"
 	"github.com/fastly/cli/pkg/update"
 )
 
@@ -34,15 +35,37 @@ func main() {
 		configFilePath           = config.FilePath // write-only for `fastly configure`
 		clientFactory            = app.FastlyAPIClient
 		httpClient               = http.DefaultClient
-		versioner                = update.NewGitHub(context.Background())
+		versioner                = update.NewGitHub(context.Background(), file.UpdateBaseURL)
 		in             io.Reader = os.Stdin
 		out            io.Writer = common.NewSyncWriter(os.Stdout)
 	)
 
+	// HTTP interactions with the API can be recorded to a fixture file, with
+	// secrets scrubbed, e.g. to attach to a bug report, or replayed from one.
+	transport, err := recording.NewTransport(env, http.DefaultTransport)
+	if err != nil {
+		errors.RemediationError{Inner: err}.Print(os.Stderr)
+		os.Exit(errors.ExitUsage)
+	}
+	if transport != nil {
+		clientFactory = app.NewFastlyAPIClientFactory(transport)
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	// Errors are rendered according to the global --format flag, or the
+	// project config file.
+	format := app.ErrorFormat(args)
+
 	// Main is basically just a shim to call Run, so we do that here.
 	if err := app.Run(args, env, file, configFilePath, clientFactory, httpClient, versioner, in, out); err != nil {
-		errors.Deduce(err).Print(os.Stderr)
-		os.Exit(1)
+		code := errors.ExitCode(err)
+		re := errors.Deduce(err)
+		if format == config.FormatJSON && re.Inner != nil {
+			re.PrintJSON(os.Stderr, code, errors.HTTPStatus(err))
+		} else {
+			re.Print(os.Stderr)
+		}
+		os.Exit(code)
 	}
 }
 
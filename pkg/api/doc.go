@@ -1,2 +1,4 @@
 // Package api provides abstractions for talking to the Fastly API.
 package api
+
+//go:generate go run github.com/fastly/cli/cmd/apigen -mock ../mock/api_gen.go -spy ../mock/spy_gen.go -middleware middleware_gen.go
@@ -0,0 +1,32 @@
+package api
+
+import "reflect"
+
+// SetFields sets the fields of input, a pointer to a go-fastly create or
+// update input struct, from the fields of the same name in resource, a
+// go-fastly resource or a pointer to one. Values are converted as needed, e.g.
+// from bool to fastly.Compatibool, or to pointers for update inputs. The
+// ServiceID and ServiceVersion fields of input are left as they are.
+func SetFields(input, resource interface{}) {
+	in := reflect.ValueOf(input).Elem()
+	r := reflect.Indirect(reflect.ValueOf(resource))
+	for i := 0; i < in.NumField(); i++ {
+		name := in.Type().Field(i).Name
+		if name == "ServiceID" || name == "ServiceVersion" {
+			continue
+		}
+		src := r.FieldByName(name)
+		if !src.IsValid() {
+			continue
+		}
+		dst := in.Field(i)
+		switch t := dst.Type(); {
+		case t.Kind() == reflect.Ptr && src.Type().ConvertibleTo(t.Elem()):
+			v := reflect.New(t.Elem())
+			v.Elem().Set(src.Convert(t.Elem()))
+			dst.Set(v)
+		case src.Type().ConvertibleTo(t):
+			dst.Set(src.Convert(t))
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestSetFields(t *testing.T) {
+	backend := &fastly.Backend{
+		ServiceID:      "123",
+		ServiceVersion: 1,
+		Name:           "origin",
+		Address:        "example.com",
+		Port:           443,
+		UseSSL:         true,
+		SSLCiphers:     []string{"DHE"},
+	}
+
+	create := &fastly.CreateBackendInput{ServiceID: "456", ServiceVersion: 2}
+	api.SetFields(create, backend)
+	testutil.AssertEqual(t, &fastly.CreateBackendInput{
+		ServiceID:      "456",
+		ServiceVersion: 2,
+		Name:           "origin",
+		Address:        "example.com",
+		Port:           443,
+		UseSSL:         true,
+		SSLCiphers:     []string{"DHE"},
+	}, create)
+
+	update := &fastly.UpdateBackendInput{ServiceID: "456", ServiceVersion: 2}
+	api.SetFields(update, backend)
+	testutil.AssertEqual(t, "origin", update.Name)
+	testutil.AssertEqual(t, "example.com", *update.Address)
+	testutil.AssertEqual(t, uint(443), *update.Port)
+	testutil.AssertEqual(t, fastly.Compatibool(true), *update.UseSSL)
+	testutil.AssertEqual(t, (*string)(nil), update.NewName)
+}
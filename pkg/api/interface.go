@@ -16,6 +16,8 @@ type HTTPClient interface {
 
 // Interface models the methods of the Fastly API client that we use.
 // It exists to allow for easier testing, in combination with Mock.
+// After changing it, run go generate ./pkg/api to regenerate mock.API and the
+// methods of mock.Spy and api.Middleware.
 //
 // TODO(integralist):
 // There are missing methods such as GetVersion from this list so review in
@@ -31,6 +33,7 @@ type Interface interface {
 	DeleteService(*fastly.DeleteServiceInput) error
 	SearchService(*fastly.SearchServiceInput) (*fastly.Service, error)
 
+	CreateVersion(*fastly.CreateVersionInput) (*fastly.Version, error)
 	CloneVersion(*fastly.CloneVersionInput) (*fastly.Version, error)
 	ListVersions(*fastly.ListVersionsInput) ([]*fastly.Version, error)
 	UpdateVersion(*fastly.UpdateVersionInput) (*fastly.Version, error)
@@ -75,6 +78,30 @@ type Interface interface {
 
 	GetDictionaryInfo(*fastly.GetDictionaryInfoInput) (*fastly.DictionaryInfo, error)
 
+	CreateCondition(*fastly.CreateConditionInput) (*fastly.Condition, error)
+	ListConditions(*fastly.ListConditionsInput) ([]*fastly.Condition, error)
+
+	CreateHeader(*fastly.CreateHeaderInput) (*fastly.Header, error)
+	ListHeaders(*fastly.ListHeadersInput) ([]*fastly.Header, error)
+
+	CreateVCL(*fastly.CreateVCLInput) (*fastly.VCL, error)
+	ListVCLs(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
+
+	CreateSnippet(*fastly.CreateSnippetInput) (*fastly.Snippet, error)
+	ListSnippets(*fastly.ListSnippetsInput) ([]*fastly.Snippet, error)
+
+	CreateGzip(*fastly.CreateGzipInput) (*fastly.Gzip, error)
+	ListGzips(*fastly.ListGzipsInput) ([]*fastly.Gzip, error)
+
+	CreateCacheSetting(*fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error)
+	ListCacheSettings(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error)
+
+	CreateRequestSetting(*fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error)
+	ListRequestSettings(*fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error)
+
+	CreateResponseObject(*fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error)
+	ListResponseObjects(*fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error)
+
 	CreateBigQuery(*fastly.CreateBigQueryInput) (*fastly.BigQuery, error)
 	ListBigQueries(*fastly.ListBigQueriesInput) ([]*fastly.BigQuery, error)
 	GetBigQuery(*fastly.GetBigQueryInput) (*fastly.BigQuery, error)
@@ -227,8 +254,28 @@ type Interface interface {
 
 	GetUser(*fastly.GetUserInput) (*fastly.User, error)
 
+	GetAPIEvents(*fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error)
+
+	ListWAFs(*fastly.ListWAFsInput) (*fastly.WAFResponse, error)
+	GetWAF(*fastly.GetWAFInput) (*fastly.WAF, error)
+	UpdateWAF(*fastly.UpdateWAFInput) (*fastly.WAF, error)
+	ListAllWAFVersions(*fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error)
+	CloneWAFVersion(*fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error)
+	DeployWAFVersion(*fastly.DeployWAFVersionInput) error
+	ListAllWAFActiveRules(*fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error)
+	BatchModificationWAFActiveRules(*fastly.BatchModificationWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error)
+	ListAllWAFRules(*fastly.ListAllWAFRulesInput) (*fastly.WAFRuleResponse, error)
+
 	GetRegions() (*fastly.RegionsResponse, error)
 	GetStatsJSON(*fastly.GetStatsInput, interface{}) error
+	GetUsage(*fastly.GetUsageInput) (*fastly.UsageResponse, error)
+	GetUsageByService(*fastly.GetUsageInput) (*fastly.UsageByServiceResponse, error)
+
+	GetBilling(*fastly.GetBillingInput) (*fastly.Billing, error)
+
+	AllDatacenters() ([]fastly.Datacenter, error)
+	IPs() (fastly.IPAddrs, error)
+	IPsV6() (fastly.IPAddrs, error)
 }
 
 // RealtimeStatsInterface is the subset of go-fastly's realtime stats API used here.
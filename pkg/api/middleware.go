@@ -0,0 +1,28 @@
+package api
+
+// Hook intercepts a call made through a Middleware. It's given the name of the
+// Interface method, the call's input, and a function which makes the call on
+// the wrapped client, and returns the call's output and error. A hook can
+// observe or replace the output, make the call more than once, or not at all.
+//
+// The input is the method's sole argument, nil if it has none, or a
+// []interface{} of its arguments if it has several. The output is the
+// method's non-error result, or nil if it only returns an error.
+type Hook func(method string, input interface{}, call func() (interface{}, error)) (interface{}, error)
+
+// Middleware is an implementation of Interface which passes every call
+// through a single Hook, for concerns which apply to every endpoint, such as
+// retries, tracing or caching. Its methods are generated by cmd/apigen.
+type Middleware struct {
+	Client Interface
+	Hook   Hook
+}
+
+// NewMiddleware returns a Middleware which passes every call to client through
+// hook.
+func NewMiddleware(client Interface, hook Hook) *Middleware {
+	return &Middleware{Client: client, Hook: hook}
+}
+
+// Ensure that Middleware satisfies Interface.
+var _ Interface = (*Middleware)(nil)
@@ -0,0 +1,1818 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package api
+
+import (
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// GetTokenSelf implements Interface.
+func (m *Middleware) GetTokenSelf() (*fastly.Token, error) {
+	o, err := m.Hook("GetTokenSelf", nil, func() (interface{}, error) {
+		return m.Client.GetTokenSelf()
+	})
+	out, _ := o.(*fastly.Token)
+	return out, err
+}
+
+// CreateService implements Interface.
+func (m *Middleware) CreateService(i *fastly.CreateServiceInput) (*fastly.Service, error) {
+	o, err := m.Hook("CreateService", i, func() (interface{}, error) {
+		return m.Client.CreateService(i)
+	})
+	out, _ := o.(*fastly.Service)
+	return out, err
+}
+
+// ListServices implements Interface.
+func (m *Middleware) ListServices(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+	o, err := m.Hook("ListServices", i, func() (interface{}, error) {
+		return m.Client.ListServices(i)
+	})
+	out, _ := o.([]*fastly.Service)
+	return out, err
+}
+
+// GetService implements Interface.
+func (m *Middleware) GetService(i *fastly.GetServiceInput) (*fastly.Service, error) {
+	o, err := m.Hook("GetService", i, func() (interface{}, error) {
+		return m.Client.GetService(i)
+	})
+	out, _ := o.(*fastly.Service)
+	return out, err
+}
+
+// GetServiceDetails implements Interface.
+func (m *Middleware) GetServiceDetails(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+	o, err := m.Hook("GetServiceDetails", i, func() (interface{}, error) {
+		return m.Client.GetServiceDetails(i)
+	})
+	out, _ := o.(*fastly.ServiceDetail)
+	return out, err
+}
+
+// UpdateService implements Interface.
+func (m *Middleware) UpdateService(i *fastly.UpdateServiceInput) (*fastly.Service, error) {
+	o, err := m.Hook("UpdateService", i, func() (interface{}, error) {
+		return m.Client.UpdateService(i)
+	})
+	out, _ := o.(*fastly.Service)
+	return out, err
+}
+
+// DeleteService implements Interface.
+func (m *Middleware) DeleteService(i *fastly.DeleteServiceInput) error {
+	_, err := m.Hook("DeleteService", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteService(i)
+	})
+	return err
+}
+
+// SearchService implements Interface.
+func (m *Middleware) SearchService(i *fastly.SearchServiceInput) (*fastly.Service, error) {
+	o, err := m.Hook("SearchService", i, func() (interface{}, error) {
+		return m.Client.SearchService(i)
+	})
+	out, _ := o.(*fastly.Service)
+	return out, err
+}
+
+// CreateVersion implements Interface.
+func (m *Middleware) CreateVersion(i *fastly.CreateVersionInput) (*fastly.Version, error) {
+	o, err := m.Hook("CreateVersion", i, func() (interface{}, error) {
+		return m.Client.CreateVersion(i)
+	})
+	out, _ := o.(*fastly.Version)
+	return out, err
+}
+
+// CloneVersion implements Interface.
+func (m *Middleware) CloneVersion(i *fastly.CloneVersionInput) (*fastly.Version, error) {
+	o, err := m.Hook("CloneVersion", i, func() (interface{}, error) {
+		return m.Client.CloneVersion(i)
+	})
+	out, _ := o.(*fastly.Version)
+	return out, err
+}
+
+// ListVersions implements Interface.
+func (m *Middleware) ListVersions(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+	o, err := m.Hook("ListVersions", i, func() (interface{}, error) {
+		return m.Client.ListVersions(i)
+	})
+	out, _ := o.([]*fastly.Version)
+	return out, err
+}
+
+// UpdateVersion implements Interface.
+func (m *Middleware) UpdateVersion(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+	o, err := m.Hook("UpdateVersion", i, func() (interface{}, error) {
+		return m.Client.UpdateVersion(i)
+	})
+	out, _ := o.(*fastly.Version)
+	return out, err
+}
+
+// ActivateVersion implements Interface.
+func (m *Middleware) ActivateVersion(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+	o, err := m.Hook("ActivateVersion", i, func() (interface{}, error) {
+		return m.Client.ActivateVersion(i)
+	})
+	out, _ := o.(*fastly.Version)
+	return out, err
+}
+
+// DeactivateVersion implements Interface.
+func (m *Middleware) DeactivateVersion(i *fastly.DeactivateVersionInput) (*fastly.Version, error) {
+	o, err := m.Hook("DeactivateVersion", i, func() (interface{}, error) {
+		return m.Client.DeactivateVersion(i)
+	})
+	out, _ := o.(*fastly.Version)
+	return out, err
+}
+
+// LockVersion implements Interface.
+func (m *Middleware) LockVersion(i *fastly.LockVersionInput) (*fastly.Version, error) {
+	o, err := m.Hook("LockVersion", i, func() (interface{}, error) {
+		return m.Client.LockVersion(i)
+	})
+	out, _ := o.(*fastly.Version)
+	return out, err
+}
+
+// LatestVersion implements Interface.
+func (m *Middleware) LatestVersion(i *fastly.LatestVersionInput) (*fastly.Version, error) {
+	o, err := m.Hook("LatestVersion", i, func() (interface{}, error) {
+		return m.Client.LatestVersion(i)
+	})
+	out, _ := o.(*fastly.Version)
+	return out, err
+}
+
+// CreateDomain implements Interface.
+func (m *Middleware) CreateDomain(i *fastly.CreateDomainInput) (*fastly.Domain, error) {
+	o, err := m.Hook("CreateDomain", i, func() (interface{}, error) {
+		return m.Client.CreateDomain(i)
+	})
+	out, _ := o.(*fastly.Domain)
+	return out, err
+}
+
+// ListDomains implements Interface.
+func (m *Middleware) ListDomains(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+	o, err := m.Hook("ListDomains", i, func() (interface{}, error) {
+		return m.Client.ListDomains(i)
+	})
+	out, _ := o.([]*fastly.Domain)
+	return out, err
+}
+
+// GetDomain implements Interface.
+func (m *Middleware) GetDomain(i *fastly.GetDomainInput) (*fastly.Domain, error) {
+	o, err := m.Hook("GetDomain", i, func() (interface{}, error) {
+		return m.Client.GetDomain(i)
+	})
+	out, _ := o.(*fastly.Domain)
+	return out, err
+}
+
+// UpdateDomain implements Interface.
+func (m *Middleware) UpdateDomain(i *fastly.UpdateDomainInput) (*fastly.Domain, error) {
+	o, err := m.Hook("UpdateDomain", i, func() (interface{}, error) {
+		return m.Client.UpdateDomain(i)
+	})
+	out, _ := o.(*fastly.Domain)
+	return out, err
+}
+
+// DeleteDomain implements Interface.
+func (m *Middleware) DeleteDomain(i *fastly.DeleteDomainInput) error {
+	_, err := m.Hook("DeleteDomain", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteDomain(i)
+	})
+	return err
+}
+
+// CreateBackend implements Interface.
+func (m *Middleware) CreateBackend(i *fastly.CreateBackendInput) (*fastly.Backend, error) {
+	o, err := m.Hook("CreateBackend", i, func() (interface{}, error) {
+		return m.Client.CreateBackend(i)
+	})
+	out, _ := o.(*fastly.Backend)
+	return out, err
+}
+
+// ListBackends implements Interface.
+func (m *Middleware) ListBackends(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+	o, err := m.Hook("ListBackends", i, func() (interface{}, error) {
+		return m.Client.ListBackends(i)
+	})
+	out, _ := o.([]*fastly.Backend)
+	return out, err
+}
+
+// GetBackend implements Interface.
+func (m *Middleware) GetBackend(i *fastly.GetBackendInput) (*fastly.Backend, error) {
+	o, err := m.Hook("GetBackend", i, func() (interface{}, error) {
+		return m.Client.GetBackend(i)
+	})
+	out, _ := o.(*fastly.Backend)
+	return out, err
+}
+
+// UpdateBackend implements Interface.
+func (m *Middleware) UpdateBackend(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+	o, err := m.Hook("UpdateBackend", i, func() (interface{}, error) {
+		return m.Client.UpdateBackend(i)
+	})
+	out, _ := o.(*fastly.Backend)
+	return out, err
+}
+
+// DeleteBackend implements Interface.
+func (m *Middleware) DeleteBackend(i *fastly.DeleteBackendInput) error {
+	_, err := m.Hook("DeleteBackend", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteBackend(i)
+	})
+	return err
+}
+
+// CreateHealthCheck implements Interface.
+func (m *Middleware) CreateHealthCheck(i *fastly.CreateHealthCheckInput) (*fastly.HealthCheck, error) {
+	o, err := m.Hook("CreateHealthCheck", i, func() (interface{}, error) {
+		return m.Client.CreateHealthCheck(i)
+	})
+	out, _ := o.(*fastly.HealthCheck)
+	return out, err
+}
+
+// ListHealthChecks implements Interface.
+func (m *Middleware) ListHealthChecks(i *fastly.ListHealthChecksInput) ([]*fastly.HealthCheck, error) {
+	o, err := m.Hook("ListHealthChecks", i, func() (interface{}, error) {
+		return m.Client.ListHealthChecks(i)
+	})
+	out, _ := o.([]*fastly.HealthCheck)
+	return out, err
+}
+
+// GetHealthCheck implements Interface.
+func (m *Middleware) GetHealthCheck(i *fastly.GetHealthCheckInput) (*fastly.HealthCheck, error) {
+	o, err := m.Hook("GetHealthCheck", i, func() (interface{}, error) {
+		return m.Client.GetHealthCheck(i)
+	})
+	out, _ := o.(*fastly.HealthCheck)
+	return out, err
+}
+
+// UpdateHealthCheck implements Interface.
+func (m *Middleware) UpdateHealthCheck(i *fastly.UpdateHealthCheckInput) (*fastly.HealthCheck, error) {
+	o, err := m.Hook("UpdateHealthCheck", i, func() (interface{}, error) {
+		return m.Client.UpdateHealthCheck(i)
+	})
+	out, _ := o.(*fastly.HealthCheck)
+	return out, err
+}
+
+// DeleteHealthCheck implements Interface.
+func (m *Middleware) DeleteHealthCheck(i *fastly.DeleteHealthCheckInput) error {
+	_, err := m.Hook("DeleteHealthCheck", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteHealthCheck(i)
+	})
+	return err
+}
+
+// GetPackage implements Interface.
+func (m *Middleware) GetPackage(i *fastly.GetPackageInput) (*fastly.Package, error) {
+	o, err := m.Hook("GetPackage", i, func() (interface{}, error) {
+		return m.Client.GetPackage(i)
+	})
+	out, _ := o.(*fastly.Package)
+	return out, err
+}
+
+// UpdatePackage implements Interface.
+func (m *Middleware) UpdatePackage(i *fastly.UpdatePackageInput) (*fastly.Package, error) {
+	o, err := m.Hook("UpdatePackage", i, func() (interface{}, error) {
+		return m.Client.UpdatePackage(i)
+	})
+	out, _ := o.(*fastly.Package)
+	return out, err
+}
+
+// CreateDictionary implements Interface.
+func (m *Middleware) CreateDictionary(i *fastly.CreateDictionaryInput) (*fastly.Dictionary, error) {
+	o, err := m.Hook("CreateDictionary", i, func() (interface{}, error) {
+		return m.Client.CreateDictionary(i)
+	})
+	out, _ := o.(*fastly.Dictionary)
+	return out, err
+}
+
+// GetDictionary implements Interface.
+func (m *Middleware) GetDictionary(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
+	o, err := m.Hook("GetDictionary", i, func() (interface{}, error) {
+		return m.Client.GetDictionary(i)
+	})
+	out, _ := o.(*fastly.Dictionary)
+	return out, err
+}
+
+// DeleteDictionary implements Interface.
+func (m *Middleware) DeleteDictionary(i *fastly.DeleteDictionaryInput) error {
+	_, err := m.Hook("DeleteDictionary", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteDictionary(i)
+	})
+	return err
+}
+
+// ListDictionaries implements Interface.
+func (m *Middleware) ListDictionaries(i *fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) {
+	o, err := m.Hook("ListDictionaries", i, func() (interface{}, error) {
+		return m.Client.ListDictionaries(i)
+	})
+	out, _ := o.([]*fastly.Dictionary)
+	return out, err
+}
+
+// UpdateDictionary implements Interface.
+func (m *Middleware) UpdateDictionary(i *fastly.UpdateDictionaryInput) (*fastly.Dictionary, error) {
+	o, err := m.Hook("UpdateDictionary", i, func() (interface{}, error) {
+		return m.Client.UpdateDictionary(i)
+	})
+	out, _ := o.(*fastly.Dictionary)
+	return out, err
+}
+
+// ListDictionaryItems implements Interface.
+func (m *Middleware) ListDictionaryItems(i *fastly.ListDictionaryItemsInput) ([]*fastly.DictionaryItem, error) {
+	o, err := m.Hook("ListDictionaryItems", i, func() (interface{}, error) {
+		return m.Client.ListDictionaryItems(i)
+	})
+	out, _ := o.([]*fastly.DictionaryItem)
+	return out, err
+}
+
+// GetDictionaryItem implements Interface.
+func (m *Middleware) GetDictionaryItem(i *fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	o, err := m.Hook("GetDictionaryItem", i, func() (interface{}, error) {
+		return m.Client.GetDictionaryItem(i)
+	})
+	out, _ := o.(*fastly.DictionaryItem)
+	return out, err
+}
+
+// CreateDictionaryItem implements Interface.
+func (m *Middleware) CreateDictionaryItem(i *fastly.CreateDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	o, err := m.Hook("CreateDictionaryItem", i, func() (interface{}, error) {
+		return m.Client.CreateDictionaryItem(i)
+	})
+	out, _ := o.(*fastly.DictionaryItem)
+	return out, err
+}
+
+// UpdateDictionaryItem implements Interface.
+func (m *Middleware) UpdateDictionaryItem(i *fastly.UpdateDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	o, err := m.Hook("UpdateDictionaryItem", i, func() (interface{}, error) {
+		return m.Client.UpdateDictionaryItem(i)
+	})
+	out, _ := o.(*fastly.DictionaryItem)
+	return out, err
+}
+
+// DeleteDictionaryItem implements Interface.
+func (m *Middleware) DeleteDictionaryItem(i *fastly.DeleteDictionaryItemInput) error {
+	_, err := m.Hook("DeleteDictionaryItem", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteDictionaryItem(i)
+	})
+	return err
+}
+
+// BatchModifyDictionaryItems implements Interface.
+func (m *Middleware) BatchModifyDictionaryItems(i *fastly.BatchModifyDictionaryItemsInput) error {
+	_, err := m.Hook("BatchModifyDictionaryItems", i, func() (interface{}, error) {
+		return nil, m.Client.BatchModifyDictionaryItems(i)
+	})
+	return err
+}
+
+// GetDictionaryInfo implements Interface.
+func (m *Middleware) GetDictionaryInfo(i *fastly.GetDictionaryInfoInput) (*fastly.DictionaryInfo, error) {
+	o, err := m.Hook("GetDictionaryInfo", i, func() (interface{}, error) {
+		return m.Client.GetDictionaryInfo(i)
+	})
+	out, _ := o.(*fastly.DictionaryInfo)
+	return out, err
+}
+
+// CreateCondition implements Interface.
+func (m *Middleware) CreateCondition(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	o, err := m.Hook("CreateCondition", i, func() (interface{}, error) {
+		return m.Client.CreateCondition(i)
+	})
+	out, _ := o.(*fastly.Condition)
+	return out, err
+}
+
+// ListConditions implements Interface.
+func (m *Middleware) ListConditions(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+	o, err := m.Hook("ListConditions", i, func() (interface{}, error) {
+		return m.Client.ListConditions(i)
+	})
+	out, _ := o.([]*fastly.Condition)
+	return out, err
+}
+
+// CreateHeader implements Interface.
+func (m *Middleware) CreateHeader(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+	o, err := m.Hook("CreateHeader", i, func() (interface{}, error) {
+		return m.Client.CreateHeader(i)
+	})
+	out, _ := o.(*fastly.Header)
+	return out, err
+}
+
+// ListHeaders implements Interface.
+func (m *Middleware) ListHeaders(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+	o, err := m.Hook("ListHeaders", i, func() (interface{}, error) {
+		return m.Client.ListHeaders(i)
+	})
+	out, _ := o.([]*fastly.Header)
+	return out, err
+}
+
+// CreateVCL implements Interface.
+func (m *Middleware) CreateVCL(i *fastly.CreateVCLInput) (*fastly.VCL, error) {
+	o, err := m.Hook("CreateVCL", i, func() (interface{}, error) {
+		return m.Client.CreateVCL(i)
+	})
+	out, _ := o.(*fastly.VCL)
+	return out, err
+}
+
+// ListVCLs implements Interface.
+func (m *Middleware) ListVCLs(i *fastly.ListVCLsInput) ([]*fastly.VCL, error) {
+	o, err := m.Hook("ListVCLs", i, func() (interface{}, error) {
+		return m.Client.ListVCLs(i)
+	})
+	out, _ := o.([]*fastly.VCL)
+	return out, err
+}
+
+// CreateSnippet implements Interface.
+func (m *Middleware) CreateSnippet(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+	o, err := m.Hook("CreateSnippet", i, func() (interface{}, error) {
+		return m.Client.CreateSnippet(i)
+	})
+	out, _ := o.(*fastly.Snippet)
+	return out, err
+}
+
+// ListSnippets implements Interface.
+func (m *Middleware) ListSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+	o, err := m.Hook("ListSnippets", i, func() (interface{}, error) {
+		return m.Client.ListSnippets(i)
+	})
+	out, _ := o.([]*fastly.Snippet)
+	return out, err
+}
+
+// CreateGzip implements Interface.
+func (m *Middleware) CreateGzip(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+	o, err := m.Hook("CreateGzip", i, func() (interface{}, error) {
+		return m.Client.CreateGzip(i)
+	})
+	out, _ := o.(*fastly.Gzip)
+	return out, err
+}
+
+// ListGzips implements Interface.
+func (m *Middleware) ListGzips(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+	o, err := m.Hook("ListGzips", i, func() (interface{}, error) {
+		return m.Client.ListGzips(i)
+	})
+	out, _ := o.([]*fastly.Gzip)
+	return out, err
+}
+
+// CreateCacheSetting implements Interface.
+func (m *Middleware) CreateCacheSetting(i *fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error) {
+	o, err := m.Hook("CreateCacheSetting", i, func() (interface{}, error) {
+		return m.Client.CreateCacheSetting(i)
+	})
+	out, _ := o.(*fastly.CacheSetting)
+	return out, err
+}
+
+// ListCacheSettings implements Interface.
+func (m *Middleware) ListCacheSettings(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	o, err := m.Hook("ListCacheSettings", i, func() (interface{}, error) {
+		return m.Client.ListCacheSettings(i)
+	})
+	out, _ := o.([]*fastly.CacheSetting)
+	return out, err
+}
+
+// CreateRequestSetting implements Interface.
+func (m *Middleware) CreateRequestSetting(i *fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error) {
+	o, err := m.Hook("CreateRequestSetting", i, func() (interface{}, error) {
+		return m.Client.CreateRequestSetting(i)
+	})
+	out, _ := o.(*fastly.RequestSetting)
+	return out, err
+}
+
+// ListRequestSettings implements Interface.
+func (m *Middleware) ListRequestSettings(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+	o, err := m.Hook("ListRequestSettings", i, func() (interface{}, error) {
+		return m.Client.ListRequestSettings(i)
+	})
+	out, _ := o.([]*fastly.RequestSetting)
+	return out, err
+}
+
+// CreateResponseObject implements Interface.
+func (m *Middleware) CreateResponseObject(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+	o, err := m.Hook("CreateResponseObject", i, func() (interface{}, error) {
+		return m.Client.CreateResponseObject(i)
+	})
+	out, _ := o.(*fastly.ResponseObject)
+	return out, err
+}
+
+// ListResponseObjects implements Interface.
+func (m *Middleware) ListResponseObjects(i *fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error) {
+	o, err := m.Hook("ListResponseObjects", i, func() (interface{}, error) {
+		return m.Client.ListResponseObjects(i)
+	})
+	out, _ := o.([]*fastly.ResponseObject)
+	return out, err
+}
+
+// CreateBigQuery implements Interface.
+func (m *Middleware) CreateBigQuery(i *fastly.CreateBigQueryInput) (*fastly.BigQuery, error) {
+	o, err := m.Hook("CreateBigQuery", i, func() (interface{}, error) {
+		return m.Client.CreateBigQuery(i)
+	})
+	out, _ := o.(*fastly.BigQuery)
+	return out, err
+}
+
+// ListBigQueries implements Interface.
+func (m *Middleware) ListBigQueries(i *fastly.ListBigQueriesInput) ([]*fastly.BigQuery, error) {
+	o, err := m.Hook("ListBigQueries", i, func() (interface{}, error) {
+		return m.Client.ListBigQueries(i)
+	})
+	out, _ := o.([]*fastly.BigQuery)
+	return out, err
+}
+
+// GetBigQuery implements Interface.
+func (m *Middleware) GetBigQuery(i *fastly.GetBigQueryInput) (*fastly.BigQuery, error) {
+	o, err := m.Hook("GetBigQuery", i, func() (interface{}, error) {
+		return m.Client.GetBigQuery(i)
+	})
+	out, _ := o.(*fastly.BigQuery)
+	return out, err
+}
+
+// UpdateBigQuery implements Interface.
+func (m *Middleware) UpdateBigQuery(i *fastly.UpdateBigQueryInput) (*fastly.BigQuery, error) {
+	o, err := m.Hook("UpdateBigQuery", i, func() (interface{}, error) {
+		return m.Client.UpdateBigQuery(i)
+	})
+	out, _ := o.(*fastly.BigQuery)
+	return out, err
+}
+
+// DeleteBigQuery implements Interface.
+func (m *Middleware) DeleteBigQuery(i *fastly.DeleteBigQueryInput) error {
+	_, err := m.Hook("DeleteBigQuery", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteBigQuery(i)
+	})
+	return err
+}
+
+// CreateS3 implements Interface.
+func (m *Middleware) CreateS3(i *fastly.CreateS3Input) (*fastly.S3, error) {
+	o, err := m.Hook("CreateS3", i, func() (interface{}, error) {
+		return m.Client.CreateS3(i)
+	})
+	out, _ := o.(*fastly.S3)
+	return out, err
+}
+
+// ListS3s implements Interface.
+func (m *Middleware) ListS3s(i *fastly.ListS3sInput) ([]*fastly.S3, error) {
+	o, err := m.Hook("ListS3s", i, func() (interface{}, error) {
+		return m.Client.ListS3s(i)
+	})
+	out, _ := o.([]*fastly.S3)
+	return out, err
+}
+
+// GetS3 implements Interface.
+func (m *Middleware) GetS3(i *fastly.GetS3Input) (*fastly.S3, error) {
+	o, err := m.Hook("GetS3", i, func() (interface{}, error) {
+		return m.Client.GetS3(i)
+	})
+	out, _ := o.(*fastly.S3)
+	return out, err
+}
+
+// UpdateS3 implements Interface.
+func (m *Middleware) UpdateS3(i *fastly.UpdateS3Input) (*fastly.S3, error) {
+	o, err := m.Hook("UpdateS3", i, func() (interface{}, error) {
+		return m.Client.UpdateS3(i)
+	})
+	out, _ := o.(*fastly.S3)
+	return out, err
+}
+
+// DeleteS3 implements Interface.
+func (m *Middleware) DeleteS3(i *fastly.DeleteS3Input) error {
+	_, err := m.Hook("DeleteS3", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteS3(i)
+	})
+	return err
+}
+
+// CreateKinesis implements Interface.
+func (m *Middleware) CreateKinesis(i *fastly.CreateKinesisInput) (*fastly.Kinesis, error) {
+	o, err := m.Hook("CreateKinesis", i, func() (interface{}, error) {
+		return m.Client.CreateKinesis(i)
+	})
+	out, _ := o.(*fastly.Kinesis)
+	return out, err
+}
+
+// ListKineses implements Interface.
+func (m *Middleware) ListKineses(i *fastly.ListKinesesInput) ([]*fastly.Kinesis, error) {
+	o, err := m.Hook("ListKineses", i, func() (interface{}, error) {
+		return m.Client.ListKineses(i)
+	})
+	out, _ := o.([]*fastly.Kinesis)
+	return out, err
+}
+
+// GetKinesis implements Interface.
+func (m *Middleware) GetKinesis(i *fastly.GetKinesisInput) (*fastly.Kinesis, error) {
+	o, err := m.Hook("GetKinesis", i, func() (interface{}, error) {
+		return m.Client.GetKinesis(i)
+	})
+	out, _ := o.(*fastly.Kinesis)
+	return out, err
+}
+
+// UpdateKinesis implements Interface.
+func (m *Middleware) UpdateKinesis(i *fastly.UpdateKinesisInput) (*fastly.Kinesis, error) {
+	o, err := m.Hook("UpdateKinesis", i, func() (interface{}, error) {
+		return m.Client.UpdateKinesis(i)
+	})
+	out, _ := o.(*fastly.Kinesis)
+	return out, err
+}
+
+// DeleteKinesis implements Interface.
+func (m *Middleware) DeleteKinesis(i *fastly.DeleteKinesisInput) error {
+	_, err := m.Hook("DeleteKinesis", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteKinesis(i)
+	})
+	return err
+}
+
+// CreateSyslog implements Interface.
+func (m *Middleware) CreateSyslog(i *fastly.CreateSyslogInput) (*fastly.Syslog, error) {
+	o, err := m.Hook("CreateSyslog", i, func() (interface{}, error) {
+		return m.Client.CreateSyslog(i)
+	})
+	out, _ := o.(*fastly.Syslog)
+	return out, err
+}
+
+// ListSyslogs implements Interface.
+func (m *Middleware) ListSyslogs(i *fastly.ListSyslogsInput) ([]*fastly.Syslog, error) {
+	o, err := m.Hook("ListSyslogs", i, func() (interface{}, error) {
+		return m.Client.ListSyslogs(i)
+	})
+	out, _ := o.([]*fastly.Syslog)
+	return out, err
+}
+
+// GetSyslog implements Interface.
+func (m *Middleware) GetSyslog(i *fastly.GetSyslogInput) (*fastly.Syslog, error) {
+	o, err := m.Hook("GetSyslog", i, func() (interface{}, error) {
+		return m.Client.GetSyslog(i)
+	})
+	out, _ := o.(*fastly.Syslog)
+	return out, err
+}
+
+// UpdateSyslog implements Interface.
+func (m *Middleware) UpdateSyslog(i *fastly.UpdateSyslogInput) (*fastly.Syslog, error) {
+	o, err := m.Hook("UpdateSyslog", i, func() (interface{}, error) {
+		return m.Client.UpdateSyslog(i)
+	})
+	out, _ := o.(*fastly.Syslog)
+	return out, err
+}
+
+// DeleteSyslog implements Interface.
+func (m *Middleware) DeleteSyslog(i *fastly.DeleteSyslogInput) error {
+	_, err := m.Hook("DeleteSyslog", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteSyslog(i)
+	})
+	return err
+}
+
+// CreateLogentries implements Interface.
+func (m *Middleware) CreateLogentries(i *fastly.CreateLogentriesInput) (*fastly.Logentries, error) {
+	o, err := m.Hook("CreateLogentries", i, func() (interface{}, error) {
+		return m.Client.CreateLogentries(i)
+	})
+	out, _ := o.(*fastly.Logentries)
+	return out, err
+}
+
+// ListLogentries implements Interface.
+func (m *Middleware) ListLogentries(i *fastly.ListLogentriesInput) ([]*fastly.Logentries, error) {
+	o, err := m.Hook("ListLogentries", i, func() (interface{}, error) {
+		return m.Client.ListLogentries(i)
+	})
+	out, _ := o.([]*fastly.Logentries)
+	return out, err
+}
+
+// GetLogentries implements Interface.
+func (m *Middleware) GetLogentries(i *fastly.GetLogentriesInput) (*fastly.Logentries, error) {
+	o, err := m.Hook("GetLogentries", i, func() (interface{}, error) {
+		return m.Client.GetLogentries(i)
+	})
+	out, _ := o.(*fastly.Logentries)
+	return out, err
+}
+
+// UpdateLogentries implements Interface.
+func (m *Middleware) UpdateLogentries(i *fastly.UpdateLogentriesInput) (*fastly.Logentries, error) {
+	o, err := m.Hook("UpdateLogentries", i, func() (interface{}, error) {
+		return m.Client.UpdateLogentries(i)
+	})
+	out, _ := o.(*fastly.Logentries)
+	return out, err
+}
+
+// DeleteLogentries implements Interface.
+func (m *Middleware) DeleteLogentries(i *fastly.DeleteLogentriesInput) error {
+	_, err := m.Hook("DeleteLogentries", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteLogentries(i)
+	})
+	return err
+}
+
+// CreatePapertrail implements Interface.
+func (m *Middleware) CreatePapertrail(i *fastly.CreatePapertrailInput) (*fastly.Papertrail, error) {
+	o, err := m.Hook("CreatePapertrail", i, func() (interface{}, error) {
+		return m.Client.CreatePapertrail(i)
+	})
+	out, _ := o.(*fastly.Papertrail)
+	return out, err
+}
+
+// ListPapertrails implements Interface.
+func (m *Middleware) ListPapertrails(i *fastly.ListPapertrailsInput) ([]*fastly.Papertrail, error) {
+	o, err := m.Hook("ListPapertrails", i, func() (interface{}, error) {
+		return m.Client.ListPapertrails(i)
+	})
+	out, _ := o.([]*fastly.Papertrail)
+	return out, err
+}
+
+// GetPapertrail implements Interface.
+func (m *Middleware) GetPapertrail(i *fastly.GetPapertrailInput) (*fastly.Papertrail, error) {
+	o, err := m.Hook("GetPapertrail", i, func() (interface{}, error) {
+		return m.Client.GetPapertrail(i)
+	})
+	out, _ := o.(*fastly.Papertrail)
+	return out, err
+}
+
+// UpdatePapertrail implements Interface.
+func (m *Middleware) UpdatePapertrail(i *fastly.UpdatePapertrailInput) (*fastly.Papertrail, error) {
+	o, err := m.Hook("UpdatePapertrail", i, func() (interface{}, error) {
+		return m.Client.UpdatePapertrail(i)
+	})
+	out, _ := o.(*fastly.Papertrail)
+	return out, err
+}
+
+// DeletePapertrail implements Interface.
+func (m *Middleware) DeletePapertrail(i *fastly.DeletePapertrailInput) error {
+	_, err := m.Hook("DeletePapertrail", i, func() (interface{}, error) {
+		return nil, m.Client.DeletePapertrail(i)
+	})
+	return err
+}
+
+// CreateSumologic implements Interface.
+func (m *Middleware) CreateSumologic(i *fastly.CreateSumologicInput) (*fastly.Sumologic, error) {
+	o, err := m.Hook("CreateSumologic", i, func() (interface{}, error) {
+		return m.Client.CreateSumologic(i)
+	})
+	out, _ := o.(*fastly.Sumologic)
+	return out, err
+}
+
+// ListSumologics implements Interface.
+func (m *Middleware) ListSumologics(i *fastly.ListSumologicsInput) ([]*fastly.Sumologic, error) {
+	o, err := m.Hook("ListSumologics", i, func() (interface{}, error) {
+		return m.Client.ListSumologics(i)
+	})
+	out, _ := o.([]*fastly.Sumologic)
+	return out, err
+}
+
+// GetSumologic implements Interface.
+func (m *Middleware) GetSumologic(i *fastly.GetSumologicInput) (*fastly.Sumologic, error) {
+	o, err := m.Hook("GetSumologic", i, func() (interface{}, error) {
+		return m.Client.GetSumologic(i)
+	})
+	out, _ := o.(*fastly.Sumologic)
+	return out, err
+}
+
+// UpdateSumologic implements Interface.
+func (m *Middleware) UpdateSumologic(i *fastly.UpdateSumologicInput) (*fastly.Sumologic, error) {
+	o, err := m.Hook("UpdateSumologic", i, func() (interface{}, error) {
+		return m.Client.UpdateSumologic(i)
+	})
+	out, _ := o.(*fastly.Sumologic)
+	return out, err
+}
+
+// DeleteSumologic implements Interface.
+func (m *Middleware) DeleteSumologic(i *fastly.DeleteSumologicInput) error {
+	_, err := m.Hook("DeleteSumologic", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteSumologic(i)
+	})
+	return err
+}
+
+// CreateGCS implements Interface.
+func (m *Middleware) CreateGCS(i *fastly.CreateGCSInput) (*fastly.GCS, error) {
+	o, err := m.Hook("CreateGCS", i, func() (interface{}, error) {
+		return m.Client.CreateGCS(i)
+	})
+	out, _ := o.(*fastly.GCS)
+	return out, err
+}
+
+// ListGCSs implements Interface.
+func (m *Middleware) ListGCSs(i *fastly.ListGCSsInput) ([]*fastly.GCS, error) {
+	o, err := m.Hook("ListGCSs", i, func() (interface{}, error) {
+		return m.Client.ListGCSs(i)
+	})
+	out, _ := o.([]*fastly.GCS)
+	return out, err
+}
+
+// GetGCS implements Interface.
+func (m *Middleware) GetGCS(i *fastly.GetGCSInput) (*fastly.GCS, error) {
+	o, err := m.Hook("GetGCS", i, func() (interface{}, error) {
+		return m.Client.GetGCS(i)
+	})
+	out, _ := o.(*fastly.GCS)
+	return out, err
+}
+
+// UpdateGCS implements Interface.
+func (m *Middleware) UpdateGCS(i *fastly.UpdateGCSInput) (*fastly.GCS, error) {
+	o, err := m.Hook("UpdateGCS", i, func() (interface{}, error) {
+		return m.Client.UpdateGCS(i)
+	})
+	out, _ := o.(*fastly.GCS)
+	return out, err
+}
+
+// DeleteGCS implements Interface.
+func (m *Middleware) DeleteGCS(i *fastly.DeleteGCSInput) error {
+	_, err := m.Hook("DeleteGCS", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteGCS(i)
+	})
+	return err
+}
+
+// CreateFTP implements Interface.
+func (m *Middleware) CreateFTP(i *fastly.CreateFTPInput) (*fastly.FTP, error) {
+	o, err := m.Hook("CreateFTP", i, func() (interface{}, error) {
+		return m.Client.CreateFTP(i)
+	})
+	out, _ := o.(*fastly.FTP)
+	return out, err
+}
+
+// ListFTPs implements Interface.
+func (m *Middleware) ListFTPs(i *fastly.ListFTPsInput) ([]*fastly.FTP, error) {
+	o, err := m.Hook("ListFTPs", i, func() (interface{}, error) {
+		return m.Client.ListFTPs(i)
+	})
+	out, _ := o.([]*fastly.FTP)
+	return out, err
+}
+
+// GetFTP implements Interface.
+func (m *Middleware) GetFTP(i *fastly.GetFTPInput) (*fastly.FTP, error) {
+	o, err := m.Hook("GetFTP", i, func() (interface{}, error) {
+		return m.Client.GetFTP(i)
+	})
+	out, _ := o.(*fastly.FTP)
+	return out, err
+}
+
+// UpdateFTP implements Interface.
+func (m *Middleware) UpdateFTP(i *fastly.UpdateFTPInput) (*fastly.FTP, error) {
+	o, err := m.Hook("UpdateFTP", i, func() (interface{}, error) {
+		return m.Client.UpdateFTP(i)
+	})
+	out, _ := o.(*fastly.FTP)
+	return out, err
+}
+
+// DeleteFTP implements Interface.
+func (m *Middleware) DeleteFTP(i *fastly.DeleteFTPInput) error {
+	_, err := m.Hook("DeleteFTP", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteFTP(i)
+	})
+	return err
+}
+
+// CreateSplunk implements Interface.
+func (m *Middleware) CreateSplunk(i *fastly.CreateSplunkInput) (*fastly.Splunk, error) {
+	o, err := m.Hook("CreateSplunk", i, func() (interface{}, error) {
+		return m.Client.CreateSplunk(i)
+	})
+	out, _ := o.(*fastly.Splunk)
+	return out, err
+}
+
+// ListSplunks implements Interface.
+func (m *Middleware) ListSplunks(i *fastly.ListSplunksInput) ([]*fastly.Splunk, error) {
+	o, err := m.Hook("ListSplunks", i, func() (interface{}, error) {
+		return m.Client.ListSplunks(i)
+	})
+	out, _ := o.([]*fastly.Splunk)
+	return out, err
+}
+
+// GetSplunk implements Interface.
+func (m *Middleware) GetSplunk(i *fastly.GetSplunkInput) (*fastly.Splunk, error) {
+	o, err := m.Hook("GetSplunk", i, func() (interface{}, error) {
+		return m.Client.GetSplunk(i)
+	})
+	out, _ := o.(*fastly.Splunk)
+	return out, err
+}
+
+// UpdateSplunk implements Interface.
+func (m *Middleware) UpdateSplunk(i *fastly.UpdateSplunkInput) (*fastly.Splunk, error) {
+	o, err := m.Hook("UpdateSplunk", i, func() (interface{}, error) {
+		return m.Client.UpdateSplunk(i)
+	})
+	out, _ := o.(*fastly.Splunk)
+	return out, err
+}
+
+// DeleteSplunk implements Interface.
+func (m *Middleware) DeleteSplunk(i *fastly.DeleteSplunkInput) error {
+	_, err := m.Hook("DeleteSplunk", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteSplunk(i)
+	})
+	return err
+}
+
+// CreateScalyr implements Interface.
+func (m *Middleware) CreateScalyr(i *fastly.CreateScalyrInput) (*fastly.Scalyr, error) {
+	o, err := m.Hook("CreateScalyr", i, func() (interface{}, error) {
+		return m.Client.CreateScalyr(i)
+	})
+	out, _ := o.(*fastly.Scalyr)
+	return out, err
+}
+
+// ListScalyrs implements Interface.
+func (m *Middleware) ListScalyrs(i *fastly.ListScalyrsInput) ([]*fastly.Scalyr, error) {
+	o, err := m.Hook("ListScalyrs", i, func() (interface{}, error) {
+		return m.Client.ListScalyrs(i)
+	})
+	out, _ := o.([]*fastly.Scalyr)
+	return out, err
+}
+
+// GetScalyr implements Interface.
+func (m *Middleware) GetScalyr(i *fastly.GetScalyrInput) (*fastly.Scalyr, error) {
+	o, err := m.Hook("GetScalyr", i, func() (interface{}, error) {
+		return m.Client.GetScalyr(i)
+	})
+	out, _ := o.(*fastly.Scalyr)
+	return out, err
+}
+
+// UpdateScalyr implements Interface.
+func (m *Middleware) UpdateScalyr(i *fastly.UpdateScalyrInput) (*fastly.Scalyr, error) {
+	o, err := m.Hook("UpdateScalyr", i, func() (interface{}, error) {
+		return m.Client.UpdateScalyr(i)
+	})
+	out, _ := o.(*fastly.Scalyr)
+	return out, err
+}
+
+// DeleteScalyr implements Interface.
+func (m *Middleware) DeleteScalyr(i *fastly.DeleteScalyrInput) error {
+	_, err := m.Hook("DeleteScalyr", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteScalyr(i)
+	})
+	return err
+}
+
+// CreateLoggly implements Interface.
+func (m *Middleware) CreateLoggly(i *fastly.CreateLogglyInput) (*fastly.Loggly, error) {
+	o, err := m.Hook("CreateLoggly", i, func() (interface{}, error) {
+		return m.Client.CreateLoggly(i)
+	})
+	out, _ := o.(*fastly.Loggly)
+	return out, err
+}
+
+// ListLoggly implements Interface.
+func (m *Middleware) ListLoggly(i *fastly.ListLogglyInput) ([]*fastly.Loggly, error) {
+	o, err := m.Hook("ListLoggly", i, func() (interface{}, error) {
+		return m.Client.ListLoggly(i)
+	})
+	out, _ := o.([]*fastly.Loggly)
+	return out, err
+}
+
+// GetLoggly implements Interface.
+func (m *Middleware) GetLoggly(i *fastly.GetLogglyInput) (*fastly.Loggly, error) {
+	o, err := m.Hook("GetLoggly", i, func() (interface{}, error) {
+		return m.Client.GetLoggly(i)
+	})
+	out, _ := o.(*fastly.Loggly)
+	return out, err
+}
+
+// UpdateLoggly implements Interface.
+func (m *Middleware) UpdateLoggly(i *fastly.UpdateLogglyInput) (*fastly.Loggly, error) {
+	o, err := m.Hook("UpdateLoggly", i, func() (interface{}, error) {
+		return m.Client.UpdateLoggly(i)
+	})
+	out, _ := o.(*fastly.Loggly)
+	return out, err
+}
+
+// DeleteLoggly implements Interface.
+func (m *Middleware) DeleteLoggly(i *fastly.DeleteLogglyInput) error {
+	_, err := m.Hook("DeleteLoggly", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteLoggly(i)
+	})
+	return err
+}
+
+// CreateHoneycomb implements Interface.
+func (m *Middleware) CreateHoneycomb(i *fastly.CreateHoneycombInput) (*fastly.Honeycomb, error) {
+	o, err := m.Hook("CreateHoneycomb", i, func() (interface{}, error) {
+		return m.Client.CreateHoneycomb(i)
+	})
+	out, _ := o.(*fastly.Honeycomb)
+	return out, err
+}
+
+// ListHoneycombs implements Interface.
+func (m *Middleware) ListHoneycombs(i *fastly.ListHoneycombsInput) ([]*fastly.Honeycomb, error) {
+	o, err := m.Hook("ListHoneycombs", i, func() (interface{}, error) {
+		return m.Client.ListHoneycombs(i)
+	})
+	out, _ := o.([]*fastly.Honeycomb)
+	return out, err
+}
+
+// GetHoneycomb implements Interface.
+func (m *Middleware) GetHoneycomb(i *fastly.GetHoneycombInput) (*fastly.Honeycomb, error) {
+	o, err := m.Hook("GetHoneycomb", i, func() (interface{}, error) {
+		return m.Client.GetHoneycomb(i)
+	})
+	out, _ := o.(*fastly.Honeycomb)
+	return out, err
+}
+
+// UpdateHoneycomb implements Interface.
+func (m *Middleware) UpdateHoneycomb(i *fastly.UpdateHoneycombInput) (*fastly.Honeycomb, error) {
+	o, err := m.Hook("UpdateHoneycomb", i, func() (interface{}, error) {
+		return m.Client.UpdateHoneycomb(i)
+	})
+	out, _ := o.(*fastly.Honeycomb)
+	return out, err
+}
+
+// DeleteHoneycomb implements Interface.
+func (m *Middleware) DeleteHoneycomb(i *fastly.DeleteHoneycombInput) error {
+	_, err := m.Hook("DeleteHoneycomb", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteHoneycomb(i)
+	})
+	return err
+}
+
+// CreateHeroku implements Interface.
+func (m *Middleware) CreateHeroku(i *fastly.CreateHerokuInput) (*fastly.Heroku, error) {
+	o, err := m.Hook("CreateHeroku", i, func() (interface{}, error) {
+		return m.Client.CreateHeroku(i)
+	})
+	out, _ := o.(*fastly.Heroku)
+	return out, err
+}
+
+// ListHerokus implements Interface.
+func (m *Middleware) ListHerokus(i *fastly.ListHerokusInput) ([]*fastly.Heroku, error) {
+	o, err := m.Hook("ListHerokus", i, func() (interface{}, error) {
+		return m.Client.ListHerokus(i)
+	})
+	out, _ := o.([]*fastly.Heroku)
+	return out, err
+}
+
+// GetHeroku implements Interface.
+func (m *Middleware) GetHeroku(i *fastly.GetHerokuInput) (*fastly.Heroku, error) {
+	o, err := m.Hook("GetHeroku", i, func() (interface{}, error) {
+		return m.Client.GetHeroku(i)
+	})
+	out, _ := o.(*fastly.Heroku)
+	return out, err
+}
+
+// UpdateHeroku implements Interface.
+func (m *Middleware) UpdateHeroku(i *fastly.UpdateHerokuInput) (*fastly.Heroku, error) {
+	o, err := m.Hook("UpdateHeroku", i, func() (interface{}, error) {
+		return m.Client.UpdateHeroku(i)
+	})
+	out, _ := o.(*fastly.Heroku)
+	return out, err
+}
+
+// DeleteHeroku implements Interface.
+func (m *Middleware) DeleteHeroku(i *fastly.DeleteHerokuInput) error {
+	_, err := m.Hook("DeleteHeroku", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteHeroku(i)
+	})
+	return err
+}
+
+// CreateSFTP implements Interface.
+func (m *Middleware) CreateSFTP(i *fastly.CreateSFTPInput) (*fastly.SFTP, error) {
+	o, err := m.Hook("CreateSFTP", i, func() (interface{}, error) {
+		return m.Client.CreateSFTP(i)
+	})
+	out, _ := o.(*fastly.SFTP)
+	return out, err
+}
+
+// ListSFTPs implements Interface.
+func (m *Middleware) ListSFTPs(i *fastly.ListSFTPsInput) ([]*fastly.SFTP, error) {
+	o, err := m.Hook("ListSFTPs", i, func() (interface{}, error) {
+		return m.Client.ListSFTPs(i)
+	})
+	out, _ := o.([]*fastly.SFTP)
+	return out, err
+}
+
+// GetSFTP implements Interface.
+func (m *Middleware) GetSFTP(i *fastly.GetSFTPInput) (*fastly.SFTP, error) {
+	o, err := m.Hook("GetSFTP", i, func() (interface{}, error) {
+		return m.Client.GetSFTP(i)
+	})
+	out, _ := o.(*fastly.SFTP)
+	return out, err
+}
+
+// UpdateSFTP implements Interface.
+func (m *Middleware) UpdateSFTP(i *fastly.UpdateSFTPInput) (*fastly.SFTP, error) {
+	o, err := m.Hook("UpdateSFTP", i, func() (interface{}, error) {
+		return m.Client.UpdateSFTP(i)
+	})
+	out, _ := o.(*fastly.SFTP)
+	return out, err
+}
+
+// DeleteSFTP implements Interface.
+func (m *Middleware) DeleteSFTP(i *fastly.DeleteSFTPInput) error {
+	_, err := m.Hook("DeleteSFTP", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteSFTP(i)
+	})
+	return err
+}
+
+// CreateLogshuttle implements Interface.
+func (m *Middleware) CreateLogshuttle(i *fastly.CreateLogshuttleInput) (*fastly.Logshuttle, error) {
+	o, err := m.Hook("CreateLogshuttle", i, func() (interface{}, error) {
+		return m.Client.CreateLogshuttle(i)
+	})
+	out, _ := o.(*fastly.Logshuttle)
+	return out, err
+}
+
+// ListLogshuttles implements Interface.
+func (m *Middleware) ListLogshuttles(i *fastly.ListLogshuttlesInput) ([]*fastly.Logshuttle, error) {
+	o, err := m.Hook("ListLogshuttles", i, func() (interface{}, error) {
+		return m.Client.ListLogshuttles(i)
+	})
+	out, _ := o.([]*fastly.Logshuttle)
+	return out, err
+}
+
+// GetLogshuttle implements Interface.
+func (m *Middleware) GetLogshuttle(i *fastly.GetLogshuttleInput) (*fastly.Logshuttle, error) {
+	o, err := m.Hook("GetLogshuttle", i, func() (interface{}, error) {
+		return m.Client.GetLogshuttle(i)
+	})
+	out, _ := o.(*fastly.Logshuttle)
+	return out, err
+}
+
+// UpdateLogshuttle implements Interface.
+func (m *Middleware) UpdateLogshuttle(i *fastly.UpdateLogshuttleInput) (*fastly.Logshuttle, error) {
+	o, err := m.Hook("UpdateLogshuttle", i, func() (interface{}, error) {
+		return m.Client.UpdateLogshuttle(i)
+	})
+	out, _ := o.(*fastly.Logshuttle)
+	return out, err
+}
+
+// DeleteLogshuttle implements Interface.
+func (m *Middleware) DeleteLogshuttle(i *fastly.DeleteLogshuttleInput) error {
+	_, err := m.Hook("DeleteLogshuttle", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteLogshuttle(i)
+	})
+	return err
+}
+
+// CreateCloudfiles implements Interface.
+func (m *Middleware) CreateCloudfiles(i *fastly.CreateCloudfilesInput) (*fastly.Cloudfiles, error) {
+	o, err := m.Hook("CreateCloudfiles", i, func() (interface{}, error) {
+		return m.Client.CreateCloudfiles(i)
+	})
+	out, _ := o.(*fastly.Cloudfiles)
+	return out, err
+}
+
+// ListCloudfiles implements Interface.
+func (m *Middleware) ListCloudfiles(i *fastly.ListCloudfilesInput) ([]*fastly.Cloudfiles, error) {
+	o, err := m.Hook("ListCloudfiles", i, func() (interface{}, error) {
+		return m.Client.ListCloudfiles(i)
+	})
+	out, _ := o.([]*fastly.Cloudfiles)
+	return out, err
+}
+
+// GetCloudfiles implements Interface.
+func (m *Middleware) GetCloudfiles(i *fastly.GetCloudfilesInput) (*fastly.Cloudfiles, error) {
+	o, err := m.Hook("GetCloudfiles", i, func() (interface{}, error) {
+		return m.Client.GetCloudfiles(i)
+	})
+	out, _ := o.(*fastly.Cloudfiles)
+	return out, err
+}
+
+// UpdateCloudfiles implements Interface.
+func (m *Middleware) UpdateCloudfiles(i *fastly.UpdateCloudfilesInput) (*fastly.Cloudfiles, error) {
+	o, err := m.Hook("UpdateCloudfiles", i, func() (interface{}, error) {
+		return m.Client.UpdateCloudfiles(i)
+	})
+	out, _ := o.(*fastly.Cloudfiles)
+	return out, err
+}
+
+// DeleteCloudfiles implements Interface.
+func (m *Middleware) DeleteCloudfiles(i *fastly.DeleteCloudfilesInput) error {
+	_, err := m.Hook("DeleteCloudfiles", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteCloudfiles(i)
+	})
+	return err
+}
+
+// CreateDigitalOcean implements Interface.
+func (m *Middleware) CreateDigitalOcean(i *fastly.CreateDigitalOceanInput) (*fastly.DigitalOcean, error) {
+	o, err := m.Hook("CreateDigitalOcean", i, func() (interface{}, error) {
+		return m.Client.CreateDigitalOcean(i)
+	})
+	out, _ := o.(*fastly.DigitalOcean)
+	return out, err
+}
+
+// ListDigitalOceans implements Interface.
+func (m *Middleware) ListDigitalOceans(i *fastly.ListDigitalOceansInput) ([]*fastly.DigitalOcean, error) {
+	o, err := m.Hook("ListDigitalOceans", i, func() (interface{}, error) {
+		return m.Client.ListDigitalOceans(i)
+	})
+	out, _ := o.([]*fastly.DigitalOcean)
+	return out, err
+}
+
+// GetDigitalOcean implements Interface.
+func (m *Middleware) GetDigitalOcean(i *fastly.GetDigitalOceanInput) (*fastly.DigitalOcean, error) {
+	o, err := m.Hook("GetDigitalOcean", i, func() (interface{}, error) {
+		return m.Client.GetDigitalOcean(i)
+	})
+	out, _ := o.(*fastly.DigitalOcean)
+	return out, err
+}
+
+// UpdateDigitalOcean implements Interface.
+func (m *Middleware) UpdateDigitalOcean(i *fastly.UpdateDigitalOceanInput) (*fastly.DigitalOcean, error) {
+	o, err := m.Hook("UpdateDigitalOcean", i, func() (interface{}, error) {
+		return m.Client.UpdateDigitalOcean(i)
+	})
+	out, _ := o.(*fastly.DigitalOcean)
+	return out, err
+}
+
+// DeleteDigitalOcean implements Interface.
+func (m *Middleware) DeleteDigitalOcean(i *fastly.DeleteDigitalOceanInput) error {
+	_, err := m.Hook("DeleteDigitalOcean", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteDigitalOcean(i)
+	})
+	return err
+}
+
+// CreateElasticsearch implements Interface.
+func (m *Middleware) CreateElasticsearch(i *fastly.CreateElasticsearchInput) (*fastly.Elasticsearch, error) {
+	o, err := m.Hook("CreateElasticsearch", i, func() (interface{}, error) {
+		return m.Client.CreateElasticsearch(i)
+	})
+	out, _ := o.(*fastly.Elasticsearch)
+	return out, err
+}
+
+// ListElasticsearch implements Interface.
+func (m *Middleware) ListElasticsearch(i *fastly.ListElasticsearchInput) ([]*fastly.Elasticsearch, error) {
+	o, err := m.Hook("ListElasticsearch", i, func() (interface{}, error) {
+		return m.Client.ListElasticsearch(i)
+	})
+	out, _ := o.([]*fastly.Elasticsearch)
+	return out, err
+}
+
+// GetElasticsearch implements Interface.
+func (m *Middleware) GetElasticsearch(i *fastly.GetElasticsearchInput) (*fastly.Elasticsearch, error) {
+	o, err := m.Hook("GetElasticsearch", i, func() (interface{}, error) {
+		return m.Client.GetElasticsearch(i)
+	})
+	out, _ := o.(*fastly.Elasticsearch)
+	return out, err
+}
+
+// UpdateElasticsearch implements Interface.
+func (m *Middleware) UpdateElasticsearch(i *fastly.UpdateElasticsearchInput) (*fastly.Elasticsearch, error) {
+	o, err := m.Hook("UpdateElasticsearch", i, func() (interface{}, error) {
+		return m.Client.UpdateElasticsearch(i)
+	})
+	out, _ := o.(*fastly.Elasticsearch)
+	return out, err
+}
+
+// DeleteElasticsearch implements Interface.
+func (m *Middleware) DeleteElasticsearch(i *fastly.DeleteElasticsearchInput) error {
+	_, err := m.Hook("DeleteElasticsearch", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteElasticsearch(i)
+	})
+	return err
+}
+
+// CreateBlobStorage implements Interface.
+func (m *Middleware) CreateBlobStorage(i *fastly.CreateBlobStorageInput) (*fastly.BlobStorage, error) {
+	o, err := m.Hook("CreateBlobStorage", i, func() (interface{}, error) {
+		return m.Client.CreateBlobStorage(i)
+	})
+	out, _ := o.(*fastly.BlobStorage)
+	return out, err
+}
+
+// ListBlobStorages implements Interface.
+func (m *Middleware) ListBlobStorages(i *fastly.ListBlobStoragesInput) ([]*fastly.BlobStorage, error) {
+	o, err := m.Hook("ListBlobStorages", i, func() (interface{}, error) {
+		return m.Client.ListBlobStorages(i)
+	})
+	out, _ := o.([]*fastly.BlobStorage)
+	return out, err
+}
+
+// GetBlobStorage implements Interface.
+func (m *Middleware) GetBlobStorage(i *fastly.GetBlobStorageInput) (*fastly.BlobStorage, error) {
+	o, err := m.Hook("GetBlobStorage", i, func() (interface{}, error) {
+		return m.Client.GetBlobStorage(i)
+	})
+	out, _ := o.(*fastly.BlobStorage)
+	return out, err
+}
+
+// UpdateBlobStorage implements Interface.
+func (m *Middleware) UpdateBlobStorage(i *fastly.UpdateBlobStorageInput) (*fastly.BlobStorage, error) {
+	o, err := m.Hook("UpdateBlobStorage", i, func() (interface{}, error) {
+		return m.Client.UpdateBlobStorage(i)
+	})
+	out, _ := o.(*fastly.BlobStorage)
+	return out, err
+}
+
+// DeleteBlobStorage implements Interface.
+func (m *Middleware) DeleteBlobStorage(i *fastly.DeleteBlobStorageInput) error {
+	_, err := m.Hook("DeleteBlobStorage", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteBlobStorage(i)
+	})
+	return err
+}
+
+// CreateDatadog implements Interface.
+func (m *Middleware) CreateDatadog(i *fastly.CreateDatadogInput) (*fastly.Datadog, error) {
+	o, err := m.Hook("CreateDatadog", i, func() (interface{}, error) {
+		return m.Client.CreateDatadog(i)
+	})
+	out, _ := o.(*fastly.Datadog)
+	return out, err
+}
+
+// ListDatadog implements Interface.
+func (m *Middleware) ListDatadog(i *fastly.ListDatadogInput) ([]*fastly.Datadog, error) {
+	o, err := m.Hook("ListDatadog", i, func() (interface{}, error) {
+		return m.Client.ListDatadog(i)
+	})
+	out, _ := o.([]*fastly.Datadog)
+	return out, err
+}
+
+// GetDatadog implements Interface.
+func (m *Middleware) GetDatadog(i *fastly.GetDatadogInput) (*fastly.Datadog, error) {
+	o, err := m.Hook("GetDatadog", i, func() (interface{}, error) {
+		return m.Client.GetDatadog(i)
+	})
+	out, _ := o.(*fastly.Datadog)
+	return out, err
+}
+
+// UpdateDatadog implements Interface.
+func (m *Middleware) UpdateDatadog(i *fastly.UpdateDatadogInput) (*fastly.Datadog, error) {
+	o, err := m.Hook("UpdateDatadog", i, func() (interface{}, error) {
+		return m.Client.UpdateDatadog(i)
+	})
+	out, _ := o.(*fastly.Datadog)
+	return out, err
+}
+
+// DeleteDatadog implements Interface.
+func (m *Middleware) DeleteDatadog(i *fastly.DeleteDatadogInput) error {
+	_, err := m.Hook("DeleteDatadog", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteDatadog(i)
+	})
+	return err
+}
+
+// CreateHTTPS implements Interface.
+func (m *Middleware) CreateHTTPS(i *fastly.CreateHTTPSInput) (*fastly.HTTPS, error) {
+	o, err := m.Hook("CreateHTTPS", i, func() (interface{}, error) {
+		return m.Client.CreateHTTPS(i)
+	})
+	out, _ := o.(*fastly.HTTPS)
+	return out, err
+}
+
+// ListHTTPS implements Interface.
+func (m *Middleware) ListHTTPS(i *fastly.ListHTTPSInput) ([]*fastly.HTTPS, error) {
+	o, err := m.Hook("ListHTTPS", i, func() (interface{}, error) {
+		return m.Client.ListHTTPS(i)
+	})
+	out, _ := o.([]*fastly.HTTPS)
+	return out, err
+}
+
+// GetHTTPS implements Interface.
+func (m *Middleware) GetHTTPS(i *fastly.GetHTTPSInput) (*fastly.HTTPS, error) {
+	o, err := m.Hook("GetHTTPS", i, func() (interface{}, error) {
+		return m.Client.GetHTTPS(i)
+	})
+	out, _ := o.(*fastly.HTTPS)
+	return out, err
+}
+
+// UpdateHTTPS implements Interface.
+func (m *Middleware) UpdateHTTPS(i *fastly.UpdateHTTPSInput) (*fastly.HTTPS, error) {
+	o, err := m.Hook("UpdateHTTPS", i, func() (interface{}, error) {
+		return m.Client.UpdateHTTPS(i)
+	})
+	out, _ := o.(*fastly.HTTPS)
+	return out, err
+}
+
+// DeleteHTTPS implements Interface.
+func (m *Middleware) DeleteHTTPS(i *fastly.DeleteHTTPSInput) error {
+	_, err := m.Hook("DeleteHTTPS", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteHTTPS(i)
+	})
+	return err
+}
+
+// CreateKafka implements Interface.
+func (m *Middleware) CreateKafka(i *fastly.CreateKafkaInput) (*fastly.Kafka, error) {
+	o, err := m.Hook("CreateKafka", i, func() (interface{}, error) {
+		return m.Client.CreateKafka(i)
+	})
+	out, _ := o.(*fastly.Kafka)
+	return out, err
+}
+
+// ListKafkas implements Interface.
+func (m *Middleware) ListKafkas(i *fastly.ListKafkasInput) ([]*fastly.Kafka, error) {
+	o, err := m.Hook("ListKafkas", i, func() (interface{}, error) {
+		return m.Client.ListKafkas(i)
+	})
+	out, _ := o.([]*fastly.Kafka)
+	return out, err
+}
+
+// GetKafka implements Interface.
+func (m *Middleware) GetKafka(i *fastly.GetKafkaInput) (*fastly.Kafka, error) {
+	o, err := m.Hook("GetKafka", i, func() (interface{}, error) {
+		return m.Client.GetKafka(i)
+	})
+	out, _ := o.(*fastly.Kafka)
+	return out, err
+}
+
+// UpdateKafka implements Interface.
+func (m *Middleware) UpdateKafka(i *fastly.UpdateKafkaInput) (*fastly.Kafka, error) {
+	o, err := m.Hook("UpdateKafka", i, func() (interface{}, error) {
+		return m.Client.UpdateKafka(i)
+	})
+	out, _ := o.(*fastly.Kafka)
+	return out, err
+}
+
+// DeleteKafka implements Interface.
+func (m *Middleware) DeleteKafka(i *fastly.DeleteKafkaInput) error {
+	_, err := m.Hook("DeleteKafka", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteKafka(i)
+	})
+	return err
+}
+
+// CreatePubsub implements Interface.
+func (m *Middleware) CreatePubsub(i *fastly.CreatePubsubInput) (*fastly.Pubsub, error) {
+	o, err := m.Hook("CreatePubsub", i, func() (interface{}, error) {
+		return m.Client.CreatePubsub(i)
+	})
+	out, _ := o.(*fastly.Pubsub)
+	return out, err
+}
+
+// ListPubsubs implements Interface.
+func (m *Middleware) ListPubsubs(i *fastly.ListPubsubsInput) ([]*fastly.Pubsub, error) {
+	o, err := m.Hook("ListPubsubs", i, func() (interface{}, error) {
+		return m.Client.ListPubsubs(i)
+	})
+	out, _ := o.([]*fastly.Pubsub)
+	return out, err
+}
+
+// GetPubsub implements Interface.
+func (m *Middleware) GetPubsub(i *fastly.GetPubsubInput) (*fastly.Pubsub, error) {
+	o, err := m.Hook("GetPubsub", i, func() (interface{}, error) {
+		return m.Client.GetPubsub(i)
+	})
+	out, _ := o.(*fastly.Pubsub)
+	return out, err
+}
+
+// UpdatePubsub implements Interface.
+func (m *Middleware) UpdatePubsub(i *fastly.UpdatePubsubInput) (*fastly.Pubsub, error) {
+	o, err := m.Hook("UpdatePubsub", i, func() (interface{}, error) {
+		return m.Client.UpdatePubsub(i)
+	})
+	out, _ := o.(*fastly.Pubsub)
+	return out, err
+}
+
+// DeletePubsub implements Interface.
+func (m *Middleware) DeletePubsub(i *fastly.DeletePubsubInput) error {
+	_, err := m.Hook("DeletePubsub", i, func() (interface{}, error) {
+		return nil, m.Client.DeletePubsub(i)
+	})
+	return err
+}
+
+// CreateOpenstack implements Interface.
+func (m *Middleware) CreateOpenstack(i *fastly.CreateOpenstackInput) (*fastly.Openstack, error) {
+	o, err := m.Hook("CreateOpenstack", i, func() (interface{}, error) {
+		return m.Client.CreateOpenstack(i)
+	})
+	out, _ := o.(*fastly.Openstack)
+	return out, err
+}
+
+// ListOpenstack implements Interface.
+func (m *Middleware) ListOpenstack(i *fastly.ListOpenstackInput) ([]*fastly.Openstack, error) {
+	o, err := m.Hook("ListOpenstack", i, func() (interface{}, error) {
+		return m.Client.ListOpenstack(i)
+	})
+	out, _ := o.([]*fastly.Openstack)
+	return out, err
+}
+
+// GetOpenstack implements Interface.
+func (m *Middleware) GetOpenstack(i *fastly.GetOpenstackInput) (*fastly.Openstack, error) {
+	o, err := m.Hook("GetOpenstack", i, func() (interface{}, error) {
+		return m.Client.GetOpenstack(i)
+	})
+	out, _ := o.(*fastly.Openstack)
+	return out, err
+}
+
+// UpdateOpenstack implements Interface.
+func (m *Middleware) UpdateOpenstack(i *fastly.UpdateOpenstackInput) (*fastly.Openstack, error) {
+	o, err := m.Hook("UpdateOpenstack", i, func() (interface{}, error) {
+		return m.Client.UpdateOpenstack(i)
+	})
+	out, _ := o.(*fastly.Openstack)
+	return out, err
+}
+
+// DeleteOpenstack implements Interface.
+func (m *Middleware) DeleteOpenstack(i *fastly.DeleteOpenstackInput) error {
+	_, err := m.Hook("DeleteOpenstack", i, func() (interface{}, error) {
+		return nil, m.Client.DeleteOpenstack(i)
+	})
+	return err
+}
+
+// GetUser implements Interface.
+func (m *Middleware) GetUser(i *fastly.GetUserInput) (*fastly.User, error) {
+	o, err := m.Hook("GetUser", i, func() (interface{}, error) {
+		return m.Client.GetUser(i)
+	})
+	out, _ := o.(*fastly.User)
+	return out, err
+}
+
+// GetAPIEvents implements Interface.
+func (m *Middleware) GetAPIEvents(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	o, err := m.Hook("GetAPIEvents", i, func() (interface{}, error) {
+		return m.Client.GetAPIEvents(i)
+	})
+	out, _ := o.(fastly.GetAPIEventsResponse)
+	return out, err
+}
+
+// ListWAFs implements Interface.
+func (m *Middleware) ListWAFs(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+	o, err := m.Hook("ListWAFs", i, func() (interface{}, error) {
+		return m.Client.ListWAFs(i)
+	})
+	out, _ := o.(*fastly.WAFResponse)
+	return out, err
+}
+
+// GetWAF implements Interface.
+func (m *Middleware) GetWAF(i *fastly.GetWAFInput) (*fastly.WAF, error) {
+	o, err := m.Hook("GetWAF", i, func() (interface{}, error) {
+		return m.Client.GetWAF(i)
+	})
+	out, _ := o.(*fastly.WAF)
+	return out, err
+}
+
+// UpdateWAF implements Interface.
+func (m *Middleware) UpdateWAF(i *fastly.UpdateWAFInput) (*fastly.WAF, error) {
+	o, err := m.Hook("UpdateWAF", i, func() (interface{}, error) {
+		return m.Client.UpdateWAF(i)
+	})
+	out, _ := o.(*fastly.WAF)
+	return out, err
+}
+
+// ListAllWAFVersions implements Interface.
+func (m *Middleware) ListAllWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	o, err := m.Hook("ListAllWAFVersions", i, func() (interface{}, error) {
+		return m.Client.ListAllWAFVersions(i)
+	})
+	out, _ := o.(*fastly.WAFVersionResponse)
+	return out, err
+}
+
+// CloneWAFVersion implements Interface.
+func (m *Middleware) CloneWAFVersion(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error) {
+	o, err := m.Hook("CloneWAFVersion", i, func() (interface{}, error) {
+		return m.Client.CloneWAFVersion(i)
+	})
+	out, _ := o.(*fastly.WAFVersion)
+	return out, err
+}
+
+// DeployWAFVersion implements Interface.
+func (m *Middleware) DeployWAFVersion(i *fastly.DeployWAFVersionInput) error {
+	_, err := m.Hook("DeployWAFVersion", i, func() (interface{}, error) {
+		return nil, m.Client.DeployWAFVersion(i)
+	})
+	return err
+}
+
+// ListAllWAFActiveRules implements Interface.
+func (m *Middleware) ListAllWAFActiveRules(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error) {
+	o, err := m.Hook("ListAllWAFActiveRules", i, func() (interface{}, error) {
+		return m.Client.ListAllWAFActiveRules(i)
+	})
+	out, _ := o.(*fastly.WAFActiveRuleResponse)
+	return out, err
+}
+
+// BatchModificationWAFActiveRules implements Interface.
+func (m *Middleware) BatchModificationWAFActiveRules(i *fastly.BatchModificationWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error) {
+	o, err := m.Hook("BatchModificationWAFActiveRules", i, func() (interface{}, error) {
+		return m.Client.BatchModificationWAFActiveRules(i)
+	})
+	out, _ := o.([]*fastly.WAFActiveRule)
+	return out, err
+}
+
+// ListAllWAFRules implements Interface.
+func (m *Middleware) ListAllWAFRules(i *fastly.ListAllWAFRulesInput) (*fastly.WAFRuleResponse, error) {
+	o, err := m.Hook("ListAllWAFRules", i, func() (interface{}, error) {
+		return m.Client.ListAllWAFRules(i)
+	})
+	out, _ := o.(*fastly.WAFRuleResponse)
+	return out, err
+}
+
+// GetRegions implements Interface.
+func (m *Middleware) GetRegions() (*fastly.RegionsResponse, error) {
+	o, err := m.Hook("GetRegions", nil, func() (interface{}, error) {
+		return m.Client.GetRegions()
+	})
+	out, _ := o.(*fastly.RegionsResponse)
+	return out, err
+}
+
+// GetStatsJSON implements Interface.
+func (m *Middleware) GetStatsJSON(i *fastly.GetStatsInput, dst interface{}) error {
+	_, err := m.Hook("GetStatsJSON", []interface{}{i, dst}, func() (interface{}, error) {
+		return nil, m.Client.GetStatsJSON(i, dst)
+	})
+	return err
+}
+
+// GetUsage implements Interface.
+func (m *Middleware) GetUsage(i *fastly.GetUsageInput) (*fastly.UsageResponse, error) {
+	o, err := m.Hook("GetUsage", i, func() (interface{}, error) {
+		return m.Client.GetUsage(i)
+	})
+	out, _ := o.(*fastly.UsageResponse)
+	return out, err
+}
+
+// GetUsageByService implements Interface.
+func (m *Middleware) GetUsageByService(i *fastly.GetUsageInput) (*fastly.UsageByServiceResponse, error) {
+	o, err := m.Hook("GetUsageByService", i, func() (interface{}, error) {
+		return m.Client.GetUsageByService(i)
+	})
+	out, _ := o.(*fastly.UsageByServiceResponse)
+	return out, err
+}
+
+// GetBilling implements Interface.
+func (m *Middleware) GetBilling(i *fastly.GetBillingInput) (*fastly.Billing, error) {
+	o, err := m.Hook("GetBilling", i, func() (interface{}, error) {
+		return m.Client.GetBilling(i)
+	})
+	out, _ := o.(*fastly.Billing)
+	return out, err
+}
+
+// AllDatacenters implements Interface.
+func (m *Middleware) AllDatacenters() ([]fastly.Datacenter, error) {
+	o, err := m.Hook("AllDatacenters", nil, func() (interface{}, error) {
+		return m.Client.AllDatacenters()
+	})
+	out, _ := o.([]fastly.Datacenter)
+	return out, err
+}
+
+// IPs implements Interface.
+func (m *Middleware) IPs() (fastly.IPAddrs, error) {
+	o, err := m.Hook("IPs", nil, func() (interface{}, error) {
+		return m.Client.IPs()
+	})
+	out, _ := o.(fastly.IPAddrs)
+	return out, err
+}
+
+// IPsV6 implements Interface.
+func (m *Middleware) IPsV6() (fastly.IPAddrs, error) {
+	o, err := m.Hook("IPsV6", nil, func() (interface{}, error) {
+		return m.Client.IPsV6()
+	})
+	out, _ := o.(fastly.IPAddrs)
+	return out, err
+}
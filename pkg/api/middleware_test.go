@@ -0,0 +1,47 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestMiddleware(t *testing.T) {
+	var methods []string
+	var inputs []interface{}
+	client := api.NewMiddleware(mock.API{
+		GetServiceFn: func(i *fastly.GetServiceInput) (*fastly.Service, error) {
+			return &fastly.Service{ID: i.ID}, nil
+		},
+		GetStatsJSONFn: func(i *fastly.GetStatsInput, dst interface{}) error {
+			return nil
+		},
+	}, func(method string, input interface{}, call func() (interface{}, error)) (interface{}, error) {
+		methods = append(methods, method)
+		inputs = append(inputs, input)
+		if method == "GetRegions" {
+			return &fastly.RegionsResponse{Data: []string{"cached"}}, nil
+		}
+		return call()
+	})
+
+	s, err := client.GetService(&fastly.GetServiceInput{ID: "123"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "123", s.ID)
+
+	// The hook can answer a call without passing it on to the client.
+	r, err := client.GetRegions()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string{"cached"}, r.Data)
+
+	var dst map[string]interface{}
+	err = client.GetStatsJSON(&fastly.GetStatsInput{Service: "123"}, &dst)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertEqual(t, []string{"GetService", "GetRegions", "GetStatsJSON"}, methods)
+	testutil.AssertBool(t, true, inputs[1] == nil)
+	testutil.AssertBool(t, true, len(inputs[2].([]interface{})) == 2)
+}
@@ -0,0 +1,31 @@
+package api
+
+import "strings"
+
+// sensitiveNames are substrings of the names of fields whose values are
+// secrets, lowercased and without underscores, e.g. the access_token of a
+// newly created API token, the secret_key of an S3 logging endpoint, or the
+// ssl_client_key and tls_client_key PEM private keys of backends and logging
+// endpoints.
+var sensitiveNames = []string{
+	"token",
+	"secret",
+	"password",
+	"privatekey",
+	"clientkey",
+	"apikey",
+	"accesskey",
+	"credential",
+}
+
+// IsSensitive reports whether a field holds a secret, given its name either as
+// in the API, e.g. ssl_client_key, or as in go-fastly, e.g. SSLClientKey.
+func IsSensitive(name string) bool {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	for _, s := range sensitiveNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestIsSensitive(t *testing.T) {
+	for name, want := range map[string]bool{
+		"access_token":    true,
+		"secret_key":      true,
+		"ssl_client_key":  true,
+		"SSLClientKey":    true,
+		"TLSClientKey":    true,
+		"Password":        true,
+		"AccessKey":       true,
+		"address":         false,
+		"Name":            false,
+		"ssl_client_cert": false,
+	} {
+		t.Run(name, func(t *testing.T) {
+			testutil.AssertBool(t, want, api.IsSensitive(name))
+		})
+	}
+}
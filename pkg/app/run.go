@@ -6,21 +6,33 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/appconfig"
 	"github.com/fastly/cli/pkg/backend"
+	"github.com/fastly/cli/pkg/billing"
 	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/completion"
 	"github.com/fastly/cli/pkg/compute"
+	"github.com/fastly/cli/pkg/compute/manifest"
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/configure"
+	"github.com/fastly/cli/pkg/credential"
+	"github.com/fastly/cli/pkg/devapi"
 	"github.com/fastly/cli/pkg/domain"
 	"github.com/fastly/cli/pkg/edgedictionary"
 	"github.com/fastly/cli/pkg/edgedictionaryitem"
 	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/events"
 	"github.com/fastly/cli/pkg/healthcheck"
+	"github.com/fastly/cli/pkg/iplist"
 	"github.com/fastly/cli/pkg/logging"
 	"github.com/fastly/cli/pkg/logging/azureblob"
 	"github.com/fastly/cli/pkg/logging/bigquery"
@@ -47,19 +59,25 @@ import (
 	"github.com/fastly/cli/pkg/logging/splunk"
 	"github.com/fastly/cli/pkg/logging/sumologic"
 	"github.com/fastly/cli/pkg/logging/syslog"
+	"github.com/fastly/cli/pkg/logtail"
+	"github.com/fastly/cli/pkg/plugin"
+	"github.com/fastly/cli/pkg/pops"
+	"github.com/fastly/cli/pkg/rawapi"
+	"github.com/fastly/cli/pkg/search"
 	"github.com/fastly/cli/pkg/service"
 	"github.com/fastly/cli/pkg/serviceversion"
 	"github.com/fastly/cli/pkg/stats"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/cli/pkg/update"
 	"github.com/fastly/cli/pkg/version"
+	"github.com/fastly/cli/pkg/waf"
 	"github.com/fastly/cli/pkg/whoami"
 	"github.com/fastly/go-fastly/v2/fastly"
 	"github.com/fastly/kingpin"
 )
 
 var (
-	completionRegExp = regexp.MustCompile("completion-(?:script-)?(?:bash|zsh)$")
+	completionRegExp = regexp.MustCompile("completion-(?:script-)?(?:bash|zsh|fish)$")
 )
 
 // Run constructs the application including all of the subcommands, parses the
@@ -73,12 +91,39 @@ var (
 // io.Writer. All error-related information should be encoded into an error type
 // and returned to the caller. This includes usage text.
 func Run(args []string, env config.Environment, file config.File, configFilePath string, cf APIClientFactory, httpClient api.HTTPClient, versioner update.Versioner, in io.Reader, out io.Writer) error {
+	// The global --format flag can't be registered with kingpin, as it would
+	// collide with command flags of the same name, so it's extracted here.
+	format, args, err := SplitFormatArgs(args)
+	if err != nil {
+		return errors.RemediationError{Inner: fmt.Errorf("error parsing arguments: %w", err)}
+	}
+
 	// The globals will hold generally-applicable configuration parameters
 	// from a variety of sources, and is provided to each concrete command.
 	globals := config.Data{
 		File: file,
 		Env:  env,
 	}
+	globals.Flag.Format = format
+
+	// Project-level defaults come from the closest project config file in the
+	// working directory or any of its parents.
+	if path := findProjectFile(); path != "" {
+		if err := globals.Project.Read(path); err != nil {
+			return fmt.Errorf("error reading project config file %s: %w", path, err)
+		}
+		if err := validateFormat(globals.Project.Format); err != nil {
+			return fmt.Errorf("error reading project config file %s: %w", path, err)
+		}
+		if profile, source := globals.Profile(); source != config.SourceUndefined {
+			if _, ok := globals.ActiveProfile(); !ok {
+				return errors.RemediationError{
+					Inner:       fmt.Errorf("error reading project config file %s: profile %q isn't defined in the config file", path, profile),
+					Remediation: fmt.Sprintf("Add a [profile.%s] table with its token to the config file, %s, or remove the profile from the project config file.", profile, configFilePath),
+				}
+			}
+		}
+	}
 
 	// Set up the main application root, including global flags, and then each
 	// of the subcommands. Note that we deliberately don't use some of the more
@@ -108,12 +153,20 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 	tokenHelp := fmt.Sprintf("Fastly API token (or via %s)", config.EnvVarToken)
 	app.Flag("token", tokenHelp).Short('t').StringVar(&globals.Flag.Token)
 	app.Flag("verbose", "Verbose logging").Short('v').BoolVar(&globals.Flag.Verbose)
+	app.Flag("auto-yes", "Answer yes to all confirmation prompts").Short('y').BoolVar(&globals.Flag.AutoYes)
+	app.Flag("yes", "Alias for --auto-yes").Hidden().BoolVar(&globals.Flag.AutoYes)
 	app.Flag("endpoint", "Fastly API endpoint").Hidden().StringVar(&globals.Flag.Endpoint)
+	app.Flag("completion-script-fish", "Generate completion script for fish.").Hidden().Bool()
 
 	configureRoot := configure.NewRootCommand(app, configFilePath, configure.APIClientFactory(cf), &globals)
 	whoamiRoot := whoami.NewRootCommand(app, httpClient, &globals)
+	configRoot := appconfig.NewRootCommand(app, &globals)
+	configShow := appconfig.NewShowCommand(configRoot.CmdClause, configFilePath, &globals)
 	versionRoot := version.NewRootCommand(app)
-	updateRoot := update.NewRootCommand(app, versioner, httpClient)
+	updateRoot := update.NewRootCommand(app, configFilePath, versioner, httpClient, &globals)
+	apiRoot := rawapi.NewRootCommand(app, httpClient, &globals)
+	devAPIRoot := devapi.NewRootCommand(app, &globals)
+	searchRoot := search.NewRootCommand(app, &globals)
 
 	serviceRoot := service.NewRootCommand(app, &globals)
 	serviceCreate := service.NewCreateCommand(serviceRoot.CmdClause, &globals)
@@ -122,6 +175,8 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 	serviceUpdate := service.NewUpdateCommand(serviceRoot.CmdClause, &globals)
 	serviceDelete := service.NewDeleteCommand(serviceRoot.CmdClause, &globals)
 	serviceSearch := service.NewSearchCommand(serviceRoot.CmdClause, &globals)
+	serviceBackup := service.NewBackupCommand(serviceRoot.CmdClause, &globals)
+	serviceRestore := service.NewRestoreCommand(serviceRoot.CmdClause, &globals)
 
 	serviceVersionRoot := serviceversion.NewRootCommand(app, &globals)
 	serviceVersionClone := serviceversion.NewCloneCommand(serviceVersionRoot.CmdClause, &globals)
@@ -130,6 +185,8 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 	serviceVersionActivate := serviceversion.NewActivateCommand(serviceVersionRoot.CmdClause, &globals)
 	serviceVersionDeactivate := serviceversion.NewDeactivateCommand(serviceVersionRoot.CmdClause, &globals)
 	serviceVersionLock := serviceversion.NewLockCommand(serviceVersionRoot.CmdClause, &globals)
+	serviceVersionLog := serviceversion.NewLogCommand(serviceVersionRoot.CmdClause, &globals)
+	serviceVersionPromote := serviceversion.NewPromoteCommand(serviceVersionRoot.CmdClause, &globals)
 
 	computeRoot := compute.NewRootCommand(app, &globals)
 	computeInit := compute.NewInitCommand(computeRoot.CmdClause, httpClient, &globals)
@@ -356,11 +413,53 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 	statsHistorical := stats.NewHistoricalCommand(statsRoot.CmdClause, &globals)
 	statsRealtime := stats.NewRealtimeCommand(statsRoot.CmdClause, &globals)
 
+	eventsRoot := events.NewRootCommand(app, &globals)
+	eventsList := events.NewListCommand(eventsRoot.CmdClause, &globals)
+
+	wafRoot := waf.NewRootCommand(app, &globals)
+	wafList := waf.NewListCommand(wafRoot.CmdClause, &globals)
+	wafDescribe := waf.NewDescribeCommand(wafRoot.CmdClause, &globals)
+	wafUpdate := waf.NewUpdateCommand(wafRoot.CmdClause, &globals)
+	wafRuleRoot := waf.NewRuleRootCommand(wafRoot.CmdClause, &globals)
+	wafRuleList := waf.NewRuleListCommand(wafRuleRoot.CmdClause, &globals)
+	wafRuleSearch := waf.NewRuleSearchCommand(wafRuleRoot.CmdClause, &globals)
+	wafRuleStatusRoot := waf.NewRuleStatusRootCommand(wafRuleRoot.CmdClause, &globals)
+	wafRuleStatusUpdate := waf.NewStatusUpdateCommand(wafRuleStatusRoot.CmdClause, &globals)
+	wafRulesetRoot := waf.NewRulesetRootCommand(wafRoot.CmdClause, &globals)
+	wafRulesetDeploy := waf.NewRulesetDeployCommand(wafRulesetRoot.CmdClause, &globals)
+
+	billingRoot := billing.NewRootCommand(app, &globals)
+	billingShow := billing.NewShowCommand(billingRoot.CmdClause, &globals)
+	billingUsage := billing.NewUsageCommand(billingRoot.CmdClause, &globals)
+
+	popsRoot := pops.NewRootCommand(app, &globals)
+	popsList := pops.NewListCommand(popsRoot.CmdClause, &globals)
+
+	ipListRoot := iplist.NewRootCommand(app, &globals)
+
+	logTailRoot := logtail.NewRootCommand(app, httpClient, &globals)
+
+	// External plugins are registered last so that built-in commands always
+	// take precedence. Any arguments following the plugin name are owned by
+	// the plugin, so we remove them before kingpin gets a chance to reject
+	// flags it doesn't know about.
+	var plugins []common.Command
+	discovered := availablePlugins(plugin.Discover(env.Path), app)
+	args, pluginArgs := splitPluginArgs(args, discovered)
+	for _, p := range discovered {
+		plugins = append(plugins, plugin.NewCommand(app, p, pluginArgs, &globals))
+	}
+
 	commands := []common.Command{
 		configureRoot,
 		whoamiRoot,
+		configRoot,
+		configShow,
 		versionRoot,
 		updateRoot,
+		apiRoot,
+		devAPIRoot,
+		searchRoot,
 
 		serviceRoot,
 		serviceCreate,
@@ -369,6 +468,8 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 		serviceUpdate,
 		serviceDelete,
 		serviceSearch,
+		serviceBackup,
+		serviceRestore,
 
 		serviceVersionRoot,
 		serviceVersionClone,
@@ -377,6 +478,8 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 		serviceVersionActivate,
 		serviceVersionDeactivate,
 		serviceVersionLock,
+		serviceVersionLog,
+		serviceVersionPromote,
 
 		computeRoot,
 		computeInit,
@@ -602,8 +705,51 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 		statsRegions,
 		statsHistorical,
 		statsRealtime,
+
+		eventsRoot,
+		eventsList,
+
+		wafRoot,
+		wafList,
+		wafDescribe,
+		wafUpdate,
+		wafRuleRoot,
+		wafRuleList,
+		wafRuleSearch,
+		wafRuleStatusRoot,
+		wafRuleStatusUpdate,
+		wafRulesetRoot,
+		wafRulesetDeploy,
+
+		billingRoot,
+		billingShow,
+		billingUsage,
+
+		popsRoot,
+		popsList,
+
+		ipListRoot,
+
+		logTailRoot,
+	}
+
+	// Kingpin only generates bash and zsh completion scripts, so we print the
+	// fish script ourselves and exit early.
+	if argsIsFishCompletionScript(args) {
+		fmt.Fprint(out, completion.FishScript)
+		return nil
 	}
 
+	// Dynamic completion of resource IDs and names queries the API, so it's
+	// only wired up when the shell is actually asking for completions.
+	if isCompletion(args) {
+		completion.New(&globals, completion.ClientFactory(cf)).Register(app)
+	}
+
+	// Commands which accept a --service-id flag default to the service ID in
+	// the project config file, if any.
+	app.Resolver(serviceIDResolver(globals.Project))
+
 	// Handle parse errors and display contextal usage if possible. Due to bugs
 	// and an obession for lots of output side-effects in the kingpin.Parse
 	// logic, we suppress it from writing any usage or errors to the writer by
@@ -661,14 +807,24 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 		return errors.RemediationError{Prefix: buf.String()}
 	}
 
-	token, source := globals.Token()
+	// A token stored via the token_command or encrypted_token settings is
+	// only resolved once a command needs it, so that commands which never call
+	// the API don't run the command or prompt for the passphrase.
+	globals.TokenResolver = func(d *config.Data) error {
+		if err := credential.Resolve(d, in, out); err != nil {
+			return errors.RemediationError{Inner: err, Remediation: errors.CredentialRemediation}
+		}
+		return nil
+	}
+
+	_, source := globals.Token()
 	if globals.Verbose() {
-		switch source {
-		case config.SourceFlag:
+		switch {
+		case source == config.SourceFlag:
 			fmt.Fprintf(out, "Fastly API token provided via --token\n")
-		case config.SourceEnvironment:
+		case source == config.SourceEnvironment:
 			fmt.Fprintf(out, "Fastly API token provided via %s\n", config.EnvVarToken)
-		case config.SourceFile:
+		case source == config.SourceFile, globals.File.TokenCommand != "", globals.File.EncryptedToken != "":
 			fmt.Fprintf(out, "Fastly API token provided via config file\n")
 		default:
 			fmt.Fprintf(out, "Fastly API token not provided\n")
@@ -701,17 +857,15 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 		}
 	}
 
-	globals.Client, err = cf(token, endpoint)
-	if err != nil {
-		return fmt.Errorf("error constructing Fastly API client: %w", err)
-	}
-
-	globals.RTSClient, err = fastly.NewRealtimeStatsClientForEndpoint(token, fastly.DefaultRealtimeStatsEndpoint)
-	if err != nil {
-		return fmt.Errorf("error constructing Fastly realtime stats client: %w", err)
-	}
+	// The API clients are likewise constructed once a command first calls the
+	// API, with the resolved token.
+	globals.Client = newLazyClient(&globals, cf)
+	globals.RTSClient = &lazyRealtimeStatsClient{globals: &globals}
 
 	command, found := common.SelectCommand(name, commands)
+	if !found {
+		command, found = common.SelectCommand(name, plugins)
+	}
 	if !found {
 		usage := Usage(args, app, out, ioutil.Discard)
 		return errors.RemediationError{Prefix: usage, Inner: fmt.Errorf("command not found")}
@@ -734,11 +888,104 @@ func Run(args []string, env config.Environment, file config.File, configFilePath
 // interface via MockClient.
 type APIClientFactory func(token, endpoint string) (api.Interface, error)
 
+// newLazyClient returns an API client which resolves the token and constructs
+// the client to pass calls to via cf on its first call. It's safe for
+// concurrent use.
+func newLazyClient(globals *config.Data, cf APIClientFactory) api.Interface {
+	var (
+		m    = api.NewMiddleware(nil, nil)
+		once sync.Once
+		err  error
+	)
+	m.Hook = func(_ string, _ interface{}, call func() (interface{}, error)) (interface{}, error) {
+		once.Do(func() {
+			if err = globals.ResolveToken(); err != nil {
+				return
+			}
+			token, _ := globals.Token()
+			endpoint, _ := globals.Endpoint()
+			if m.Client, err = cf(token, endpoint); err != nil {
+				err = fmt.Errorf("error constructing Fastly API client: %w", err)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		return call()
+	}
+	return m
+}
+
+// lazyRealtimeStatsClient is a realtime stats client which resolves the token
+// and constructs the client to pass calls to on its first call.
+type lazyRealtimeStatsClient struct {
+	globals *config.Data
+	client  api.RealtimeStatsInterface
+}
+
+// GetRealtimeStatsJSON implements api.RealtimeStatsInterface.
+func (c *lazyRealtimeStatsClient) GetRealtimeStatsJSON(i *fastly.GetRealtimeStatsInput, dst interface{}) error {
+	if c.client == nil {
+		if err := c.globals.ResolveToken(); err != nil {
+			return err
+		}
+		token, _ := c.globals.Token()
+		client, err := fastly.NewRealtimeStatsClientForEndpoint(token, fastly.DefaultRealtimeStatsEndpoint)
+		if err != nil {
+			return fmt.Errorf("error constructing Fastly realtime stats client: %w", err)
+		}
+		c.client = client
+	}
+	return c.client.GetRealtimeStatsJSON(i, dst)
+}
+
 // FastlyAPIClient is a ClientFactory that returns a real Fastly API client
 // using the provided token and endpoint.
 func FastlyAPIClient(token, endpoint string) (api.Interface, error) {
-	client, err := fastly.NewClientForEndpoint(token, endpoint)
-	return client, err
+	return NewFastlyAPIClientFactory(nil)(token, endpoint)
+}
+
+// NewFastlyAPIClientFactory returns an APIClientFactory like FastlyAPIClient,
+// whose clients make requests via the transport, e.g. to record or replay them.
+// A nil transport uses the Fastly API client library's default.
+func NewFastlyAPIClientFactory(transport http.RoundTripper) APIClientFactory {
+	return func(token, endpoint string) (api.Interface, error) {
+		client, err := fastly.NewClientForEndpoint(token, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if transport != nil {
+			client.HTTPClient.Transport = transport
+		}
+		client.HTTPClient.Transport = rateLimitTransport{client.HTTPClient.Transport}
+		return client, nil
+	}
+}
+
+// rateLimitTransport converts 429 Too Many Requests responses to an
+// errors.RateLimitError, so the time at which the rate limit resets, which is
+// only available via a response header, can be reported to the user.
+type rateLimitTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	var reset time.Time
+	if secs, err := strconv.ParseInt(resp.Header.Get("Fastly-RateLimit-Reset"), 10, 64); err == nil {
+		reset = time.Unix(secs, 0)
+	}
+	return nil, errors.RateLimitError{Reset: reset, HTTPError: fastly.NewHTTPError(resp)}
 }
 
 // contextHasHelpFlag asserts whether a given kingpin.ParseContext contains a
@@ -757,8 +1004,132 @@ func argsIsHelpJSON(args []string) bool {
 		args[2] == "json")
 }
 
+// ErrorFormat returns the format in which errors returned from Run should be
+// rendered, as determined by the global --format flag or a project config file.
+func ErrorFormat(args []string) string {
+	if format, _, err := SplitFormatArgs(args); err == nil && format != "" {
+		return format
+	}
+	if path := findProjectFile(); path != "" {
+		var project config.ProjectFile
+		if err := project.Read(path); err == nil && validateFormat(project.Format) == nil && project.Format != "" {
+			return project.Format
+		}
+	}
+	return config.FormatText
+}
+
+// SplitFormatArgs extracts the global --format flag from the supplied command
+// arguments, returning the format, which is empty if the flag isn't provided,
+// and the remaining arguments. Some commands define a --format flag of their
+// own, so the global flag is only recognised when it precedes the command name.
+func SplitFormatArgs(args []string) (string, []string, error) {
+	var format string
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--format" && i+1 < len(args):
+			i++
+			format = args[i]
+			continue
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+			continue
+		case arg == "-t" || arg == "--token" || arg == "--endpoint":
+			if i+1 < len(args) {
+				rest = append(rest, arg)
+				i++ // keep the flag value
+				arg = args[i]
+			}
+		case arg == "--" || !strings.HasPrefix(arg, "-"):
+			return format, append(rest, args[i:]...), validateFormat(format)
+		}
+		rest = append(rest, arg)
+	}
+	return format, rest, validateFormat(format)
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case "", config.FormatText, config.FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid --format %q, must be one of %s, %s", format, config.FormatText, config.FormatJSON)
+}
+
+// findProjectFile returns the path of the closest project config file to the
+// working directory, or an empty string if there is none.
+func findProjectFile() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return config.FindProjectFile(wd)
+}
+
+// serviceIDResolver resolves the --service-id flag of every command to the
+// default service ID from the project config file. A package manifest in the
+// working directory which specifies a service ID takes precedence.
+func serviceIDResolver(project config.ProjectFile) kingpin.Resolver {
+	return kingpin.ResolverFunc(func(clause *kingpin.ClauseModel, _ *kingpin.ParseContext) ([]string, error) {
+		if clause.Name != "service-id" || project.ServiceID == "" {
+			return nil, nil
+		}
+		var m manifest.File
+		if err := m.Read(manifest.Filename); err == nil && m.ServiceID != "" {
+			return nil, nil
+		}
+		return []string{project.ServiceID}, nil
+	})
+}
+
+// availablePlugins filters out any plugin whose name collides with a built-in
+// command.
+func availablePlugins(plugins []plugin.Plugin, app *kingpin.Application) []plugin.Plugin {
+	var available []plugin.Plugin
+	for _, p := range plugins {
+		if app.GetCommand(p.Name) != nil || p.Name == "help" {
+			continue
+		}
+		available = append(available, p)
+	}
+	return available
+}
+
+// splitPluginArgs splits the supplied command arguments at the first
+// positional argument if it names a plugin. The first slice should be parsed
+// by kingpin, and the second slice is passed verbatim to the plugin.
+func splitPluginArgs(args []string, plugins []plugin.Plugin) ([]string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return args, nil
+		case arg == "-t" || arg == "--token" || arg == "--endpoint":
+			i++ // skip the flag value
+		case strings.HasPrefix(arg, "-"):
+			continue
+		default:
+			for _, p := range plugins {
+				if p.Name == arg {
+					return args[:i+1], args[i+1:]
+				}
+			}
+			return args, nil
+		}
+	}
+	return args, nil
+}
+
+// argsIsFishCompletionScript determines whether the supplied command arguments
+// request the fish completion script.
+func argsIsFishCompletionScript(args []string) bool {
+	return len(args) == 1 && args[0] == "--completion-script-fish"
+}
+
 // isCompletion determines whether the supplied command arguments are for
-// bash/zsh completion output.
+// bash/zsh/fish completion output.
 func isCompletion(args []string) bool {
 	var found bool
 	for _, arg := range args {
@@ -3,17 +3,31 @@ package app_test
 import (
 	"bufio"
 	"bytes"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/credential"
 	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/fakeapi"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/apptest"
 	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestApplication(t *testing.T) {
@@ -76,6 +90,351 @@ func TestApplication(t *testing.T) {
 	}
 }
 
+func TestSplitFormatArgs(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		args       []string
+		wantFormat string
+		wantArgs   []string
+		wantError  string
+	}{
+		{
+			name:       "default",
+			args:       []string{"service", "list"},
+			wantFormat: "",
+			wantArgs:   []string{"service", "list"},
+		},
+		{
+			name:       "json",
+			args:       []string{"--format", "json", "service", "list"},
+			wantFormat: "json",
+			wantArgs:   []string{"service", "list"},
+		},
+		{
+			name:       "json with equals and other global flags",
+			args:       []string{"-t", "123", "--format=json", "-v", "service", "list"},
+			wantFormat: "json",
+			wantArgs:   []string{"-t", "123", "-v", "service", "list"},
+		},
+		{
+			name:       "command flag is left alone",
+			args:       []string{"logging", "s3", "create", "--format", "%h"},
+			wantFormat: "",
+			wantArgs:   []string{"logging", "s3", "create", "--format", "%h"},
+		},
+		{
+			name:       "help format json is left alone",
+			args:       []string{"help", "--format", "json"},
+			wantFormat: "",
+			wantArgs:   []string{"help", "--format", "json"},
+		},
+		{
+			name:      "invalid",
+			args:      []string{"--format", "yaml", "service", "list"},
+			wantError: `invalid --format "yaml"`,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			format, args, err := app.SplitFormatArgs(testcase.args)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantError != "" {
+				return
+			}
+			testutil.AssertString(t, testcase.wantFormat, format)
+			testutil.AssertEqual(t, testcase.wantArgs, args)
+		})
+	}
+}
+
+func TestFastlyAPIClientRateLimit(t *testing.T) {
+	reset := time.Date(2020, time.June, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Fastly-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"msg":"Too many requests"}`)
+	}))
+	defer server.Close()
+
+	client, err := app.FastlyAPIClient("123", server.URL)
+	testutil.AssertNoError(t, err)
+	_, err = client.GetService(&fastly.GetServiceInput{ID: "123"})
+
+	var rateLimitError errors.RateLimitError
+	if !stderrors.As(err, &rateLimitError) {
+		t.Fatalf("want errors.RateLimitError, have %#v", err)
+	}
+	if !rateLimitError.Reset.Equal(reset) {
+		t.Fatalf("want reset %s, have %s", reset, rateLimitError.Reset)
+	}
+	testutil.AssertEqual(t, errors.ExitRateLimited, errors.ExitCode(err))
+	testutil.AssertString(t, errors.RateLimitRemediation(rateLimitError.Reset), errors.Deduce(err).Remediation)
+	testutil.AssertErrorContains(t, errors.Deduce(err), "429 Too Many Requests: Too many requests")
+}
+
+func TestProjectConfig(t *testing.T) {
+	for _, testcase := range []struct {
+		name          string
+		args          []string
+		file          config.File
+		project       string
+		wantError     string
+		wantServiceID string
+		wantToken     string
+		wantOutput    string
+	}{
+		{
+			name:          "service ID from project",
+			args:          []string{"backend", "list", "--version", "1"},
+			project:       "service_id = \"123\"\n",
+			wantServiceID: "123",
+		},
+		{
+			name:          "service ID flag overrides project",
+			args:          []string{"backend", "list", "--service-id", "456", "--version", "1"},
+			project:       "service_id = \"123\"\n",
+			wantServiceID: "456",
+		},
+		{
+			name:      "no service ID",
+			args:      []string{"backend", "list", "--version", "1"},
+			project:   "format = \"text\"\n",
+			wantError: "required flag --service-id not provided",
+		},
+		{
+			name: "profile selects token",
+			args: []string{"backend", "list", "--version", "1"},
+			file: config.File{
+				Token:    "123",
+				Profiles: map[string]config.Profile{"staging": {Token: "456"}},
+			},
+			project:       "service_id = \"123\"\nprofile = \"staging\"\n",
+			wantServiceID: "123",
+			wantToken:     "456",
+		},
+		{
+			name:      "undefined profile",
+			args:      []string{"backend", "list", "--version", "1"},
+			file:      config.File{Token: "123"},
+			project:   "service_id = \"123\"\nprofile = \"staging\"\n",
+			wantError: `profile "staging" isn't defined in the config file`,
+		},
+		{
+			name:       "format from project",
+			args:       []string{"billing", "show", "--year", "2021", "--month", "1"},
+			project:    "format = \"json\"\n",
+			wantOutput: `"item": "Total"`,
+		},
+		{
+			name:       "format flag overrides project",
+			args:       []string{"billing", "show", "--year", "2021", "--month", "1", "--format", "csv"},
+			project:    "format = \"json\"\n",
+			wantOutput: "item,quantity,unit,cost\n",
+		},
+		{
+			name:      "invalid format",
+			args:      []string{"backend", "list", "--version", "1"},
+			project:   "format = \"yaml\"\n",
+			wantError: `invalid --format "yaml"`,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			rootdir, err := ioutil.TempDir("", "fastly-project-*")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(rootdir)
+			if err := os.MkdirAll(filepath.Join(rootdir, ".fastly"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(filepath.Join(rootdir, ".fastly", "config.toml"), []byte(testcase.project), 0644); err != nil {
+				t.Fatal(err)
+			}
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(rootdir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			var serviceID, token string
+			fake := mock.API{
+				ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+					serviceID = i.ServiceID
+					return nil, nil
+				},
+				GetBillingFn: func(i *fastly.GetBillingInput) (*fastly.Billing, error) {
+					return &fastly.Billing{}, nil
+				},
+			}
+
+			var (
+				args                            = testcase.args
+				env                             = config.Environment{}
+				file                            = testcase.file
+				configFilePath                  = "/dev/null"
+				clientFactory                   = func(t, _ string) (api.Interface, error) { token = t; return fake, nil }
+				httpClient     api.HTTPClient   = nil
+				versioner      update.Versioner = nil
+				stdin          io.Reader        = nil
+				stdout         bytes.Buffer
+			)
+			err = app.Run(args, env, file, configFilePath, clientFactory, httpClient, versioner, stdin, &stdout)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantServiceID, serviceID)
+			if testcase.wantToken != "" {
+				testutil.AssertString(t, testcase.wantToken, token)
+			}
+			testutil.AssertStringContains(t, stdout.String(), testcase.wantOutput)
+		})
+	}
+}
+
+func TestStoredToken(t *testing.T) {
+	encrypted, err := credential.Encrypt("decrypted", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	parsed, err := credential.ParseKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptedWithKey, err := credential.EncryptWithKey("decrypted", parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		args      []string
+		env       config.Environment
+		file      config.File
+		stdin     string
+		wantError string
+		wantToken string
+	}{
+		{
+			name:      "plaintext",
+			file:      config.File{Token: "plaintext"},
+			wantToken: "plaintext",
+		},
+		{
+			name:      "encrypted with passphrase from environment",
+			env:       config.Environment{CredentialsPassphrase: "hunter2"},
+			file:      config.File{EncryptedToken: encrypted},
+			wantToken: "decrypted",
+		},
+		{
+			name:      "encrypted with interactive passphrase",
+			file:      config.File{EncryptedToken: encrypted},
+			stdin:     "hunter2\n",
+			wantToken: "decrypted",
+		},
+		{
+			name:      "encrypted with wrong passphrase",
+			env:       config.Environment{CredentialsPassphrase: "hunter3"},
+			file:      config.File{EncryptedToken: encrypted},
+			wantError: credential.ErrDecrypt.Error(),
+		},
+		{
+			name:      "encrypted without passphrase",
+			file:      config.File{EncryptedToken: encrypted},
+			wantError: credential.ErrNoPassphrase.Error(),
+		},
+		{
+			name:      "encrypted with key from environment",
+			env:       config.Environment{CredentialsKey: key},
+			file:      config.File{EncryptedToken: encryptedWithKey},
+			wantToken: "decrypted",
+		},
+		{
+			name:      "encrypted with key without key",
+			env:       config.Environment{CredentialsPassphrase: "hunter2"},
+			file:      config.File{EncryptedToken: encryptedWithKey},
+			wantError: credential.ErrNoKey.Error(),
+		},
+		{
+			name:      "offline command doesn't resolve token",
+			args:      []string{"compute", "validate", "--path", "does-not-exist.tar.gz"},
+			file:      config.File{EncryptedToken: encrypted, TokenCommand: "exit 1"},
+			wantError: "error reading package",
+		},
+		{
+			name:      "token command takes precedence",
+			file:      config.File{Token: "plaintext", EncryptedToken: encrypted, TokenCommand: "echo commanded"},
+			wantToken: "commanded",
+		},
+		{
+			name:      "environment takes precedence",
+			env:       config.Environment{Token: "environment"},
+			file:      config.File{EncryptedToken: encrypted, TokenCommand: "exit 1"},
+			wantToken: "environment",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var token string
+			clientFactory := func(tok, endpoint string) (api.Interface, error) {
+				token = tok
+				return mock.API{
+					ListBackendsFn: func(*fastly.ListBackendsInput) ([]*fastly.Backend, error) { return nil, nil },
+				}, nil
+			}
+
+			args := testcase.args
+			if args == nil {
+				args = []string{"backend", "list", "--service-id", "123", "--version", "1"}
+			}
+			var (
+				configFilePath                  = "/dev/null"
+				httpClient     api.HTTPClient   = nil
+				versioner      update.Versioner = nil
+				stdin          io.Reader
+				stdout         bytes.Buffer
+			)
+			if testcase.stdin != "" {
+				stdin = strings.NewReader(testcase.stdin)
+			}
+			err := app.Run(args, testcase.env, testcase.file, configFilePath, clientFactory, httpClient, versioner, stdin, &stdout)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantToken, token)
+			if testcase.wantError != "" && testcase.args == nil {
+				testutil.AssertEqual(t, errors.ExitAuth, errors.ExitCode(err))
+			}
+		})
+	}
+}
+
+func TestFakeFastly(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+
+	out, err := apptest.Run(t, server.URL, nil, "service", "create", "--name", "example", "--type", "vcl")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Created service service000001")
+
+	_, err = apptest.Run(t, server.URL, nil, "backend", "create", "--service-id", "service000001", "--version", "1", "--name", "origin", "--address", "example.com")
+	testutil.AssertNoError(t, err)
+
+	_, err = apptest.Run(t, server.URL, nil, "service-version", "activate", "--service-id", "service000001", "--version", "1")
+	testutil.AssertNoError(t, err)
+
+	_, err = apptest.Run(t, server.URL, nil, "backend", "create", "--service-id", "service000001", "--version", "1", "--name", "other", "--address", "example.org")
+	testutil.AssertErrorContains(t, err, "Version active")
+	testutil.AssertEqual(t, errors.ExitConflict, errors.ExitCode(err))
+	testutil.AssertString(t, errors.CloneRemediation, errors.Deduce(err).Remediation)
+
+	out, err = apptest.Run(t, server.URL, nil, "service-version", "clone", "--service-id", "service000001", "--version", "1")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Cloned service service000001 version 1 to version 2")
+
+	out, err = apptest.Run(t, server.URL, nil, "backend", "list", "--service-id", "service000001", "--version", "2")
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "example.com")
+}
+
 // stripTrailingSpace removes any trailing spaces from the multiline str.
 func stripTrailingSpace(str string) string {
 	buf := bytes.NewBuffer(nil)
@@ -98,13 +457,18 @@ GLOBAL FLAGS
       --help         Show context-sensitive help.
   -t, --token=TOKEN  Fastly API token (or via FASTLY_API_TOKEN)
   -v, --verbose      Verbose logging
+  -y, --auto-yes     Answer yes to all confirmation prompts
 
 COMMANDS
   help             Show help.
   configure        Configure the Fastly CLI
   whoami           Get information about the currently authenticated account
+  config           Inspect the Fastly CLI configuration
   version          Display version information for the Fastly CLI
   update           Update the CLI to the latest version
+  api              Make an authenticated request to the Fastly API
+  dev-api          Serve an in-memory fake of the Fastly API for offline use
+  search           Search the active versions of every service for resources
   service          Manipulate Fastly services
   service-version  Manipulate Fastly service versions
   compute          Manage Compute@Edge packages
@@ -116,6 +480,14 @@ COMMANDS
   logging          Manipulate Fastly service version logging endpoints
   stats            View statistics (historical and realtime) for a Fastly
                    service
+  events           Inspect the audit log of changes to your account and services
+  waf              Manipulate Fastly web application firewalls
+  billing          Report on the invoices and usage of your account
+  pops             Inspect the points of presence (POPs) of the Fastly network
+  ip-list          List the IP ranges of the Fastly network, for allowlisting at
+                   origins
+  log-tail         Stream the stdout and stderr output of a Compute@Edge
+                   service, until interrupted
 `) + "\n\n"
 
 var helpService = strings.TrimSpace(`
@@ -126,6 +498,7 @@ GLOBAL FLAGS
       --help         Show context-sensitive help.
   -t, --token=TOKEN  Fastly API token (or via FASTLY_API_TOKEN)
   -v, --verbose      Verbose logging
+  -y, --auto-yes     Answer yes to all confirmation prompts
 
 SUBCOMMANDS
 
@@ -163,6 +536,27 @@ SUBCOMMANDS
 
     -n, --name=NAME  Service name
 
+  service backup --output=OUTPUT [<flags>]
+    Back up the configuration of Fastly services to local JSON files
+
+    -s, --service-id=SERVICE-ID  Service ID
+        --all                    Back up every service
+    -o, --output=OUTPUT          Directory to write a <service ID>.json file to
+                                 for each service
+
+  service restore --file=FILE [<flags>]
+    Recreate a Fastly service from a backup, as a new service or a new version
+    of an existing one
+
+    -f, --file=FILE              Path to a backup written by service backup
+    -s, --service-id=SERVICE-ID  ID of an existing service to restore to,
+                                 instead of a new service
+    -n, --name=NAME              Name of the new service, defaulting to the name
+                                 of the backed up service
+        --package=PACKAGE        Path to the Compute@Edge package of the backed
+                                 up version, which must match its hash
+        --activate               Activate the restored version
+
 `) + "\n\n"
 
 var fullFatHelpDefault = strings.TrimSpace(`
@@ -175,27 +569,67 @@ GLOBAL FLAGS
       --help         Show context-sensitive help.
   -t, --token=TOKEN  Fastly API token (or via FASTLY_API_TOKEN)
   -v, --verbose      Verbose logging
+  -y, --auto-yes     Answer yes to all confirmation prompts
 
 COMMANDS
   help [<command> ...]
     Show help.
 
 
-  configure
+  configure [<flags>]
     Configure the Fastly CLI
 
+    --encrypt  Encrypt the API token in the config file with a passphrase, or
+               the key in FASTLY_CREDENTIALS_KEY
 
   whoami
     Get information about the currently authenticated account
 
 
+  config show
+    Show the effective configuration and where each setting comes from
+
+
   version
     Display version information for the Fastly CLI
 
 
-  update
+  update [<flags>]
     Update the CLI to the latest version
 
+    --rollback         Restore the binary replaced by the most recent update
+    --version=VERSION  Install a specific version, which may be older than the
+                       current one
+    --channel=CHANNEL  Release channel to track, saved for future updates
+                       (stable, prerelease)
+
+  api [<flags>] <method> <path>
+    Make an authenticated request to the Fastly API
+
+    -d, --data=DATA          Request body, or @file to read it from a file (@-
+                             for stdin)
+    -H, --header=HEADER ...  Additional request header in key:value form
+                             (repeatable)
+        --paginate           Follow pagination links and combine every page of
+                             results
+
+  dev-api [<flags>]
+    Serve an in-memory fake of the Fastly API for offline use
+
+    --addr="127.0.0.1:8080"  Address to listen on
+
+  search [<flags>] [<text>]
+    Search the active versions of every service for resources
+
+    --backend-address=BACKEND-ADDRESS
+                                 Find backends with an address or hostname
+                                 containing this
+    --domain=DOMAIN              Find domains containing this
+    --logging-host=LOGGING-HOST  Find logging endpoints with a host, URL or
+                                 bucket containing this
+    --workers=8                  Number of services to index concurrently
+    --refresh                    Rebuild the index of every service, rather than
+                                 reusing the cached index
 
   service create --name=NAME [<flags>]
     Create a Fastly service
@@ -231,11 +665,33 @@ COMMANDS
 
     -n, --name=NAME  Service name
 
+  service backup --output=OUTPUT [<flags>]
+    Back up the configuration of Fastly services to local JSON files
+
+    -s, --service-id=SERVICE-ID  Service ID
+        --all                    Back up every service
+    -o, --output=OUTPUT          Directory to write a <service ID>.json file to
+                                 for each service
+
+  service restore --file=FILE [<flags>]
+    Recreate a Fastly service from a backup, as a new service or a new version
+    of an existing one
+
+    -f, --file=FILE              Path to a backup written by service backup
+    -s, --service-id=SERVICE-ID  ID of an existing service to restore to,
+                                 instead of a new service
+    -n, --name=NAME              Name of the new service, defaulting to the name
+                                 of the backed up service
+        --package=PACKAGE        Path to the Compute@Edge package of the backed
+                                 up version, which must match its hash
+        --activate               Activate the restored version
+
   service-version clone --version=VERSION [<flags>]
     Clone a Fastly service version
 
     -s, --service-id=SERVICE-ID  Service ID
         --version=VERSION        Number of version you wish to clone
+        --comment=COMMENT        Human-readable comment for the new version
 
   service-version list [<flags>]
     List Fastly service versions
@@ -254,6 +710,12 @@ COMMANDS
 
     -s, --service-id=SERVICE-ID  Service ID
         --version=VERSION        Number of version you wish to activate
+        --comment=COMMENT        Human-readable comment, recording why the
+                                 version was activated
+        --at=AT                  Wait until this time, e.g. 2026-10-20T02:00Z,
+                                 or for this duration, e.g. 2h, to activate,
+                                 refusing if the version or active version
+                                 changes meanwhile
 
   service-version deactivate --version=VERSION [<flags>]
     Deactivate a Fastly service version
@@ -267,6 +729,23 @@ COMMANDS
     -s, --service-id=SERVICE-ID  Service ID
         --version=VERSION        Number of version you wish to lock
 
+  service-version log [<flags>]
+    Show the history of a Fastly service's versions, and what changed in each
+
+    -s, --service-id=SERVICE-ID  Service ID
+        --limit=10               Maximum number of versions to show, newest
+                                 first (0 for all)
+
+  service-version promote --from-service=FROM-SERVICE --from-version=FROM-VERSION --to-service=TO-SERVICE [<flags>]
+    Promote the domains, health checks, backends, dictionaries and logging
+    endpoints of a service version to another service
+
+    --from-service=FROM-SERVICE  ID of the service to promote from
+    --from-version=FROM-VERSION  Number of the version to promote
+    --to-service=TO-SERVICE      ID of the service to promote to
+    --mapping=MAPPING            Path to a TOML file rewriting
+                                 environment-specific values
+
   compute init [<flags>]
     Initialize a new Compute@Edge package locally
 
@@ -292,6 +771,10 @@ COMMANDS
     --language=LANGUAGE  Language type
     --include-source     Include source code in built package
     --force              Skip verification steps and force build
+    --gitignore          Also exclude files ignored by .gitignore, unless
+                         re-included by .fastlyignore
+    --list-files         List the files to be packaged, with their sizes,
+                         without creating the package archive
 
   compute deploy [<flags>]
     Deploy a package to a Fastly Compute@Edge service
@@ -299,6 +782,7 @@ COMMANDS
     -s, --service-id=SERVICE-ID  Service ID
         --version=VERSION        Number of version to activate
     -p, --path=PATH              Path to package
+        --comment=COMMENT        Human-readable comment for the deployed version
 
   compute update --service-id=SERVICE-ID --version=VERSION --path=PATH
     Update a package on a Fastly Compute@Edge service version
@@ -3042,6 +3526,129 @@ COMMANDS
     -s, --service-id=SERVICE-ID  Service ID
         --format=FORMAT          Output format (json)
 
+  events list [<flags>]
+    List events, such as version activations, oldest first
+
+    -s, --service-id=SERVICE-ID  Only list events of this service
+        --user-id=USER-ID        Only list events caused by this user
+        --event-type=EVENT-TYPE  Only list events of this type, e.g.
+                                 version.activate
+        --from=FROM              Only list events since this time, e.g.
+                                 2021-01-02T15:04Z, or 24h ago
+        --to=TO                  Only list events before this time, e.g.
+                                 2021-01-02T15:04Z, or 1h ago
+        --limit=100              Maximum number of events to list, or 0 for no
+                                 limit
+        --follow                 Keep listing new events as they happen, until
+                                 interrupted
+        --format=FORMAT          Output format (json)
+
+  waf list [<flags>]
+    List the web application firewalls of a Fastly service
+
+    -s, --service-id=SERVICE-ID  Service ID
+        --version=VERSION        Only list the firewalls of this service version
+
+  waf describe --version=VERSION --id=ID [<flags>]
+    Show detailed information about a web application firewall
+
+    -s, --service-id=SERVICE-ID  Service ID
+        --version=VERSION        Number of service version
+        --id=ID                  ID of the firewall
+
+  waf update --version=VERSION --id=ID [<flags>]
+    Update a web application firewall
+
+    -s, --service-id=SERVICE-ID  Service ID
+        --version=VERSION        Number of service version
+        --id=ID                  ID of the firewall
+        --prefetch-condition=PREFETCH-CONDITION
+                                 Name of the condition under which requests are
+                                 inspected
+        --response=RESPONSE      Name of the response object sent when a request
+                                 is blocked
+        --disable                Disable the firewall
+        --enable                 Enable the firewall
+
+  waf rule list --id=ID [<flags>]
+    List the rules of a firewall, and their status
+
+    --id=ID                    ID of the firewall
+    --waf-version=WAF-VERSION  Number of firewall version, defaulting to the
+                               active version
+    --status=STATUS            Only list rules with this status
+
+  waf rule search [<flags>]
+    Search the rules which can be added to firewalls
+
+    --tag=TAG ...  Only list rules with this tag, e.g. attack-sqli (repeatable)
+    --owasp        Only list OWASP rules
+
+  waf rule status update --id=ID [<flags>]
+    Change the status of a rule of a firewall, or of many rules from a file
+
+    --id=ID                    ID of the firewall
+    --waf-version=WAF-VERSION  Number of firewall version, defaulting to the
+                               latest version
+    --rule-id=RULE-ID          ModSecurity ID of the rule
+    --status=STATUS            New status of the rule
+    --revision=REVISION        Revision of the rule, defaulting to the latest
+                               revision
+    --file=FILE                Path to a JSON file of rule statuses, e.g.
+                               {"rules": [{"rule_id": 1010010, "status":
+                               "block"}]}
+
+  waf ruleset deploy --id=ID [<flags>]
+    Deploy a version of the ruleset of a firewall
+
+    --id=ID                    ID of the firewall
+    --waf-version=WAF-VERSION  Number of firewall version, defaulting to the
+                               latest version
+
+  billing show --year=YEAR --month=MONTH [<flags>]
+    Show the invoice of a month, or the estimate of the current month
+
+    --year=YEAR      Year of the invoice, e.g. 2021
+    --month=MONTH    Month of the invoice, from 1 to 12
+    --format=FORMAT  Output format (csv, json)
+
+  billing usage --year=YEAR --month=MONTH [<flags>]
+    Report the requests and bandwidth of a month, by region or by service
+
+    --year=YEAR      Year of the usage, e.g. 2021
+    --month=MONTH    Month of the usage, from 1 to 12
+    --region=REGION  Only report usage in this region ('stats regions' to list)
+    --by-service     Report usage by service, with its share of the invoice's
+                     request and bandwidth costs
+    --format=FORMAT  Output format (csv, json)
+
+  pops list [<flags>]
+    List the POPs of the Fastly network, with their locations
+
+    --shields  Only list POPs which can be used as shields
+
+  ip-list [<flags>]
+    List the IP ranges of the Fastly network, for allowlisting at origins
+
+    --ipv6         List IPv6 ranges, rather than IPv4 ranges
+    --format=cidr  Output format (cidr, nginx, iptables)
+    --diff=DIFF    Only list ranges added or removed since this earlier export,
+                   in any format
+
+  log-tail [<flags>]
+    Stream the stdout and stderr output of a Compute@Edge service, until
+    interrupted
+
+    -s, --service-id=SERVICE-ID  Service ID
+        --from=FROM              Also list logs of requests since this time,
+                                 e.g. 2021-01-02T15:04Z, or 5m ago
+        --to=TO                  Only list logs of requests before this time,
+                                 and stop once it has passed, e.g.
+                                 2021-01-02T15:04Z
+        --search=SEARCH          Only list logs containing this text, ignoring
+                                 case
+        --format=FORMAT          Output format (json)
+
 For help on a specific command, try e.g.
 
 	fastly help configure
@@ -105,9 +105,10 @@ func Usage(args []string, app *kingpin.Application, out, err io.Writer) string {
 // if you add/remove a global flag you will also need to update flag binding in
 // pkg/app/app.go.
 var globalFlags = map[string]bool{
-	"help":    true,
-	"token":   true,
-	"verbose": true,
+	"help":     true,
+	"token":    true,
+	"verbose":  true,
+	"auto-yes": true,
 }
 
 // UsageTemplateFuncs is a map of template functions which get passed to the
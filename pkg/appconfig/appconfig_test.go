@@ -0,0 +1,172 @@
+package appconfig_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
+)
+
+func TestConfigShow(t *testing.T) {
+	for _, testcase := range []struct {
+		name        string
+		args        []string
+		env         config.Environment
+		file        config.File
+		project     string
+		manifest    string
+		wantOutput  []string
+		wantMissing []string
+	}{
+		{
+			name: "defaults",
+			args: []string{"config", "show"},
+			wantOutput: []string{
+				"Project config file: (none)",
+				"token            -                        -",
+				"endpoint         https://api.fastly.com   default",
+				"service_id       -                        -",
+				"format           text                     default",
+				"update_channel   stable                   default",
+				"update_base_url  https://api.github.com/  default",
+			},
+		},
+		{
+			name: "user config file",
+			args: []string{"config", "show"},
+			file: config.File{
+				Token:         "abcdefghijklmnop1234",
+				Email:         "alice@example.com",
+				UpdateChannel: "prerelease",
+			},
+			wantOutput: []string{
+				"token            ****1234                 user config file",
+				"email            alice@example.com        user config file",
+				"update_channel   prerelease               user config file",
+			},
+			wantMissing: []string{"abcdefghijklmnop"},
+		},
+		{
+			name: "encrypted token",
+			args: []string{"config", "show"},
+			file: config.File{EncryptedToken: "AQID"},
+			wantOutput: []string{
+				"token            (encrypted)              user config file",
+			},
+		},
+		{
+			name: "token command",
+			args: []string{"config", "show"},
+			file: config.File{Token: "abcdefghijklmnop1234", TokenCommand: "pass show fastly"},
+			wantOutput: []string{
+				"token            (via token_command)      user config file",
+			},
+			wantMissing: []string{"1234"},
+		},
+		{
+			name: "environment and flags",
+			args: []string{"--format", "json", "--token", "short", "config", "show"},
+			env:  config.Environment{Token: "abcdefghijklmnop1234", Endpoint: "https://staging.fastly.com"},
+			wantOutput: []string{
+				"token            ****                        flag",
+				"endpoint         https://staging.fastly.com  environment",
+				"format           json                        flag",
+			},
+			wantMissing: []string{"short", "1234"},
+		},
+		{
+			name: "project config file",
+			args: []string{"config", "show"},
+			file: config.File{
+				TokenCommand: "pass show fastly",
+				Profiles: map[string]config.Profile{
+					"staging": {Token: "abcdefghijklmnop5678", Email: "bob@example.com", Endpoint: "https://staging.fastly.com"},
+				},
+			},
+			project: "service_id = \"123\"\nprofile = \"staging\"\nformat = \"json\"\n",
+			wantOutput: []string{
+				filepath.Join(".fastly", "config.toml"),
+				"token            ****5678                    user config file",
+				"endpoint         https://staging.fastly.com  user config file",
+				"email            bob@example.com             user config file",
+				"service_id       123                         project config file",
+				"profile          staging                     project config file",
+				"format           json                        project config file",
+			},
+			wantMissing: []string{"token_command"},
+		},
+		{
+			name:     "package manifest takes precedence",
+			args:     []string{"config", "show"},
+			project:  "service_id = \"123\"\n",
+			manifest: "service_id = \"456\"\n",
+			wantOutput: []string{
+				"service_id       456                      package manifest (fastly.toml)",
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			// Run from a subdirectory of the project, as the project config
+			// file should be discovered by walking up the tree.
+			rootdir, err := ioutil.TempDir("", "fastly-config-*")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(rootdir)
+			workdir := filepath.Join(rootdir, "src")
+			if err := os.MkdirAll(filepath.Join(rootdir, ".fastly"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.MkdirAll(workdir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if testcase.project != "" {
+				if err := ioutil.WriteFile(filepath.Join(rootdir, ".fastly", "config.toml"), []byte(testcase.project), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if testcase.manifest != "" {
+				if err := ioutil.WriteFile(filepath.Join(workdir, "fastly.toml"), []byte(testcase.manifest), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(workdir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			out, err := golden.Exec(golden.Scenario{
+				Args: testcase.args,
+				Env:  testcase.env,
+				File: testcase.file,
+			})
+			testutil.AssertNoError(t, err)
+			output := stripTrailingSpace(out)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, output, s)
+			}
+			for _, s := range testcase.wantMissing {
+				if strings.Contains(output, s) {
+					t.Fatalf("%q shouldn't contain %q", output, s)
+				}
+			}
+		})
+	}
+}
+
+func stripTrailingSpace(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
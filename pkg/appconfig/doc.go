@@ -0,0 +1,3 @@
+// Package appconfig contains commands to inspect the configuration of the
+// Fastly CLI itself.
+package appconfig
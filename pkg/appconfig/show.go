@@ -0,0 +1,129 @@
+package appconfig
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/update"
+)
+
+// ShowCommand prints every effective configuration setting and its source.
+type ShowCommand struct {
+	common.Base
+	configFilePath string
+}
+
+// NewShowCommand returns a usable command registered under the parent.
+func NewShowCommand(parent common.Registerer, configFilePath string, globals *config.Data) *ShowCommand {
+	var c ShowCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("show", "Show the effective configuration and where each setting comes from")
+	c.configFilePath = configFilePath
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ShowCommand) Exec(in io.Reader, out io.Writer) error {
+	projectFilePath := c.Globals.Project.Path()
+	if projectFilePath == "" {
+		projectFilePath = "(none)"
+	}
+	text.Output(out, "User config file: %s", c.configFilePath)
+	text.Output(out, "Project config file: %s", projectFilePath)
+	text.Break(out)
+
+	tw := text.NewTable(out)
+	tw.AddHeader("SETTING", "VALUE", "SOURCE")
+
+	token, source := c.Globals.Token()
+	token = mask(token)
+	active, _ := c.Globals.ActiveProfile()
+	if source != config.SourceFlag && source != config.SourceEnvironment && active.Token == "" {
+		// Stored tokens aren't resolved here, so as not to prompt for a
+		// passphrase or run a command just to mask the result.
+		switch {
+		case c.Globals.File.TokenCommand != "":
+			token, source = "(via token_command)", config.SourceFile
+		case c.Globals.File.EncryptedToken != "":
+			token, source = "(encrypted)", config.SourceFile
+		}
+	}
+	tw.AddLine("token", orNone(token), describe(source))
+
+	endpoint, source := c.Globals.Endpoint()
+	tw.AddLine("endpoint", orNone(endpoint), describe(source))
+
+	email, source := c.Globals.Email()
+	tw.AddLine("email", orNone(email), describe(source))
+
+	serviceID, serviceIDSource := c.serviceID()
+	tw.AddLine("service_id", orNone(serviceID), serviceIDSource)
+
+	profile, source := c.Globals.Profile()
+	tw.AddLine("profile", orNone(profile), describe(source))
+
+	format, source := c.Globals.Format()
+	tw.AddLine("format", orNone(format), describe(source))
+
+	channel, source := update.ChannelStable, config.SourceDefault
+	if c.Globals.File.UpdateChannel != "" {
+		channel, source = c.Globals.File.UpdateChannel, config.SourceFile
+	}
+	tw.AddLine("update_channel", orNone(channel), describe(source))
+
+	baseURL, source := update.DefaultBaseURL, config.SourceDefault
+	if c.Globals.File.UpdateBaseURL != "" {
+		baseURL, source = c.Globals.File.UpdateBaseURL, config.SourceFile
+	}
+	tw.AddLine("update_base_url", orNone(baseURL), describe(source))
+
+	tw.Print()
+	return nil
+}
+
+// serviceID yields the default service ID for commands run from the working
+// directory, which may come from the package manifest rather than config.
+func (c *ShowCommand) serviceID() (string, string) {
+	var m manifest.File
+	if err := m.Read(manifest.Filename); err == nil && m.ServiceID != "" {
+		return m.ServiceID, fmt.Sprintf("package manifest (%s)", manifest.Filename)
+	}
+
+	if c.Globals.Project.ServiceID != "" {
+		return c.Globals.Project.ServiceID, describe(config.SourceProjectFile)
+	}
+
+	return "", describe(config.SourceUndefined)
+}
+
+// orNone renders an empty value for humans.
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// describe renders a source for humans.
+func describe(source config.Source) string {
+	if source == config.SourceUndefined {
+		return "-"
+	}
+	return source.String()
+}
+
+// mask hides all but the last four characters of a secret, and all of a secret
+// too short to safely reveal any of.
+func mask(secret string) string {
+	switch {
+	case secret == "":
+		return ""
+	case len(secret) < 16:
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
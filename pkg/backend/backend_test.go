@@ -1,256 +1,155 @@
 package backend_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestBackendCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"backend", "create", "--version", "1", "--service-id", "123", "--address", "example.com"},
-			api:       mock.API{CreateBackendFn: createBackendOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"backend", "create", "--version", "1", "--service-id", "123", "--address", "example.com"},
+			API:  mock.API{CreateBackendFn: createBackendOK},
 		},
 		{
-			args:      []string{"backend", "create", "--service-id", "123", "--version", "1", "--address", "example.com", "--name", "www.test.com"},
-			api:       mock.API{CreateBackendFn: createBackendError},
-			wantError: errTest.Error(),
+			Args: []string{"backend", "create", "--service-id", "123", "--version", "1", "--address", "example.com", "--name", "www.test.com"},
+			API:  mock.API{CreateBackendFn: createBackendError},
 		},
 		{
-			args:       []string{"backend", "create", "--service-id", "123", "--version", "1", "--address", "127.0.0.1", "--name", "www.test.com"},
-			api:        mock.API{CreateBackendFn: createBackendOK},
-			wantOutput: "Created backend www.test.com (service 123 version 1)",
+			Args: []string{"backend", "create", "--service-id", "123", "--version", "1", "--address", "127.0.0.1", "--name", "www.test.com"},
+			API:  mock.API{CreateBackendFn: createBackendOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBackendList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"backend", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListBackendsFn: listBackendsOK},
-			wantOutput: listBackendsShortOutput,
+			Args: []string{"backend", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBackendsFn: listBackendsOK},
 		},
 		{
-			args:       []string{"backend", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListBackendsFn: listBackendsOK},
-			wantOutput: listBackendsVerboseOutput,
+			Args: []string{"backend", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListBackendsFn: listBackendsOK},
 		},
 		{
-			args:       []string{"backend", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListBackendsFn: listBackendsOK},
-			wantOutput: listBackendsVerboseOutput,
+			Args: []string{"backend", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListBackendsFn: listBackendsOK},
 		},
 		{
-			args:       []string{"backend", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListBackendsFn: listBackendsOK},
-			wantOutput: listBackendsVerboseOutput,
+			Args: []string{"backend", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBackendsFn: listBackendsOK},
 		},
 		{
-			args:       []string{"-v", "backend", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListBackendsFn: listBackendsOK},
-			wantOutput: listBackendsVerboseOutput,
+			Args: []string{"-v", "backend", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBackendsFn: listBackendsOK},
 		},
 		{
-			args:      []string{"backend", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListBackendsFn: listBackendsError},
-			wantError: errTest.Error(),
+			Args: []string{"backend", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBackendsFn: listBackendsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBackendDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"backend", "describe", "--service-id", "123", "--version", "1"},
-			api:       mock.API{GetBackendFn: getBackendOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"backend", "describe", "--service-id", "123", "--version", "1"},
+			API:  mock.API{GetBackendFn: getBackendOK},
 		},
 		{
-			args:      []string{"backend", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{GetBackendFn: getBackendError},
-			wantError: errTest.Error(),
+			Args: []string{"backend", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{GetBackendFn: getBackendError},
 		},
 		{
-			args:       []string{"backend", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{GetBackendFn: getBackendOK},
-			wantOutput: describeBackendOutput,
+			Args: []string{"backend", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{GetBackendFn: getBackendOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBackendUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"backend", "update", "--service-id", "123", "--version", "2", "--new-name", "www.test.com", "--comment", ""},
-			api:       mock.API{UpdateBackendFn: updateBackendOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"backend", "update", "--service-id", "123", "--version", "2", "--new-name", "www.test.com", "--comment", ""},
+			API:  mock.API{UpdateBackendFn: updateBackendOK},
 		},
 		{
-			args: []string{"backend", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"backend", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetBackendFn:    getBackendError,
 				UpdateBackendFn: updateBackendOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"backend", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"backend", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetBackendFn:    getBackendError,
 				UpdateBackendFn: updateBackendError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"backend", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com", "--comment", ""},
-			api: mock.API{
+			Args: []string{"backend", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com", "--comment", ""},
+			API: mock.API{
 				GetBackendFn:    getBackendOK,
 				UpdateBackendFn: updateBackendOK,
 			},
-			wantOutput: "Updated backend www.example.com (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBackendDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"backend", "delete", "--service-id", "123", "--version", "1"},
-			api:       mock.API{DeleteBackendFn: deleteBackendOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"backend", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
+			API:  mock.API{DeleteBackendFn: deleteBackendOK},
 		},
 		{
-			args:      []string{"backend", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{DeleteBackendFn: deleteBackendError},
-			wantError: errTest.Error(),
+			Args: []string{"backend", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--auto-yes"},
+			API:  mock.API{DeleteBackendFn: deleteBackendError},
 		},
 		{
-			args:       []string{"backend", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{DeleteBackendFn: deleteBackendOK},
-			wantOutput: "Deleted backend www.test.com (service 123 version 1)",
+			Args: []string{"backend", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--auto-yes"},
+			API:  mock.API{DeleteBackendFn: deleteBackendOK},
+		},
+		{
+			Args: []string{"backend", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{DeleteBackendFn: deleteBackendOK},
+		},
+		{
+			Args:  []string{"backend", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:   mock.API{DeleteBackendFn: deleteBackendOK},
+			Stdin: "www.test.com\n",
+		},
+		{
+			Args:  []string{"backend", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:   mock.API{DeleteBackendFn: deleteBackendError},
+			Stdin: "y\n",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -295,67 +194,6 @@ func listBackendsError(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
 	return nil, errTest
 }
 
-var listBackendsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME         ADDRESS          PORT  COMMENT
-123      1        test.com     www.test.com     80    test
-123      1        example.com  www.example.com  443   example
-`) + "\n"
-
-var listBackendsVerboseOutput = strings.Join([]string{
-	"Fastly API token not provided",
-	"Fastly API endpoint: https://api.fastly.com",
-	"Service ID: 123",
-	"Version: 1",
-	"	Backend 1/2",
-	"		Name: test.com",
-	"		Comment: test",
-	"		Address: www.test.com",
-	"		Port: 80",
-	"		Override host: ",
-	"		Connect timeout: 0",
-	"		Max connections: 0",
-	"		First byte timeout: 0",
-	"		Between bytes timeout: 0",
-	"		Auto loadbalance: false",
-	"		Weight: 0",
-	"		Healthcheck: ",
-	"		Shield: ",
-	"		Use SSL: false",
-	"		SSL check cert: false",
-	"		SSL CA cert: ",
-	"		SSL client cert: ",
-	"		SSL client key: ",
-	"		SSL cert hostname: ",
-	"		SSL SNI hostname: ",
-	"		Min TLS version: ",
-	"		Max TLS version: ",
-	"		SSL ciphers: []",
-	"	Backend 2/2",
-	"		Name: example.com",
-	"		Comment: example",
-	"		Address: www.example.com",
-	"		Port: 443",
-	"		Override host: ",
-	"		Connect timeout: 0",
-	"		Max connections: 0",
-	"		First byte timeout: 0",
-	"		Between bytes timeout: 0",
-	"		Auto loadbalance: false",
-	"		Weight: 0",
-	"		Healthcheck: ",
-	"		Shield: ",
-	"		Use SSL: false",
-	"		SSL check cert: false",
-	"		SSL CA cert: ",
-	"		SSL client cert: ",
-	"		SSL client key: ",
-	"		SSL cert hostname: ",
-	"		SSL SNI hostname: ",
-	"		Min TLS version: ",
-	"		Max TLS version: ",
-	"		SSL ciphers: []",
-}, "\n") + "\n\n"
-
 func getBackendOK(i *fastly.GetBackendInput) (*fastly.Backend, error) {
 	return &fastly.Backend{
 		ServiceID:      i.ServiceID,
@@ -371,34 +209,6 @@ func getBackendError(i *fastly.GetBackendInput) (*fastly.Backend, error) {
 	return nil, errTest
 }
 
-var describeBackendOutput = strings.Join([]string{
-	"Service ID: 123",
-	"Version: 1",
-	"Name: test.com",
-	"Comment: test",
-	"Address: www.test.com",
-	"Port: 80",
-	"Override host: ",
-	"Connect timeout: 0",
-	"Max connections: 0",
-	"First byte timeout: 0",
-	"Between bytes timeout: 0",
-	"Auto loadbalance: false",
-	"Weight: 0",
-	"Healthcheck: ",
-	"Shield: ",
-	"Use SSL: false",
-	"SSL check cert: false",
-	"SSL CA cert: ",
-	"SSL client cert: ",
-	"SSL client key: ",
-	"SSL cert hostname: ",
-	"SSL SNI hostname: ",
-	"Min TLS version: ",
-	"Max TLS version: ",
-	"SSL ciphers: []",
-}, "\n") + "\n"
-
 func updateBackendOK(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
 	return &fastly.Backend{
 		ServiceID:      i.ServiceID,
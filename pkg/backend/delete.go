@@ -1,6 +1,7 @@
 package backend
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/common"
@@ -28,6 +29,11 @@ func NewDeleteCommand(parent common.Registerer, globals *config.Data) *DeleteCom
 
 // Exec invokes the application logic for the command.
 func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
+	prompt := fmt.Sprintf("Delete backend %s (service %s version %d)?", c.Input.Name, c.Input.ServiceID, c.Input.ServiceVersion)
+	if err := text.Confirm(out, in, c.Globals.AutoYes(), prompt, c.Input.Name); err != nil {
+		return err
+	}
+
 	if err := c.Globals.Client.DeleteBackend(&c.Input); err != nil {
 		return err
 	}
@@ -0,0 +1,155 @@
+package billing_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil/golden"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestBillingShow(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"billing", "show", "--year", "2021", "--month", "1"},
+			API:  mock.API{GetBillingFn: getBillingOK},
+		},
+		{
+			Args: []string{"billing", "show", "--year", "2021", "--month", "1", "--format", "csv"},
+			API:  mock.API{GetBillingFn: getBillingOK},
+		},
+		{
+			Args: []string{"billing", "show", "--year", "2021", "--month", "1", "--format", "json"},
+			API:  mock.API{GetBillingFn: getBillingOK},
+		},
+		{
+			Args: []string{"billing", "show", "--year", "2021", "--month", "2"},
+			API:  mock.API{GetBillingFn: getBillingError},
+		},
+		{
+			Args: []string{"billing", "show", "--year", "2021", "--month", "13"},
+		},
+		{
+			Args: []string{"billing", "show", "--year", "2021"},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func TestBillingUsage(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "1"},
+			API:  mock.API{GetUsageFn: getUsageOK},
+		},
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "1", "--format", "csv"},
+			API:  mock.API{GetUsageFn: getUsageOK},
+		},
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "1", "--by-service"},
+			API:  mock.API{GetUsageByServiceFn: getUsageByServiceOK, GetBillingFn: getBillingOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "1", "--by-service", "--format", "csv"},
+			API:  mock.API{GetUsageByServiceFn: getUsageByServiceOK, GetBillingFn: getBillingOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "1", "--by-service", "--format", "json"},
+			API:  mock.API{GetUsageByServiceFn: getUsageByServiceOK, GetBillingFn: getBillingOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "1", "--by-service", "--region", "europe"},
+			API:  mock.API{GetUsageByServiceFn: getUsageByServiceOK, GetUsageFn: getUsageOK, GetBillingFn: getBillingOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "2", "--by-service"},
+			API:  mock.API{GetUsageByServiceFn: getUsageByServiceOK, GetBillingFn: getBillingError},
+		},
+		{
+			Args: []string{"billing", "usage", "--year", "2021", "--month", "2"},
+			API:  mock.API{GetUsageFn: getUsageFailure},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func getBillingOK(i *fastly.GetBillingInput) (*fastly.Billing, error) {
+	start := time.Date(int(i.Year), time.Month(i.Month), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0).Add(-time.Second)
+	return &fastly.Billing{
+		InvoiceID: "inv1",
+		StartTime: &start,
+		EndTime:   &end,
+		Status:    &fastly.BillingStatus{InvoiceID: "inv1", Status: "Pending"},
+		Total: &fastly.BillingTotal{
+			PlanName:      "Developer",
+			Bandwidth:     12.5,
+			BandwidthCost: 100,
+			Requests:      4000,
+			RequestsCost:  30,
+			Extras: []*fastly.BillingExtra{
+				{Name: "TLS", Setup: 0, Recurring: 20},
+			},
+			Discount: 15,
+			Cost:     135,
+		},
+	}, nil
+}
+
+func getBillingError(i *fastly.GetBillingInput) (*fastly.Billing, error) {
+	return nil, errTest
+}
+
+// getUsageOK returns the usage of the account, which for every service and
+// region is that of getUsageByServiceOK.
+func getUsageOK(i *fastly.GetUsageInput) (*fastly.UsageResponse, error) {
+	data := fastly.RegionsUsage{
+		"europe": {Requests: 1000, Bandwidth: 3000},
+		"usa":    {Requests: 3000, Bandwidth: 7000},
+	}
+	if i.Region != "" {
+		data = fastly.RegionsUsage{i.Region: data[i.Region]}
+	}
+	return &fastly.UsageResponse{Status: "success", Data: &data}, nil
+}
+
+func getUsageFailure(i *fastly.GetUsageInput) (*fastly.UsageResponse, error) {
+	return &fastly.UsageResponse{Status: "error", Message: "bad range"}, nil
+}
+
+func getUsageByServiceOK(i *fastly.GetUsageInput) (*fastly.UsageByServiceResponse, error) {
+	data := fastly.ServicesByRegionsUsage{
+		"europe": {
+			"123": {Requests: 1000, Bandwidth: 1000},
+			"789": {Requests: 0, Bandwidth: 2000},
+		},
+		"usa": {
+			"123": {Requests: 1000, Bandwidth: 1000},
+			"456": {Requests: 2000, Bandwidth: 6000},
+		},
+	}
+	if i.Region != "" {
+		data = fastly.ServicesByRegionsUsage{i.Region: data[i.Region]}
+	}
+	return &fastly.UsageByServiceResponse{Status: "success", Data: &data}, nil
+}
+
+// listServicesOK doesn't list service 789, as though it's been deleted.
+func listServicesOK(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+	return []*fastly.Service{
+		{ID: "123", Name: "Foo"},
+		{ID: "456", Name: "Bar"},
+	}, nil
+}
+
+var errTest = errors.New("fixture error")
@@ -0,0 +1,3 @@
+// Package billing contains commands to report on the invoices and usage of
+// Fastly accounts, in formats suited to spreadsheets.
+package billing
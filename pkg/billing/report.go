@@ -0,0 +1,117 @@
+package billing
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// money is an amount of the account's currency, which is written with two
+// decimal places.
+type money float64
+
+func (m money) String() string {
+	return fmt.Sprintf("%.2f", float64(m))
+}
+
+// MarshalJSON implements json.Marshaler, rounding to the nearest cent.
+func (m money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(math.Round(float64(m)*100) / 100)
+}
+
+// share returns the part of a cost attributable to part of a total quantity.
+func share(cost float64, part, total uint64) money {
+	if total == 0 {
+		return 0
+	}
+	return money(cost * float64(part) / float64(total))
+}
+
+// report is tabular data, which is written as a table, CSV with a header row,
+// or a JSON array of objects. Missing values are nil, and written as null in
+// JSON, or left empty otherwise.
+type report struct {
+	columns []string // names as used by CSV and JSON, e.g. service_id
+	rows    [][]interface{}
+}
+
+func cell(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func (r *report) add(values ...interface{}) {
+	r.rows = append(r.rows, values)
+}
+
+func (r *report) write(out io.Writer, format string) error {
+	switch format {
+	case "csv":
+		w := csv.NewWriter(out)
+		if err := w.Write(r.columns); err != nil {
+			return err
+		}
+		for _, row := range r.rows {
+			record := make([]string, len(row))
+			for i, v := range row {
+				record[i] = cell(v)
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+
+	case "json":
+		objects := make([]map[string]interface{}, 0, len(r.rows))
+		for _, row := range r.rows {
+			o := make(map[string]interface{}, len(row))
+			for i, v := range row {
+				o[r.columns[i]] = v
+			}
+			objects = append(objects, o)
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(objects)
+
+	default:
+		tw := text.NewTable(out)
+		header := make([]interface{}, len(r.columns))
+		for i, c := range r.columns {
+			header[i] = strings.ToUpper(strings.ReplaceAll(c, "_", " "))
+		}
+		tw.AddHeader(header...)
+		for _, row := range r.rows {
+			line := make([]interface{}, len(row))
+			for i, v := range row {
+				line[i] = cell(v)
+			}
+			tw.AddLine(line...)
+		}
+		tw.Print()
+		return nil
+	}
+}
+
+// month returns the start and end of a calendar month, in UTC.
+func month(year, month int) (start, end time.Time, err error) {
+	if year < 2000 || year > 9999 || month < 1 || month > 12 {
+		return start, end, errors.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: %04d-%02d isn't a valid month", year, month),
+			Remediation: "Provide a year, e.g. --year 2021, and a month from 1 to 12, e.g. --month 1.",
+		}
+	}
+	start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0), nil
+}
@@ -0,0 +1,72 @@
+package billing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// ShowCommand calls the Fastly API to show the invoice of a month.
+type ShowCommand struct {
+	common.Base
+	year   int
+	month  int
+	format string
+}
+
+// NewShowCommand returns a usable command registered under the parent.
+func NewShowCommand(parent common.Registerer, globals *config.Data) *ShowCommand {
+	var c ShowCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("show", "Show the invoice of a month, or the estimate of the current month")
+	c.CmdClause.Flag("year", "Year of the invoice, e.g. 2021").Required().IntVar(&c.year)
+	c.CmdClause.Flag("month", "Month of the invoice, from 1 to 12").Required().IntVar(&c.month)
+	c.CmdClause.Flag("format", "Output format (csv, json)").EnumVar(&c.format, "csv", "json")
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ShowCommand) Exec(in io.Reader, out io.Writer) error {
+	c.format = c.Globals.CommandFormat(c.format)
+
+	if _, _, err := month(c.year, c.month); err != nil {
+		return err
+	}
+
+	bill, err := c.Globals.Client.GetBilling(&fastly.GetBillingInput{
+		Year:  uint16(c.year),
+		Month: uint8(c.month),
+	})
+	if err != nil {
+		return err
+	}
+	total := bill.Total
+	if total == nil {
+		total = &fastly.BillingTotal{}
+	}
+
+	r := report{columns: []string{"item", "quantity", "unit", "cost"}}
+	r.add("Bandwidth", total.Bandwidth, "GB", money(total.BandwidthCost))
+	r.add("Requests", total.Requests, "requests", money(total.RequestsCost))
+	for _, e := range total.Extras {
+		r.add(e.Name, nil, nil, money(e.Setup+e.Recurring))
+	}
+	r.add("Discount", nil, nil, money(-total.Discount))
+	r.add("Total", nil, nil, money(total.Cost))
+
+	if c.format == "" {
+		fmt.Fprintf(out, "Invoice ID: %s\n", bill.InvoiceID)
+		if bill.StartTime != nil && bill.EndTime != nil {
+			fmt.Fprintf(out, "Period (UTC): %s to %s\n", bill.StartTime.UTC().Format(common.TimeFormat), bill.EndTime.UTC().Format(common.TimeFormat))
+		}
+		if bill.Status != nil {
+			fmt.Fprintf(out, "Status: %s\n", bill.Status.Status)
+		}
+		fmt.Fprintf(out, "Plan: %s\n", total.PlanName)
+		fmt.Fprintf(out, "---\n")
+	}
+	return r.write(out, c.format)
+}
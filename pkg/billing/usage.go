@@ -0,0 +1,196 @@
+package billing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+const statusSuccess = "success"
+
+// UsageCommand calls the Fastly API to report the usage of a month, by region
+// or by service.
+type UsageCommand struct {
+	common.Base
+	year      int
+	month     int
+	region    string
+	byService bool
+	format    string
+}
+
+// NewUsageCommand returns a usable command registered under the parent.
+func NewUsageCommand(parent common.Registerer, globals *config.Data) *UsageCommand {
+	var c UsageCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("usage", "Report the requests and bandwidth of a month, by region or by service")
+	c.CmdClause.Flag("year", "Year of the usage, e.g. 2021").Required().IntVar(&c.year)
+	c.CmdClause.Flag("month", "Month of the usage, from 1 to 12").Required().IntVar(&c.month)
+	c.CmdClause.Flag("region", "Only report usage in this region ('stats regions' to list)").StringVar(&c.region)
+	c.CmdClause.Flag("by-service", "Report usage by service, with its share of the invoice's request and bandwidth costs").BoolVar(&c.byService)
+	c.CmdClause.Flag("format", "Output format (csv, json)").EnumVar(&c.format, "csv", "json")
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UsageCommand) Exec(in io.Reader, out io.Writer) error {
+	c.format = c.Globals.CommandFormat(c.format)
+
+	start, end, err := month(c.year, c.month)
+	if err != nil {
+		return err
+	}
+	input := fastly.GetUsageInput{
+		From:   fmt.Sprint(start.Unix()),
+		To:     fmt.Sprint(end.Unix()),
+		By:     "day",
+		Region: c.region,
+	}
+
+	if c.byService {
+		return c.usageByService(&input, out)
+	}
+
+	resp, err := c.Globals.Client.GetUsage(&input)
+	if err != nil {
+		return err
+	}
+	if resp.Status != statusSuccess {
+		return fmt.Errorf("non-success response: %s", resp.Message)
+	}
+
+	r := report{columns: []string{"region", "requests", "bandwidth_bytes"}}
+	if resp.Data != nil {
+		regions := make([]string, 0, len(*resp.Data))
+		for region := range *resp.Data {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+		for _, region := range regions {
+			if u := (*resp.Data)[region]; u != nil {
+				r.add(region, u.Requests, u.Bandwidth)
+			}
+		}
+	}
+	return r.write(out, c.format)
+}
+
+// usageByService reports the usage of each service, summed across regions,
+// joined with its name and the invoice of the month. The invoice doesn't break
+// costs down by service, so each service is attributed the share of the request
+// and bandwidth costs matching its share of the account's requests and
+// bandwidth.
+func (c *UsageCommand) usageByService(input *fastly.GetUsageInput, out io.Writer) error {
+	resp, err := c.Globals.Client.GetUsageByService(input)
+	if err != nil {
+		return err
+	}
+	if resp.Status != statusSuccess {
+		return fmt.Errorf("non-success response: %s", resp.Message)
+	}
+
+	bill, err := c.Globals.Client.GetBilling(&fastly.GetBillingInput{
+		Year:  uint16(c.year),
+		Month: uint8(c.month),
+	})
+	if err != nil {
+		return err
+	}
+	total := bill.Total
+	if total == nil {
+		total = &fastly.BillingTotal{}
+	}
+
+	services, err := c.Globals.Client.ListServices(&fastly.ListServicesInput{})
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(services))
+	for _, s := range services {
+		names[s.ID] = s.Name
+	}
+
+	usage := make(map[string]*fastly.Usage)
+	var sum fastly.Usage
+	if resp.Data != nil {
+		for _, byService := range *resp.Data {
+			if byService == nil {
+				continue
+			}
+			for id, u := range *byService {
+				if u == nil {
+					continue
+				}
+				if usage[id] == nil {
+					usage[id] = &fastly.Usage{}
+				}
+				usage[id].Requests += u.Requests
+				usage[id].Bandwidth += u.Bandwidth
+				sum.Requests += u.Requests
+				sum.Bandwidth += u.Bandwidth
+			}
+		}
+	}
+
+	// With --region, services' shares are still of the account's usage in
+	// every region, as the invoice is.
+	if c.region != "" {
+		all := *input
+		all.Region = ""
+		resp, err := c.Globals.Client.GetUsage(&all)
+		if err != nil {
+			return err
+		}
+		if resp.Status != statusSuccess {
+			return fmt.Errorf("non-success response: %s", resp.Message)
+		}
+		sum = fastly.Usage{}
+		if resp.Data != nil {
+			for _, u := range *resp.Data {
+				if u != nil {
+					sum.Requests += u.Requests
+					sum.Bandwidth += u.Bandwidth
+				}
+			}
+		}
+	}
+
+	type row struct {
+		id                          string
+		usage                       *fastly.Usage
+		requestsCost, bandwidthCost money
+	}
+	rows := make([]row, 0, len(usage))
+	for id, u := range usage {
+		rows = append(rows, row{
+			id:            id,
+			usage:         u,
+			requestsCost:  share(total.RequestsCost, u.Requests, sum.Requests),
+			bandwidthCost: share(total.BandwidthCost, u.Bandwidth, sum.Bandwidth),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		ci, cj := rows[i].requestsCost+rows[i].bandwidthCost, rows[j].requestsCost+rows[j].bandwidthCost
+		if ci != cj {
+			return ci > cj
+		}
+		return rows[i].id < rows[j].id
+	})
+
+	r := report{columns: []string{"service_id", "service_name", "requests", "requests_cost", "bandwidth_bytes", "bandwidth_cost", "cost"}}
+	for _, row := range rows {
+		r.add(row.id, names[row.id], row.usage.Requests, row.requestsCost, row.usage.Bandwidth, row.bandwidthCost, row.requestsCost+row.bandwidthCost)
+	}
+	if err := r.write(out, c.format); err != nil {
+		return err
+	}
+	if c.format == "" {
+		text.Info(out, "Costs are shares of the request and bandwidth costs of invoice %s, in proportion to usage, and exclude extras and discounts", bill.InvoiceID)
+	}
+	return nil
+}
@@ -1,5 +1,28 @@
 package common
 
+import (
+	"fmt"
+	"time"
+)
+
 // TimeFormat is a format string for time.Format that reflects what the Fastly
 // web UI uses.
 const TimeFormat = "2006-01-02 15:04"
+
+// TimeLayouts are the layouts accepted by ParseTime, and other flags taking a
+// time: RFC 3339, optionally without seconds.
+var TimeLayouts = []string{time.RFC3339, "2006-01-02T15:04Z07:00"}
+
+// ParseTime parses the value of a time flag, such as --from, which is either a
+// time or a duration before now.
+func ParseTime(flag, s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range TimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("error parsing --%s: %q isn't a valid time, want an RFC 3339 time with an explicit time zone, e.g. 2021-01-02T15:04Z, or a duration before now, e.g. 24h", flag, s)
+}
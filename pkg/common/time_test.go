@@ -0,0 +1,34 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	for _, testcase := range []struct {
+		value     string
+		want      time.Time
+		wantError string
+	}{
+		{value: "24h", want: now.Add(-24 * time.Hour)},
+		{value: "90m", want: now.Add(-90 * time.Minute)},
+		{value: "2021-01-01T09:30:15Z", want: time.Date(2021, 1, 1, 9, 30, 15, 0, time.UTC)},
+		{value: "2021-01-01T09:30Z", want: time.Date(2021, 1, 1, 9, 30, 0, 0, time.UTC)},
+		{value: "2021-01-01", wantError: `error parsing --from: "2021-01-01" isn't a valid time`},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			have, err := common.ParseTime("from", testcase.value, now)
+			if testcase.wantError != "" {
+				testutil.AssertErrorContains(t, err, testcase.wantError)
+				return
+			}
+			testutil.AssertNoError(t, err)
+			testutil.AssertBool(t, true, testcase.want.Equal(have))
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/credential"
+	"github.com/fastly/cli/pkg/logging"
+	"github.com/fastly/go-fastly/v2/fastly"
+	"github.com/fastly/kingpin"
+)
+
+const (
+	// DefaultCacheTTL is how long completion results are reused before the
+	// API is queried again.
+	DefaultCacheTTL = 5 * time.Minute
+
+	// DefaultTimeout bounds how long the shell waits on the API for a single
+	// completion. Completion is abandoned rather than blocking the user.
+	DefaultTimeout = 2 * time.Second
+)
+
+// ClientFactory creates a Fastly API client from a token and endpoint. It
+// mirrors app.APIClientFactory, which can't be imported here.
+type ClientFactory func(token, endpoint string) (api.Interface, error)
+
+// Completer attaches dynamic completion hints to the flags of an application.
+// Results are cached on disk, keyed by endpoint, token and resource, so that
+// repeated tab presses don't each cost an API round trip.
+type Completer struct {
+	Globals       *config.Data
+	ClientFactory ClientFactory
+	CacheDir      string
+	CacheTTL      time.Duration
+	Timeout       time.Duration
+
+	client   api.Interface
+	resolved bool
+}
+
+// New returns a Completer with the default cache location and timeouts.
+func New(globals *config.Data, cf ClientFactory) *Completer {
+	var dir string
+	if d, err := os.UserCacheDir(); err == nil {
+		dir = filepath.Join(d, "fastly", "completion")
+	}
+	return &Completer{
+		Globals:       globals,
+		ClientFactory: cf,
+		CacheDir:      dir,
+		CacheTTL:      DefaultCacheTTL,
+		Timeout:       DefaultTimeout,
+	}
+}
+
+// Register walks every command in the application and attaches hints to the
+// flags which identify a resource: --service-id, --version, --dictionary-id,
+// and --name for backends and logging endpoints. Hints are resolved lazily, so
+// calling Register is cheap; it should only be called when completing.
+func (c *Completer) Register(app *kingpin.Application) {
+	for _, m := range app.Model().Commands {
+		c.register(app.GetCommand(m.Name), m, nil)
+	}
+}
+
+func (c *Completer) register(cmd *kingpin.CmdClause, model *kingpin.CmdModel, path []string) {
+	if cmd == nil {
+		return
+	}
+	path = append(path, model.Name)
+
+	if f := cmd.GetFlag("service-id"); f != nil {
+		f.HintAction(c.ServiceIDs)
+	}
+	if f := cmd.GetFlag("version"); f != nil {
+		f.HintAction(func() []string {
+			return c.Versions(c.serviceID(cmd))
+		})
+	}
+	if f := cmd.GetFlag("dictionary-id"); f != nil {
+		f.HintAction(func() []string {
+			return c.DictionaryIDs(c.serviceID(cmd), flagInt(cmd, "version"))
+		})
+	}
+	if f := cmd.GetFlag("name"); f != nil && model.Name != "create" {
+		if kind := resourceKind(path); kind != "" {
+			f.HintAction(func() []string {
+				return c.Names(kind, c.serviceID(cmd), flagInt(cmd, "version"))
+			})
+		}
+	}
+
+	for _, m := range model.Commands {
+		c.register(cmd.GetCommand(m.Name), m, path)
+	}
+}
+
+// resourceKind returns the kind of resource a --name flag refers to, given
+// the path of the command it belongs to, or an empty string if unsupported.
+func resourceKind(path []string) string {
+	switch {
+	case len(path) == 2 && path[0] == "backend":
+		return "backend"
+	case len(path) == 3 && path[0] == "logging":
+		if _, ok := logging.Lists[path[1]]; ok {
+			return path[1]
+		}
+	}
+	return ""
+}
+
+// ServiceIDs returns the IDs of every service on the account.
+func (c *Completer) ServiceIDs() []string {
+	return c.cached("services", func(client api.Interface) ([]string, error) {
+		services, err := client.ListServices(&fastly.ListServicesInput{})
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(services))
+		for _, s := range services {
+			ids = append(ids, s.ID)
+		}
+		return ids, nil
+	})
+}
+
+// Versions returns the version numbers of a service.
+func (c *Completer) Versions(serviceID string) []string {
+	if serviceID == "" {
+		return nil
+	}
+	return c.cached("versions/"+serviceID, func(client api.Interface) ([]string, error) {
+		versions, err := client.ListVersions(&fastly.ListVersionsInput{ServiceID: serviceID})
+		if err != nil {
+			return nil, err
+		}
+		numbers := make([]string, 0, len(versions))
+		for _, v := range versions {
+			numbers = append(numbers, strconv.Itoa(v.Number))
+		}
+		return numbers, nil
+	})
+}
+
+// DictionaryIDs returns the IDs of the dictionaries on a service version. If
+// version is zero, the active (or else latest) version is used.
+func (c *Completer) DictionaryIDs(serviceID string, version int) []string {
+	if serviceID == "" {
+		return nil
+	}
+	return c.cached(fmt.Sprintf("dictionaries/%s/%d", serviceID, version), func(client api.Interface) ([]string, error) {
+		v, err := resolveVersion(client, serviceID, version)
+		if err != nil {
+			return nil, err
+		}
+		dictionaries, err := client.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: serviceID, ServiceVersion: v})
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(dictionaries))
+		for _, d := range dictionaries {
+			ids = append(ids, d.ID)
+		}
+		return ids, nil
+	})
+}
+
+// Names returns the names of the backends, or logging endpoints of the given
+// kind, on a service version. If version is zero, the active (or else latest)
+// version is used.
+func (c *Completer) Names(kind, serviceID string, version int) []string {
+	list, ok := logging.Lists[kind]
+	if kind == "backend" {
+		list, ok = listBackends, true
+	}
+	if !ok || serviceID == "" {
+		return nil
+	}
+	return c.cached(fmt.Sprintf("%s/%s/%d", kind, serviceID, version), func(client api.Interface) ([]string, error) {
+		v, err := resolveVersion(client, serviceID, version)
+		if err != nil {
+			return nil, err
+		}
+		resources, err := list(client, serviceID, v)
+		if err != nil {
+			return nil, err
+		}
+		return names(resources), nil
+	})
+}
+
+// serviceID returns the --service-id flag value of cmd, falling back to the
+// package manifest in the current directory.
+func (c *Completer) serviceID(cmd *kingpin.CmdClause) string {
+	if id := flagString(cmd, "service-id"); id != "" {
+		return id
+	}
+	var m manifest.File
+	if err := m.Read(manifest.Filename); err == nil {
+		return m.ServiceID
+	}
+	return ""
+}
+
+// token yields the API token. A token stored via token_command, or an
+// encrypted_token whose passphrase is set in the environment, is resolved on
+// first use; completion never prompts for a passphrase.
+func (c *Completer) token() string {
+	if !c.resolved {
+		c.resolved = true
+		credential.Resolve(c.Globals, nil, ioutil.Discard) // no token means no completions
+	}
+	token, _ := c.Globals.Token()
+	return token
+}
+
+// cached returns the cached values for key if they're fresh, otherwise it
+// calls fetch, with a timeout, and caches the result. Any failure results in
+// no completions rather than an error, as there's nowhere to report it.
+func (c *Completer) cached(key string, fetch func(api.Interface) ([]string, error)) []string {
+	token := c.token()
+	endpoint, _ := c.Globals.Endpoint()
+	if token == "" {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + token + "\x00" + key))
+	path := filepath.Join(c.CacheDir, hex.EncodeToString(sum[:])+".json")
+
+	if c.CacheDir != "" {
+		if values, ok := readCache(path, c.CacheTTL); ok {
+			return values
+		}
+	}
+
+	if c.client == nil {
+		client, err := c.ClientFactory(token, endpoint)
+		if err != nil {
+			return nil
+		}
+		c.client = client
+	}
+
+	type result struct {
+		values []string
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		values, err := fetch(c.client)
+		done <- result{values, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil
+		}
+		sort.Strings(r.values)
+		if c.CacheDir != "" {
+			writeCache(path, r.values) // best effort
+		}
+		return r.values
+	case <-time.After(c.Timeout):
+		return nil
+	}
+}
+
+type cacheEntry struct {
+	Created time.Time `json:"created"`
+	Values  []string  `json:"values"`
+}
+
+func readCache(path string, ttl time.Duration) ([]string, bool) {
+	b, err := ioutil.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, false
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(b, &e); err != nil || time.Since(e.Created) > ttl {
+		return nil, false
+	}
+	return e.Values, true
+}
+
+func writeCache(path string, values []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), config.DirectoryPermissions); err != nil {
+		return err
+	}
+	b, err := json.Marshal(cacheEntry{Created: time.Now(), Values: values})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, config.FilePermissions)
+}
+
+// resolveVersion returns version if it's set, otherwise the active version of
+// the service, or its latest version if none is active.
+func resolveVersion(client api.Interface, serviceID string, version int) (int, error) {
+	if version > 0 {
+		return version, nil
+	}
+	versions, err := client.ListVersions(&fastly.ListVersionsInput{ServiceID: serviceID})
+	if err != nil {
+		return 0, err
+	}
+	var latest int
+	for _, v := range versions {
+		if v.Active {
+			return v.Number, nil
+		}
+		if v.Number > latest {
+			latest = v.Number
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("service %s has no versions", serviceID)
+	}
+	return latest, nil
+}
+
+func flagString(cmd *kingpin.CmdClause, name string) string {
+	f := cmd.GetFlag(name)
+	if f == nil {
+		return ""
+	}
+	if v := f.Model().Value; v != nil {
+		return v.String()
+	}
+	return ""
+}
+
+func flagInt(cmd *kingpin.CmdClause, name string) int {
+	i, _ := strconv.Atoi(flagString(cmd, name))
+	return i
+}
+
+// names extracts the Name field from a slice of pointers to structs, which
+// is the shape of every go-fastly List method result we complete.
+func names(resources interface{}) []string {
+	var ns []string
+	v := reflect.ValueOf(resources)
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		e := reflect.Indirect(v.Index(i))
+		if e.Kind() != reflect.Struct {
+			continue
+		}
+		if f := e.FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String {
+			ns = append(ns, f.String())
+		}
+	}
+	return ns
+}
+
+func listBackends(c api.Interface, id string, v int) (interface{}, error) {
+	return c.ListBackends(&fastly.ListBackendsInput{ServiceID: id, ServiceVersion: v})
+}
+
+// FishScript is the fish shell completion script, emitted by the
+// --completion-script-fish flag. Like the bash and zsh scripts generated by
+// kingpin, it delegates to the hidden --completion-bash flag.
+const FishScript = `function __fastly_complete
+    set -l args (commandline -opc)
+    set -e args[1]
+    fastly --completion-bash $args (commandline -ct)
+end
+complete -c fastly -f -a '(__fastly_complete)'
+`
@@ -0,0 +1,247 @@
+package completion_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/completion"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/credential"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+	"github.com/fastly/kingpin"
+)
+
+func TestRegister(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "service ID",
+			args: []string{"backend", "describe", "--service-id", ""},
+			want: []string{"123", "456"},
+		},
+		{
+			name: "version",
+			args: []string{"backend", "describe", "--service-id", "123", "--version", ""},
+			want: []string{"1", "2"},
+		},
+		{
+			name: "backend name on active version",
+			args: []string{"backend", "describe", "--service-id", "123", "--name", ""},
+			want: []string{"origin-a", "origin-b"},
+		},
+		{
+			name: "logging endpoint name",
+			args: []string{"logging", "s3", "delete", "--service-id", "123", "--version", "1", "--name", ""},
+			want: []string{"bucket"},
+		},
+		{
+			name: "dictionary ID",
+			args: []string{"dictionaryitem", "list", "--service-id", "123", "--dictionary-id", ""},
+			want: []string{"dict"},
+		},
+		{
+			name: "no service ID",
+			args: []string{"backend", "describe", "--version", ""},
+			want: nil,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "fastly-completion-*")
+			testutil.AssertNoError(t, err)
+			defer os.RemoveAll(dir)
+
+			app, globals := newApp()
+			c := completion.New(globals, mock.APIClient(completionAPI))
+			c.CacheDir = dir
+			c.Register(app)
+
+			have := complete(t, app, testcase.args)
+			testutil.AssertEqual(t, testcase.want, have)
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fastly-completion-*")
+	testutil.AssertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var calls int
+	a := mock.API{
+		ListServicesFn: func(*fastly.ListServicesInput) ([]*fastly.Service, error) {
+			calls++
+			return []*fastly.Service{{ID: "123"}}, nil
+		},
+	}
+	_, globals := newApp()
+
+	c := completion.New(globals, mock.APIClient(a))
+	c.CacheDir = dir
+	testutil.AssertEqual(t, []string{"123"}, c.ServiceIDs())
+	testutil.AssertEqual(t, []string{"123"}, c.ServiceIDs())
+	testutil.AssertEqual(t, 1, calls)
+
+	// A different token must not see the cached results.
+	globals.Flag.Token = "other"
+	testutil.AssertEqual(t, []string{"123"}, c.ServiceIDs())
+	testutil.AssertEqual(t, 2, calls)
+
+	// Expired entries are refreshed.
+	c.CacheTTL = -time.Second
+	testutil.AssertEqual(t, []string{"123"}, c.ServiceIDs())
+	testutil.AssertEqual(t, 3, calls)
+}
+
+func TestTimeoutAndErrors(t *testing.T) {
+	_, globals := newApp()
+
+	slow := mock.API{
+		ListServicesFn: func(*fastly.ListServicesInput) ([]*fastly.Service, error) {
+			time.Sleep(time.Second)
+			return []*fastly.Service{{ID: "123"}}, nil
+		},
+	}
+	c := completion.New(globals, mock.APIClient(slow))
+	c.CacheDir = ""
+	c.Timeout = 10 * time.Millisecond
+	testutil.AssertEqual(t, []string(nil), c.ServiceIDs())
+
+	failing := mock.API{
+		ListServicesFn: func(*fastly.ListServicesInput) ([]*fastly.Service, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	c = completion.New(globals, mock.APIClient(failing))
+	c.CacheDir = ""
+	testutil.AssertEqual(t, []string(nil), c.ServiceIDs())
+
+	globals.Flag.Token = ""
+	c = completion.New(globals, func(string, string) (api.Interface, error) {
+		t.Fatal("client should not be constructed without a token")
+		return nil, nil
+	})
+	testutil.AssertEqual(t, []string(nil), c.ServiceIDs())
+}
+
+func TestStoredToken(t *testing.T) {
+	encrypted, err := credential.Encrypt("stored", "passphrase")
+	testutil.AssertNoError(t, err)
+
+	for _, testcase := range []struct {
+		name      string
+		file      config.File
+		env       config.Environment
+		wantToken string
+	}{
+		{
+			name:      "token_command",
+			file:      config.File{TokenCommand: "echo stored"},
+			wantToken: "stored",
+		},
+		{
+			name:      "encrypted token with passphrase from environment",
+			file:      config.File{EncryptedToken: encrypted},
+			env:       config.Environment{CredentialsPassphrase: "passphrase"},
+			wantToken: "stored",
+		},
+		{
+			name: "encrypted token without passphrase",
+			file: config.File{EncryptedToken: encrypted},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			globals := &config.Data{File: testcase.file, Env: testcase.env}
+
+			var token string
+			c := completion.New(globals, func(t, _ string) (api.Interface, error) {
+				token = t
+				return mock.API{
+					ListServicesFn: func(*fastly.ListServicesInput) ([]*fastly.Service, error) {
+						return []*fastly.Service{{ID: "123"}}, nil
+					},
+				}, nil
+			})
+			c.CacheDir = ""
+			c.ServiceIDs()
+			testutil.AssertString(t, testcase.wantToken, token)
+		})
+	}
+}
+
+// newApp returns a minimal application mirroring the shape of the real
+// backend, logging and dictionaryitem commands.
+func newApp() (*kingpin.Application, *config.Data) {
+	globals := &config.Data{Flag: config.Flag{Token: "123"}}
+	app := kingpin.New("fastly", "")
+	app.Terminate(nil)
+
+	backend := app.Command("backend", "").Command("describe", "")
+	backend.Flag("service-id", "").String()
+	backend.Flag("version", "").Int()
+	backend.Flag("name", "").String()
+
+	s3 := app.Command("logging", "").Command("s3", "").Command("delete", "")
+	s3.Flag("service-id", "").String()
+	s3.Flag("version", "").Int()
+	s3.Flag("name", "").String()
+
+	item := app.Command("dictionaryitem", "").Command("list", "")
+	item.Flag("service-id", "").String()
+	item.Flag("dictionary-id", "").String()
+
+	return app, globals
+}
+
+// complete runs kingpin's bash completion for args and returns the options
+// it writes to stdout.
+func complete(t *testing.T, app *kingpin.Application, args []string) []string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	testutil.AssertNoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	app.Parse(append([]string{"--completion-bash"}, args...))
+	os.Stdout = stdout
+	w.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	if buf.Len() == 0 {
+		return nil
+	}
+	return strings.Split(buf.String(), "\n")
+}
+
+var completionAPI = mock.API{
+	ListServicesFn: func(*fastly.ListServicesInput) ([]*fastly.Service, error) {
+		return []*fastly.Service{{ID: "456"}, {ID: "123"}}, nil
+	},
+	ListVersionsFn: func(*fastly.ListVersionsInput) ([]*fastly.Version, error) {
+		return []*fastly.Version{{Number: 1}, {Number: 2, Active: true}}, nil
+	},
+	ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+		if i.ServiceVersion != 2 {
+			return nil, errors.New("expected active version")
+		}
+		return []*fastly.Backend{{Name: "origin-b"}, {Name: "origin-a"}}, nil
+	},
+	ListS3sFn: func(i *fastly.ListS3sInput) ([]*fastly.S3, error) {
+		return []*fastly.S3{{Name: "bucket"}}, nil
+	},
+	ListDictionariesFn: func(*fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) {
+		return []*fastly.Dictionary{{ID: "dict"}}, nil
+	},
+}
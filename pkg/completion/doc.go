@@ -0,0 +1,3 @@
+// Package completion provides dynamic shell completion of resource IDs and
+// names, by querying the Fastly API while the shell is completing a flag.
+package completion
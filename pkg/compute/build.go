@@ -1,11 +1,12 @@
 package compute
 
 import (
-	"bufio"
+	"bytes"
 	"crypto/rand"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -69,6 +70,8 @@ type BuildCommand struct {
 	lang       string
 	includeSrc bool
 	force      bool
+	gitignore  bool
+	listFiles  bool
 }
 
 // NewBuildCommand returns a usable command registered under the parent.
@@ -81,6 +84,8 @@ func NewBuildCommand(parent common.Registerer, client api.HTTPClient, globals *c
 	c.CmdClause.Flag("language", "Language type").StringVar(&c.lang)
 	c.CmdClause.Flag("include-source", "Include source code in built package").BoolVar(&c.includeSrc)
 	c.CmdClause.Flag("force", "Skip verification steps and force build").BoolVar(&c.force)
+	c.CmdClause.Flag("gitignore", "Also exclude files ignored by .gitignore, unless re-included by .fastlyignore").BoolVar(&c.gitignore)
+	c.CmdClause.Flag("list-files", "List the files to be packaged, with their sizes, without creating the package archive").BoolVar(&c.listFiles)
 	return &c
 }
 
@@ -166,7 +171,11 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
-	progress.Step("Creating package archive...")
+	if c.listFiles {
+		progress.Step("Listing package files...")
+	} else {
+		progress.Step("Creating package archive...")
+	}
 
 	dest := filepath.Join("pkg", fmt.Sprintf("%s.tar.gz", name))
 
@@ -175,25 +184,42 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}
 	files = append(files, language.IncludeFiles...)
 
-	ignoreFiles, err := getIgnoredFiles(IgnoreFilePath)
+	ignoreFiles := []string{IgnoreFilePath}
+	if c.gitignore {
+		ignoreFiles = []string{GitIgnoreFilePath, IgnoreFilePath}
+	}
+	ignore, err := getIgnoreList(ignoreFiles...)
 	if err != nil {
 		return err
 	}
 
-	binFiles, err := getNonIgnoredFiles("bin", ignoreFiles)
+	binFiles, err := getNonIgnoredFiles("bin", ignore)
 	if err != nil {
 		return err
 	}
 	files = append(files, binFiles...)
 
 	if c.includeSrc {
-		srcFiles, err := getNonIgnoredFiles(language.SourceDirectory, ignoreFiles)
+		srcFiles, err := getNonIgnoredFiles(language.SourceDirectory, ignore)
 		if err != nil {
 			return err
 		}
 		files = append(files, srcFiles...)
 	}
 
+	// The files are only listed, rather than packaged, printing the list once
+	// progress is done.
+	if c.listFiles {
+		var list bytes.Buffer
+		if err := listPackageFiles(&list, fileNameWithoutExtension(dest), files); err != nil {
+			return err
+		}
+		progress.Done()
+		text.Break(out)
+		list.WriteTo(out)
+		return nil
+	}
+
 	err = createPackageArchive(files, dest)
 	if err != nil {
 		return fmt.Errorf("error creating package archive: %w", err)
@@ -266,63 +292,21 @@ func fileNameWithoutExtension(filename string) string {
 	return base
 }
 
-// getIgnoredFiles reads the .fastlyignore file line-by-line and expands the
-// glob pattern into a map containing all files it matches. If no ignore file
-// is present it returns an empty map.
-func getIgnoredFiles(filePath string) (files map[string]bool, err error) {
-	files = make(map[string]bool)
-
-	if !common.FileExists(filePath) {
-		return files, nil
-	}
-
-	// gosec flagged this:
-	// G304 (CWE-22): Potential file inclusion via variable
-	// Disabling as we trust the source of the filepath variable as it comes
-	// from the IgnoreFilePath constant.
-	/* #nosec */
-	file, err := os.Open(filePath)
-	if err != nil {
-		return files, err
-	}
-	defer func() {
-		cerr := file.Close()
-		if err == nil {
-			err = cerr
-		}
-	}()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		glob := strings.TrimSpace(scanner.Text())
-		globFiles, err := filepath.Glob(glob)
-		if err != nil {
-			return files, fmt.Errorf("parsing glob %s: %w", glob, err)
-		}
-		for _, f := range globFiles {
-			files[f] = true
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return files, fmt.Errorf("reading %s file: %w", filePath, err)
-	}
-
-	return files, nil
-}
-
-// getNonIgnoredFiles walks a filepath and returns all files don't exist in the
-// provided ignore files map.
-func getNonIgnoredFiles(base string, ignoredFiles map[string]bool) ([]string, error) {
+// getNonIgnoredFiles walks a filepath and returns all files which aren't
+// ignored, without descending into ignored directories.
+func getNonIgnoredFiles(base string, ignore ignoreList) ([]string, error) {
 	var files []string
 	err := filepath.Walk(base, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
+		if path != "." && ignore.ignored(path, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
 			return nil
 		}
-		if ignoredFiles[path] {
+		if info.IsDir() {
 			return nil
 		}
 		files = append(files, path)
@@ -331,3 +315,22 @@ func getNonIgnoredFiles(base string, ignoredFiles map[string]bool) ([]string, er
 
 	return files, err
 }
+
+// listPackageFiles writes the files of a package archive, as they're named in
+// the archive, and their sizes.
+func listPackageFiles(out io.Writer, root string, files []string) error {
+	tw := text.NewTable(out)
+	tw.AddHeader("FILE", "SIZE")
+	var total int64
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return fmt.Errorf("error reading file: %w", err)
+		}
+		tw.AddLine(path.Join(root, filepath.ToSlash(f)), info.Size())
+		total += info.Size()
+	}
+	tw.Print()
+	fmt.Fprintf(out, "%d file(s), %d bytes in total\n", len(files), total)
+	return nil
+}
@@ -817,6 +817,33 @@ func TestDeploy(t *testing.T) {
 				"Deployed package (service 123, version 2)",
 			},
 		},
+		{
+			name: "success with comment",
+			args: []string{"compute", "deploy", "-t", "123", "-p", "pkg/package.tar.gz", "-s", "123", "--version", "2", "--comment", "Fix caching"},
+			api: mock.API{
+				GetPackageFn:      getPackageOk,
+				UpdatePackageFn:   updatePackageOk,
+				UpdateVersionFn:   updateVersionOk,
+				ActivateVersionFn: activateVersionOk,
+				ListDomainsFn:     listDomainsOk,
+			},
+			wantOutput: []string{
+				"Uploading package...",
+				"Setting version comment...",
+				"Activating version...",
+				"Deployed package (service 123, version 2)",
+			},
+		},
+		{
+			name: "comment error",
+			args: []string{"compute", "deploy", "-t", "123", "-p", "pkg/package.tar.gz", "-s", "123", "--version", "2", "--comment", "Fix caching"},
+			api: mock.API{
+				GetPackageFn:    getPackageOk,
+				UpdatePackageFn: updatePackageOk,
+				UpdateVersionFn: updateVersionError,
+			},
+			wantError: "error setting version comment: fixture error",
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			// We're going to chdir to a deploy environment,
@@ -1304,6 +1331,14 @@ func activateVersionError(i *fastly.ActivateVersionInput) (*fastly.Version, erro
 	return nil, errTest
 }
 
+func updateVersionOk(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+	return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion, Comment: *i.Comment}, nil
+}
+
+func updateVersionError(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+	return nil, errTest
+}
+
 func listDomainsOk(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
 	return []*fastly.Domain{
 		{Name: "https://directly-careful-coyote.edgecompute.app"},
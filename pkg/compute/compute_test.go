@@ -1,6 +1,7 @@
 package compute
 
 import (
+	"bytes"
 	"crypto/rand"
 	"errors"
 	"fmt"
@@ -114,77 +115,86 @@ func TestFileNameWithoutExtension(t *testing.T) {
 	}
 }
 
-func TestGetIgnoredFiles(t *testing.T) {
+func TestIgnoreList(t *testing.T) {
 	for _, testcase := range []struct {
-		name         string
-		fastlyignore string
-		wantfiles    map[string]bool
+		name    string
+		rules   string
+		path    string
+		isDir   bool
+		wantErr string
+		want    bool
 	}{
-		{
-			name:         "ignore src",
-			fastlyignore: "src/*",
-			wantfiles: map[string]bool{
-				filepath.Join("src/main.rs"): true,
-			},
-		},
-		{
-			name:         "ignore cargo files",
-			fastlyignore: "Cargo.*",
-			wantfiles: map[string]bool{
-				"Cargo.lock": true,
-				"Cargo.toml": true,
-			},
-		},
-		{
-			name:         "ignore all",
-			fastlyignore: "*",
-			wantfiles: map[string]bool{
-				".fastlyignore": true,
-				"Cargo.lock":    true,
-				"Cargo.toml":    true,
-				"src":           true,
-			},
-		},
+		{name: "no rules", rules: "", path: "src/main.rs", want: false},
+		{name: "glob in directory", rules: "src/*", path: "src/main.rs", want: true},
+		{name: "glob in other directory", rules: "src/*", path: "bin/src/main.rs", want: false},
+		{name: "name at any depth", rules: "Cargo.*", path: "vendor/Cargo.toml", want: true},
+		{name: "anchored name", rules: "/Cargo.*", path: "vendor/Cargo.toml", want: false},
+		{name: "wildcard doesn't match separator", rules: "src*rs", path: "src/main.rs", want: false},
+		{name: "single character", rules: "main.r?", path: "src/main.rs", want: true},
+		{name: "character class", rules: "*.[ch]", path: "lib/wasm.h", want: true},
+		{name: "negated character class", rules: "*.[!ch]", path: "lib/wasm.h", want: false},
+		{name: "leading double star", rules: "**/*.map", path: "bin/a/b.map", want: true},
+		{name: "inner double star", rules: "src/**/test.rs", path: "src/a/b/test.rs", want: true},
+		{name: "inner double star matches no directories", rules: "src/**/test.rs", path: "src/test.rs", want: true},
+		{name: "trailing double star", rules: "src/**", path: "src/a/b.rs", want: true},
+		{name: "trailing double star doesn't match directory", rules: "src/**", path: "src", isDir: true, want: false},
+		{name: "directory only matches directory", rules: "target/", path: "a/target", isDir: true, want: true},
+		{name: "directory only doesn't match file", rules: "target/", path: "a/target", want: false},
+		{name: "comment", rules: "# src/main.rs", path: "# src/main.rs", want: false},
+		{name: "escaped hash", rules: "\\#notes", path: "#notes", want: true},
+		{name: "negation", rules: "*.rs\n!main.rs", path: "src/main.rs", want: false},
+		{name: "negation of other path", rules: "*.rs\n!main.rs", path: "src/lib.rs", want: true},
+		{name: "last rule wins", rules: "!main.rs\n*.rs", path: "src/main.rs", want: true},
+		{name: "escaped exclamation mark", rules: "\\!important", path: "!important", want: true},
+		{name: "trailing spaces", rules: "main.rs  ", path: "src/main.rs", want: true},
+		{name: "escaped trailing space", rules: "main.rs\\ ", path: "src/main.rs ", want: true},
+		{name: "carriage return", rules: "main.rs\r\nlib.rs\r\n", path: "src/lib.rs", want: true},
+		{name: "invalid range", rules: "*.rs\n[z-a].rs", wantErr: `line 2: invalid pattern "[z-a].rs"`},
+		{name: "empty character class", rules: "[]", wantErr: `line 1: invalid pattern "[]"`},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
-			// we're going to chdir to a build environment,
-			// so save the pwd to return to, afterwards.
-			pwd, err := os.Getwd()
-			if err != nil {
-				t.Fatal(err)
-			}
+			path := testutil.MakeTempFile(t, testcase.rules)
+			defer os.RemoveAll(path)
 
-			// create our build environment in a temp dir.
-			// defer a call to clean it up.
-			rootdir := makeBuildEnvironment(t, testcase.fastlyignore)
-			defer os.RemoveAll(rootdir)
-
-			// before running the test, chdir into the build environment.
-			// when we're done, chdir back to our original location.
-			// this is so we can reliably copy the testdata/ fixtures.
-			if err := os.Chdir(rootdir); err != nil {
-				t.Fatal(err)
+			ignore, err := getIgnoreList(path)
+			testutil.AssertErrorContains(t, err, testcase.wantErr)
+			if testcase.wantErr != "" {
+				return
 			}
-			defer os.Chdir(pwd)
-
-			output, err := getIgnoredFiles(IgnoreFilePath)
-			testutil.AssertNoError(t, err)
-			testutil.AssertEqual(t, testcase.wantfiles, output)
+			testutil.AssertBool(t, testcase.want, ignore.ignored(testcase.path, testcase.isDir))
 		})
 	}
 }
 
+func TestGetIgnoreList(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fastly-ignore")
+	testutil.AssertNoError(t, err)
+	defer os.RemoveAll(dir)
+	gitignore := filepath.Join(dir, GitIgnoreFilePath)
+	fastlyignore := filepath.Join(dir, IgnoreFilePath)
+	testutil.AssertNoError(t, ioutil.WriteFile(gitignore, []byte("*.map\ndebug.log\n"), 0644))
+	testutil.AssertNoError(t, ioutil.WriteFile(fastlyignore, []byte("!bin/main.wasm.map\n"), 0644))
+
+	// Rules of the .fastlyignore file take priority over those of .gitignore,
+	// and ignore files which don't exist have no rules.
+	ignore, err := getIgnoreList(gitignore, fastlyignore, filepath.Join(dir, "missing"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertBool(t, true, ignore.ignored("debug.log", false))
+	testutil.AssertBool(t, true, ignore.ignored("bin/other.map", false))
+	testutil.AssertBool(t, false, ignore.ignored("bin/main.wasm.map", false))
+}
+
 func TestGetNonIgnoredFiles(t *testing.T) {
 	for _, testcase := range []struct {
 		name         string
 		path         string
-		ignoredFiles map[string]bool
+		fastlyignore string
 		wantFiles    []string
 	}{
 		{
 			name:         "no ignored files",
 			path:         ".",
-			ignoredFiles: map[string]bool{},
+			fastlyignore: "",
 			wantFiles: []string{
 				"Cargo.lock",
 				"Cargo.toml",
@@ -192,27 +202,55 @@ func TestGetNonIgnoredFiles(t *testing.T) {
 			},
 		},
 		{
-			name: "one ignored file",
-			path: ".",
-			ignoredFiles: map[string]bool{
-				filepath.Join("src/main.rs"): true,
+			name:         "one ignored file",
+			path:         ".",
+			fastlyignore: "src/main.rs",
+			wantFiles: []string{
+				".fastlyignore",
+				"Cargo.lock",
+				"Cargo.toml",
+			},
+		},
+		{
+			name:         "multiple ignored files",
+			path:         ".",
+			fastlyignore: "Cargo.*\n.fastlyignore",
+			wantFiles: []string{
+				filepath.Join("src/main.rs"),
 			},
+		},
+		{
+			name:         "ignored directory",
+			path:         ".",
+			fastlyignore: "src/\n.*",
 			wantFiles: []string{
 				"Cargo.lock",
 				"Cargo.toml",
 			},
 		},
 		{
-			name: "multiple ignored files",
-			path: ".",
-			ignoredFiles: map[string]bool{
-				"Cargo.toml": true,
-				"Cargo.lock": true,
+			name:         "files of an ignored directory can't be re-included",
+			path:         ".",
+			fastlyignore: "src\n!src/main.rs\n.*",
+			wantFiles: []string{
+				"Cargo.lock",
+				"Cargo.toml",
 			},
+		},
+		{
+			name:         "include list",
+			path:         ".",
+			fastlyignore: "/*\n!/src/",
 			wantFiles: []string{
 				filepath.Join("src/main.rs"),
 			},
 		},
+		{
+			name:         "ignore all",
+			path:         "src",
+			fastlyignore: "*",
+			wantFiles:    nil,
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
 			// We're going to chdir to a build environment,
@@ -224,7 +262,7 @@ func TestGetNonIgnoredFiles(t *testing.T) {
 
 			// Create our build environment in a temp dir.
 			// Defer a call to clean it up.
-			rootdir := makeBuildEnvironment(t, "")
+			rootdir := makeBuildEnvironment(t, testcase.fastlyignore)
 			defer os.RemoveAll(rootdir)
 
 			// Before running the test, chdir into the build environment.
@@ -235,13 +273,44 @@ func TestGetNonIgnoredFiles(t *testing.T) {
 			}
 			defer os.Chdir(pwd)
 
-			output, err := getNonIgnoredFiles(testcase.path, testcase.ignoredFiles)
+			ignore, err := getIgnoreList(IgnoreFilePath)
+			testutil.AssertNoError(t, err)
+			output, err := getNonIgnoredFiles(testcase.path, ignore)
 			testutil.AssertNoError(t, err)
 			testutil.AssertEqual(t, testcase.wantFiles, output)
 		})
 	}
 }
 
+func TestListPackageFiles(t *testing.T) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootdir := makeBuildEnvironment(t, "")
+	defer os.RemoveAll(rootdir)
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	var files []string
+	var total int64
+	for _, f := range []string{"Cargo.toml", filepath.Join("src", "main.rs")} {
+		info, err := os.Stat(f)
+		testutil.AssertNoError(t, err)
+		files = append(files, f)
+		total += info.Size()
+	}
+
+	var buf bytes.Buffer
+	testutil.AssertNoError(t, listPackageFiles(&buf, "test", files))
+	output := buf.String()
+	testutil.AssertStringContains(t, output, "test/Cargo.toml")
+	testutil.AssertStringContains(t, output, "test/src/main.rs")
+	testutil.AssertStringContains(t, output, fmt.Sprintf("2 file(s), %d bytes in total", total))
+}
+
 func TestGetIdealPackage(t *testing.T) {
 	for _, testcase := range []struct {
 		name          string
@@ -23,6 +23,7 @@ type DeployCommand struct {
 	manifest manifest.Data
 	path     string
 	version  common.OptionalInt
+	comment  common.OptionalString
 }
 
 // NewDeployCommand returns a usable command registered under the parent.
@@ -34,6 +35,7 @@ func NewDeployCommand(parent common.Registerer, client api.HTTPClient, globals *
 	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
 	c.CmdClause.Flag("version", "Number of version to activate").Action(c.version.Set).IntVar(&c.version.Value)
 	c.CmdClause.Flag("path", "Path to package").Short('p').StringVar(&c.path)
+	c.CmdClause.Flag("comment", "Human-readable comment for the deployed version").Action(c.comment.Set).StringVar(&c.comment.Value)
 	return &c
 }
 
@@ -140,6 +142,18 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return fmt.Errorf("error uploading package: %w", err)
 	}
 
+	if c.comment.WasSet {
+		progress.Step("Setting version comment...")
+		_, err = c.Globals.Client.UpdateVersion(&fastly.UpdateVersionInput{
+			ServiceID:      serviceID,
+			ServiceVersion: version.Number,
+			Comment:        fastly.String(c.comment.Value),
+		})
+		if err != nil {
+			return fmt.Errorf("error setting version comment: %w", err)
+		}
+	}
+
 	progress.Step("Activating version...")
 
 	_, err = c.Globals.Client.ActivateVersion(&fastly.ActivateVersionInput{
@@ -0,0 +1,190 @@
+package compute
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fastly/cli/pkg/common"
+)
+
+// GitIgnoreFilePath is the filepath name of the Git ignore file, which is
+// honoured alongside the Fastly ignore file by compute build --gitignore.
+const GitIgnoreFilePath = ".gitignore"
+
+// ignoreRule is a single pattern of an ignore file.
+type ignoreRule struct {
+	re      *regexp.Regexp // matches slash separated paths
+	negate  bool           // the pattern started with !, so re-includes paths
+	dirOnly bool           // the pattern ended with /, so only matches directories
+}
+
+// ignoreList is the rules of one or more ignore files, which follow the syntax
+// and semantics of .gitignore files, in order of increasing priority.
+type ignoreList []ignoreRule
+
+// ignored reports whether a path, relative to the project root, is ignored.
+// The last matching rule decides. As with Git, a path can't be re-included if
+// its parent directory is ignored, which getNonIgnoredFiles achieves by never
+// descending into ignored directories.
+func (l ignoreList) ignored(path string, isDir bool) bool {
+	path = filepath.ToSlash(path)
+	for i := len(l) - 1; i >= 0; i-- {
+		r := l[i]
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.re.MatchString(path) {
+			return !r.negate
+		}
+	}
+	return false
+}
+
+// getIgnoreList reads the rules of the ignore files at the paths, any of
+// which may not exist. Rules of later files take priority.
+func getIgnoreList(paths ...string) (ignoreList, error) {
+	var l ignoreList
+	for _, path := range paths {
+		rules, err := readIgnoreFile(path)
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, rules...)
+	}
+	return l, nil
+}
+
+// readIgnoreFile reads the rules of an ignore file, or none if it doesn't
+// exist.
+func readIgnoreFile(path string) (rules ignoreList, err error) {
+	if !common.FileExists(path) {
+		return nil, nil
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we trust the source of the filepath variable as it comes
+	// from the IgnoreFilePath and GitIgnoreFilePath constants.
+	/* #nosec */
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		cerr := file.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		rule, ok, err := parseIgnoreRule(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s line %d: %w", path, n, err)
+		}
+		if ok {
+			rules = append(rules, rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s file: %w", path, err)
+	}
+	return rules, nil
+}
+
+// parseIgnoreRule parses a line of an ignore file, which is a rule unless it's
+// blank or a comment.
+func parseIgnoreRule(line string) (rule ignoreRule, ok bool, err error) {
+	line = strings.TrimSuffix(line, "\r")
+
+	// Trailing spaces are ignored, unless escaped with a backslash.
+	trimmed := strings.TrimRight(line, " ")
+	if strings.HasSuffix(trimmed, `\`) && len(trimmed) < len(line) {
+		trimmed += " "
+	}
+	line = trimmed
+
+	switch {
+	case line == "", strings.HasPrefix(line, "#"):
+		return rule, false, nil
+	case strings.HasPrefix(line, "!"):
+		rule.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\#`), strings.HasPrefix(line, `\!`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	if line == "" {
+		return rule, false, nil
+	}
+
+	// Patterns with a separator at the start or in the middle are relative
+	// to the project root, while others match at any depth.
+	prefix := "(?:.*/)?"
+	if strings.Contains(line, "/") {
+		prefix = ""
+		line = strings.TrimPrefix(line, "/")
+	}
+
+	re, err := regexp.Compile("^" + prefix + globToRegexp(line) + "$")
+	if err != nil {
+		return rule, false, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+	rule.re = re
+	return rule, true, nil
+}
+
+// globToRegexp translates a gitignore glob, without leading or trailing
+// separators, into a regular expression. Wildcards don't match separators,
+// except for the ** of a leading **/, a trailing /** or an inner /**/, which
+// match any number of directories.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/") && i == 0:
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**/"):
+			b.WriteString("/(?:.*/)?")
+			i += 3
+		case glob[i:] == "/**":
+			b.WriteString("/.*")
+			i += 2
+		case c == '*':
+			for i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+			}
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.Index(glob[i+1:], "]")
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
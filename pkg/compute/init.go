@@ -100,6 +100,9 @@ func NewInitCommand(parent common.Registerer, client api.HTTPClient, globals *co
 // Exec implements the command interface.
 func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	// Exit early if no token configured.
+	if err := c.Globals.ResolveToken(); err != nil {
+		return err
+	}
 	_, s := c.Globals.Token()
 	if s == config.SourceUndefined {
 		return errors.ErrNoToken
@@ -29,6 +29,10 @@ const (
 	// SourceDefault indicates the parameter came from a program default.
 	SourceDefault
 
+	// SourceProjectFile indicates the parameter came from a project config
+	// file.
+	SourceProjectFile
+
 	// DirectoryPermissions is the default directory permissions for the config file directory.
 	DirectoryPermissions = 0700
 
@@ -36,29 +40,71 @@ const (
 	FilePermissions = 0600
 )
 
+// String returns a human readable description of the source.
+func (s Source) String() string {
+	switch s {
+	case SourceFile:
+		return "user config file"
+	case SourceEnvironment:
+		return "environment"
+	case SourceFlag:
+		return "flag"
+	case SourceDefault:
+		return "default"
+	case SourceProjectFile:
+		return "project config file"
+	}
+	return "undefined"
+}
+
 // Data holds global-ish configuration data from all sources: environment
 // variables, config files, and flags. It has methods to give each parameter to
 // the components that need it, including the place the parameter came from,
 // which is a requirement.
 //
 // If the same parameter is defined in multiple places, it is resolved according
-// to the following priority order: the config file (lowest priority), env vars,
-// and then explicit flags (highest priority).
+// to the following priority order: the config file (lowest priority), the
+// project config file, env vars, and then explicit flags (highest priority).
 //
 // This package and its types are only meant for parameters that are applicable
 // to most/all subcommands (e.g. API token) and are consistent for a given user
 // (e.g. an email address). Otherwise, parameters should be defined in specific
 // command structs, and parsed as flags.
 type Data struct {
-	File File
-	Env  Environment
-	Flag Flag
+	File    File
+	Project ProjectFile
+	Env     Environment
+	Flag    Flag
+
+	// StoredToken is the API token resolved from the encrypted_token or
+	// token_command settings in the config file, if either is used, by
+	// ResolveToken.
+	StoredToken string
+
+	// TokenResolver sets the StoredToken. It may prompt for a passphrase or
+	// run a command, so it's only called via ResolveToken, once a command
+	// needs the token.
+	TokenResolver func(*Data) error
+	tokenResolved bool
+	tokenErr      error
 
 	Client    api.Interface
 	RTSClient api.RealtimeStatsInterface
 }
 
-// Token yields the Fastly API token.
+// ResolveToken resolves a token stored in the config file via the
+// TokenResolver, the first time it's called. Commands which use the token
+// directly, rather than via the API client, must call it before Token.
+func (d *Data) ResolveToken() error {
+	if !d.tokenResolved && d.TokenResolver != nil {
+		d.tokenResolved = true
+		d.tokenErr = d.TokenResolver(d)
+	}
+	return d.tokenErr
+}
+
+// Token yields the Fastly API token. A token stored via the encrypted_token or
+// token_command settings is only yielded once ResolveToken has been called.
 func (d *Data) Token() (string, Source) {
 	if d.Flag.Token != "" {
 		return d.Flag.Token, SourceFlag
@@ -68,6 +114,14 @@ func (d *Data) Token() (string, Source) {
 		return d.Env.Token, SourceEnvironment
 	}
 
+	if p, ok := d.ActiveProfile(); ok && p.Token != "" {
+		return p.Token, SourceFile
+	}
+
+	if d.StoredToken != "" {
+		return d.StoredToken, SourceFile
+	}
+
 	if d.File.Token != "" {
 		return d.File.Token, SourceFile
 	}
@@ -80,6 +134,76 @@ func (d *Data) Verbose() bool {
 	return d.Flag.Verbose
 }
 
+// AutoYes yields the auto-yes flag, which can only be set via flags. When set,
+// commands should skip confirmation prompts.
+func (d *Data) AutoYes() bool {
+	return d.Flag.AutoYes
+}
+
+// Email yields the email address of the user, from the selected profile or the
+// config file.
+func (d *Data) Email() (string, Source) {
+	if p, ok := d.ActiveProfile(); ok && p.Email != "" {
+		return p.Email, SourceFile
+	}
+
+	if d.File.Email != "" {
+		return d.File.Email, SourceFile
+	}
+
+	return "", SourceUndefined
+}
+
+// Format yields the output format, either FormatText or FormatJSON.
+func (d *Data) Format() (string, Source) {
+	if d.Flag.Format != "" {
+		return d.Flag.Format, SourceFlag
+	}
+
+	if d.Project.Format != "" {
+		return d.Project.Format, SourceProjectFile
+	}
+
+	return FormatText, SourceDefault
+}
+
+// CommandFormat yields the output format of a command with its own --format
+// flag, which supports JSON along with any other formats. That's the value of
+// the flag, if set, or else FormatJSON if it's the global format, or else an
+// empty string for the command's default.
+func (d *Data) CommandFormat(flag string) string {
+	if flag != "" {
+		return flag
+	}
+
+	if format, _ := d.Format(); format == FormatJSON {
+		return format
+	}
+
+	return ""
+}
+
+// Profile yields the name of the selected profile, which can only be set in a
+// project config file.
+func (d *Data) Profile() (string, Source) {
+	if d.Project.Profile != "" {
+		return d.Project.Profile, SourceProjectFile
+	}
+
+	return "", SourceUndefined
+}
+
+// ActiveProfile yields the settings of the selected profile, if any, and
+// whether it's defined in the config file.
+func (d *Data) ActiveProfile() (Profile, bool) {
+	name, source := d.Profile()
+	if source == SourceUndefined {
+		return Profile{}, false
+	}
+	p, ok := d.File.Profiles[name]
+	return p, ok
+}
+
 // Endpoint yields the API endpoint.
 func (d *Data) Endpoint() (string, Source) {
 	if d.Flag.Endpoint != "" {
@@ -90,6 +214,10 @@ func (d *Data) Endpoint() (string, Source) {
 		return d.Env.Endpoint, SourceEnvironment
 	}
 
+	if p, ok := d.ActiveProfile(); ok && p.Endpoint != "" {
+		return p.Endpoint, SourceFile
+	}
+
 	if d.File.Endpoint != DefaultEndpoint && d.File.Endpoint != "" {
 		return d.File.Endpoint, SourceFile
 	}
@@ -108,16 +236,39 @@ var FilePath = func() string {
 	panic("unable to deduce user config dir or user home dir")
 }()
 
+// Output formats selectable via the global --format flag.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
 // DefaultEndpoint is the default Fastly API endpoint.
 const DefaultEndpoint = "https://api.fastly.com"
 
 // File represents all of the configuration parameters that can end up in the
-// config file. At some point, it may expand to include e.g. user profiles.
+// config file.
 type File struct {
 	Token            string `toml:"token"`
 	Email            string `toml:"email"`
 	Endpoint         string `toml:"endpoint"`
 	LastVersionCheck string `toml:"last_version_check"`
+	UpdateChannel    string `toml:"update_channel,omitempty"`
+	UpdateBaseURL    string `toml:"update_base_url,omitempty"`
+	EncryptedToken   string `toml:"encrypted_token,omitempty"`
+	TokenCommand     string `toml:"token_command,omitempty"`
+
+	// Profiles are named sets of credentials, which take precedence over the
+	// settings above when selected by a project config file.
+	Profiles map[string]Profile `toml:"profile,omitempty"`
+}
+
+// Profile represents the parameters of a named profile in the config file,
+// defined in a [profile.<name>] table. Unset parameters fall back to the top
+// level settings of the config file.
+type Profile struct {
+	Token    string `toml:"token,omitempty"`
+	Email    string `toml:"email,omitempty"`
+	Endpoint string `toml:"endpoint,omitempty"`
 }
 
 // Read the File and populate its fields from the filename on disk.
@@ -146,6 +297,22 @@ func (f *File) Write(filename string) error {
 type Environment struct {
 	Token    string
 	Endpoint string
+
+	// CredentialsPassphrase decrypts the encrypted_token in the config file
+	// without prompting.
+	CredentialsPassphrase string
+
+	// CredentialsKey encrypts and decrypts the encrypted_token in the config
+	// file in place of a passphrase, e.g. in CI.
+	CredentialsKey string
+
+	// Record and Replay are the paths of fixture files to record HTTP
+	// interactions with the API to, or replay them from.
+	Record string
+	Replay string
+
+	// Path is the executable search path, used to discover plugins.
+	Path string
 }
 
 const (
@@ -158,12 +325,38 @@ const (
 
 	// EnvVarEndpoint is the env var we look in for the API endpoint.
 	EnvVarEndpoint = "FASTLY_API_ENDPOINT"
+
+	// EnvVarCredentialsPassphrase is the env var we look in for the passphrase
+	// of the encrypted API token.
+	/* #nosec */
+	EnvVarCredentialsPassphrase = "FASTLY_CREDENTIALS_PASSPHRASE"
+
+	// EnvVarCredentialsKey is the env var we look in for the key of the
+	// encrypted API token, which is used in place of a passphrase.
+	/* #nosec */
+	EnvVarCredentialsKey = "FASTLY_CREDENTIALS_KEY"
+
+	// EnvVarRecord is the env var we look in for the path of a fixture file to
+	// record HTTP interactions with the API to.
+	EnvVarRecord = "FASTLY_RECORD"
+
+	// EnvVarReplay is the env var we look in for the path of a fixture file to
+	// replay HTTP interactions with the API from.
+	EnvVarReplay = "FASTLY_REPLAY"
+
+	// EnvVarPath is the env var we look in for the executable search path.
+	EnvVarPath = "PATH"
 )
 
 // Read populates the fields from the provided environment.
 func (e *Environment) Read(env map[string]string) {
 	e.Token = env[EnvVarToken]
 	e.Endpoint = env[EnvVarEndpoint]
+	e.CredentialsPassphrase = env[EnvVarCredentialsPassphrase]
+	e.CredentialsKey = env[EnvVarCredentialsKey]
+	e.Record = env[EnvVarRecord]
+	e.Replay = env[EnvVarReplay]
+	e.Path = env[EnvVarPath]
 }
 
 // Flag represents all of the configuration parameters that can be set with
@@ -172,5 +365,7 @@ func (e *Environment) Read(env map[string]string) {
 type Flag struct {
 	Token    string
 	Verbose  bool
+	AutoYes  bool
 	Endpoint string
+	Format   string
 }
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ProjectFilePath is the location of a project config file, relative to the
+// project root directory.
+var ProjectFilePath = filepath.Join(".fastly", "config.toml")
+
+// ProjectFile represents all of the configuration parameters that can end up in
+// a project config file. These provide defaults for all commands run from
+// within the project directory or any of its subdirectories.
+type ProjectFile struct {
+	ServiceID string `toml:"service_id"`
+	Profile   string `toml:"profile"`
+	Format    string `toml:"format"`
+
+	path string
+}
+
+// Read the ProjectFile and populate its fields from the filename on disk.
+func (f *ProjectFile) Read(filename string) error {
+	if _, err := toml.DecodeFile(filename, f); err != nil {
+		return err
+	}
+	f.path = filename
+	return nil
+}
+
+// Path yields the location the ProjectFile was read from, or an empty string
+// if it wasn't read.
+func (f *ProjectFile) Path() string {
+	return f.path
+}
+
+// FindProjectFile walks up the directory tree from dir, and returns the path of
+// the first project config file found, or an empty string if there is none.
+// The user config file is never considered a project config file, even if it
+// happens to live at the same relative path, e.g. in the home directory.
+func FindProjectFile(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFilePath)
+		if fi, err := os.Stat(path); err == nil && fi.Mode().IsRegular() && path != FilePath {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
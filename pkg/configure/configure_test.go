@@ -1,20 +1,17 @@
 package configure_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/credential"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
@@ -198,20 +195,17 @@ func TestConfigure(t *testing.T) {
 			configFilePath := testutil.MakeTempFile(t, testcase.configFileData)
 			defer os.RemoveAll(configFilePath)
 
-			var (
-				args                           = testcase.args
-				env                            = testcase.env
-				file                           = testcase.file
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = strings.NewReader(testcase.stdin)
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFilePath, clientFactory, httpClient, versioner, in, &out)
+			out, err := golden.Exec(golden.Scenario{
+				Args:       testcase.args,
+				API:        testcase.api,
+				Env:        testcase.env,
+				File:       testcase.file,
+				Stdin:      testcase.stdin,
+				ConfigFile: configFilePath,
+			})
 			testutil.AssertErrorContains(t, err, testcase.wantError)
 			for _, s := range testcase.wantOutput {
-				testutil.AssertStringContains(t, out.String(), s)
+				testutil.AssertStringContains(t, out, s)
 			}
 			if testcase.wantError == "" {
 				p, err := ioutil.ReadFile(configFilePath)
@@ -221,3 +215,108 @@ func TestConfigure(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigureStoredToken(t *testing.T) {
+	api := mock.API{
+		GetTokenSelfFn: func() (*fastly.Token, error) { return &fastly.Token{}, nil },
+		GetUserFn: func(*fastly.GetUserInput) (*fastly.User, error) {
+			return &fastly.User{Login: "test@example.com"}, nil
+		},
+	}
+
+	for _, testcase := range []struct {
+		name               string
+		args               []string
+		env                config.Environment
+		file               config.File
+		stdin              string
+		wantOutput         []string
+		wantError          string
+		wantToken          string
+		wantEncryptedToken string // the token after decrypting
+		wantTokenCommand   string
+	}{
+		{
+			name:               "encrypt with passphrase from environment",
+			args:               []string{"configure", "--token=abcdef", "--encrypt"},
+			env:                config.Environment{CredentialsPassphrase: "hunter2"},
+			wantEncryptedToken: "abcdef",
+		},
+		{
+			name:               "encrypt with interactive passphrase",
+			args:               []string{"configure", "--token=abcdef", "--encrypt"},
+			stdin:              "hunter2\nhunter2\n",
+			wantOutput:         []string{"Passphrase: ", "Confirm passphrase: "},
+			wantEncryptedToken: "abcdef",
+		},
+		{
+			name:      "encrypt with mismatched passphrase",
+			args:      []string{"configure", "--token=abcdef", "--encrypt"},
+			stdin:     "hunter2\nhunter3\n",
+			wantError: "passphrases don't match",
+		},
+		{
+			name:               "migrate plaintext token accepted",
+			args:               []string{"configure"},
+			file:               config.File{Token: "old_token"},
+			stdin:              "new_token\ny\nhunter2\nhunter2\n",
+			wantOutput:         []string{"Encrypt the API token in the config file with a passphrase? [y/N]"},
+			wantEncryptedToken: "new_token",
+		},
+		{
+			name:       "migrate plaintext token declined",
+			args:       []string{"configure"},
+			file:       config.File{Token: "old_token"},
+			stdin:      "new_token\nn\n",
+			wantOutput: []string{"Encrypt the API token in the config file with a passphrase? [y/N]"},
+			wantToken:  "new_token",
+		},
+		{
+			name:               "already encrypted stays encrypted",
+			args:               []string{"configure", "--token=abcdef"},
+			env:                config.Environment{CredentialsPassphrase: "hunter2"},
+			file:               config.File{EncryptedToken: "stale"},
+			wantEncryptedToken: "abcdef",
+		},
+		{
+			name:             "token from token_command isn't stored",
+			args:             []string{"configure"},
+			file:             config.File{Token: "old_token", TokenCommand: "echo abcdef"},
+			wantOutput:       []string{"Fastly API token provided via token_command"},
+			wantTokenCommand: "echo abcdef",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			configFilePath := testutil.MakeTempFile(t, "")
+			defer os.RemoveAll(configFilePath)
+
+			out, err := golden.Exec(golden.Scenario{
+				Args:       testcase.args,
+				API:        api,
+				Env:        testcase.env,
+				File:       testcase.file,
+				Stdin:      testcase.stdin,
+				ConfigFile: configFilePath,
+			})
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, out, s)
+			}
+			if testcase.wantError != "" {
+				return
+			}
+
+			var written config.File
+			testutil.AssertNoError(t, written.Read(configFilePath))
+			testutil.AssertString(t, testcase.wantToken, written.Token)
+			testutil.AssertString(t, testcase.wantTokenCommand, written.TokenCommand)
+			if testcase.wantEncryptedToken == "" {
+				testutil.AssertString(t, "", written.EncryptedToken)
+				return
+			}
+			token, err := credential.Decrypt(written.EncryptedToken, "hunter2")
+			testutil.AssertNoError(t, err)
+			testutil.AssertString(t, testcase.wantEncryptedToken, token)
+		})
+	}
+}
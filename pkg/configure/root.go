@@ -11,6 +11,7 @@ import (
 	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/common"
 	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/credential"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
@@ -26,6 +27,7 @@ type RootCommand struct {
 	common.Base
 	configFilePath string
 	clientFactory  APIClientFactory
+	encrypt        bool
 }
 
 // NewRootCommand returns a new command registered in the parent.
@@ -35,6 +37,7 @@ func NewRootCommand(parent common.Registerer, configFilePath string, cf APIClien
 	c.CmdClause = parent.Command("configure", "Configure the Fastly CLI")
 	c.configFilePath = configFilePath
 	c.clientFactory = cf
+	c.CmdClause.Flag("encrypt", fmt.Sprintf("Encrypt the API token in the config file with a passphrase, or the key in %s", config.EnvVarCredentialsKey)).BoolVar(&c.encrypt)
 	return &c
 }
 
@@ -50,15 +53,20 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		text.Output(out, "Fastly API endpoint (via %s): %s", config.EnvVarEndpoint, endpoint)
 	}
 
-	// Get the token provided by the user, if it was explicitly provided. If it
-	// wasn't provided, or if it only exists in the config file, take it
-	// interactively.
+	// Get the token provided by the user, if it was explicitly provided, or via
+	// the token_command. If it wasn't provided, or if it only exists in the
+	// config file, take it interactively.
 	token, source := c.Globals.Token()
-	switch source { // TODO(pb): this can be duplicate output if --verbose is passed
-	case config.SourceFlag:
+	switch { // TODO(pb): this can be duplicate output if --verbose is passed
+	case source == config.SourceFlag:
 		text.Output(out, "Fastly API token provided via --token")
-	case config.SourceEnvironment:
+	case source == config.SourceEnvironment:
 		text.Output(out, "Fastly API token provided via %s", config.EnvVarToken)
+	case c.Globals.File.TokenCommand != "":
+		text.Output(out, "Fastly API token provided via token_command")
+		if token, err = credential.Command(c.Globals.File.TokenCommand); err != nil {
+			return err
+		}
 	default:
 		text.Output(out, `
 			An API token is used to authenticate requests to the Fastly API.
@@ -72,6 +80,24 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		text.Break(out)
 	}
 
+	// The token is encrypted if asked to, if it already was, or if the user
+	// accepts the offer to migrate their existing plaintext token. A key
+	// provided via the environment is used in place of a passphrase.
+	var (
+		passphrase string
+		key        []byte
+	)
+	if c.shouldEncrypt(in, out) {
+		if c.Globals.Env.CredentialsKey != "" {
+			key, err = credential.ParseKey(c.Globals.Env.CredentialsKey)
+		} else {
+			passphrase, err = credential.Passphrase(c.Globals.Env.CredentialsPassphrase, in, out, true)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	text.Break(out)
 
 	progress := text.NewQuietProgress(out)
@@ -101,7 +127,25 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	progress.Step("Persisting configuration...")
 
 	// Set everything in the File struct based on provided user input.
-	c.Globals.File.Token = token
+	switch {
+	case c.Globals.File.TokenCommand != "":
+		// The token_command supplies the token, so there's nothing to store.
+		c.Globals.File.Token, c.Globals.File.EncryptedToken = "", ""
+	case key != nil:
+		encrypted, err := credential.EncryptWithKey(token, key)
+		if err != nil {
+			return err
+		}
+		c.Globals.File.Token, c.Globals.File.EncryptedToken = "", encrypted
+	case passphrase != "":
+		encrypted, err := credential.Encrypt(token, passphrase)
+		if err != nil {
+			return err
+		}
+		c.Globals.File.Token, c.Globals.File.EncryptedToken = "", encrypted
+	default:
+		c.Globals.File.Token, c.Globals.File.EncryptedToken = token, ""
+	}
 	c.Globals.File.Email = user.Login
 	c.Globals.File.Endpoint = endpoint
 
@@ -136,6 +180,22 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	return nil
 }
 
+// shouldEncrypt reports whether the token should be stored encrypted. Users
+// with a plaintext token in the config file are offered to encrypt it.
+func (c *RootCommand) shouldEncrypt(in io.Reader, out io.Writer) bool {
+	switch {
+	case c.Globals.File.TokenCommand != "":
+		return false
+	case c.encrypt, c.Globals.File.EncryptedToken != "":
+		return true
+	case c.Globals.File.Token != "":
+		text.Break(out)
+		err := text.Confirm(out, in, false, "Encrypt the API token in the config file with a passphrase?", "")
+		return err == nil
+	}
+	return false
+}
+
 func validateTokenNotEmpty(s string) error {
 	if s == "" {
 		return ErrEmptyToken
@@ -0,0 +1,314 @@
+package credential
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Versions identify the encryption scheme, so it can be changed in future
+// without breaking existing config files.
+const (
+	// passphraseVersion tokens are encrypted with a key derived from a
+	// passphrase, and prefixed with the salt of the derivation.
+	passphraseVersion byte = 1
+
+	// keyVersion tokens are encrypted with a key provided as is.
+	keyVersion byte = 2
+)
+
+// scrypt parameters, as recommended for interactive logins in 2017.
+const (
+	saltSize = 16
+	scryptN  = 1 << 15
+	scryptR  = 8
+	scryptP  = 1
+	keySize  = 32 // AES-256
+)
+
+// ErrDecrypt is returned when an encrypted token can't be decrypted, most
+// likely due to an incorrect passphrase or key.
+var ErrDecrypt = errors.New("error decrypting token: incorrect passphrase, incorrect key or corrupt data")
+
+// ErrInvalidKey is returned when a key isn't 32 bytes, base64 encoded.
+var ErrInvalidKey = fmt.Errorf("invalid %s: want %d random bytes, base64 encoded, e.g. the output of `openssl rand -base64 %d`", config.EnvVarCredentialsKey, keySize, keySize)
+
+// Encrypt the token with a key derived from the passphrase. The result is
+// base64 encoded, and safe to store in the config file.
+func Encrypt(token, passphrase string) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", fmt.Errorf("error generating salt: %w", err)
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	return seal(token, key, append([]byte{passphraseVersion}, salt...))
+}
+
+// EncryptWithKey encrypts the token with a key, e.g. one provided to CI, which
+// is parsed by ParseKey. The result is base64 encoded, and safe to store in the
+// config file.
+func EncryptWithKey(token string, key []byte) (string, error) {
+	return seal(token, key, []byte{keyVersion})
+}
+
+// Decrypt a token previously encrypted by Encrypt with the same passphrase.
+func Decrypt(encrypted, passphrase string) (string, error) {
+	data, err := decode(encrypted, passphraseVersion)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < 1+saltSize {
+		return "", ErrDecrypt
+	}
+
+	header := data[:1+saltSize]
+	key, err := deriveKey(passphrase, header[1:])
+	if err != nil {
+		return "", err
+	}
+	return open(data, len(header), key)
+}
+
+// DecryptWithKey decrypts a token previously encrypted by EncryptWithKey with
+// the same key.
+func DecryptWithKey(encrypted string, key []byte) (string, error) {
+	data, err := decode(encrypted, keyVersion)
+	if err != nil {
+		return "", err
+	}
+	return open(data, 1, key)
+}
+
+// UsesKey reports whether the token was encrypted by EncryptWithKey, rather
+// than with a passphrase.
+func UsesKey(encrypted string) bool {
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	return err == nil && len(data) > 0 && data[0] == keyVersion
+}
+
+// ParseKey decodes a key provided via the environment.
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != keySize {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving key: %w", err)
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the token, and returns the header, nonce and sealed token,
+// base64 encoded. The header, i.e. the version and any salt, is authenticated
+// as additional data, so it can't be tampered with.
+func seal(token string, key, header []byte) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+	sealed := aead.Seal(nil, nonce, []byte(token), header)
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.Write(nonce)
+	buf.Write(sealed)
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decode returns the bytes of an encrypted token, which must be of the version.
+func decode(encrypted string, want byte) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(data) < 1 {
+		return nil, ErrDecrypt
+	}
+	switch {
+	case data[0] == want:
+		return data, nil
+	case data[0] == passphraseVersion:
+		return nil, fmt.Errorf("error decrypting token: it was encrypted with a passphrase, not a key")
+	case data[0] == keyVersion:
+		return nil, fmt.Errorf("error decrypting token: it was encrypted with a key, not a passphrase")
+	}
+	return nil, fmt.Errorf("error decrypting token: unsupported version %d", data[0])
+}
+
+// open decrypts the token of data, whose header is of length n.
+func open(data []byte, n int, key []byte) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	header, rest := data[:n], data[n:]
+	if len(rest) < aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	token, err := aead.Open(nil, nonce, sealed, header)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(token), nil
+}
+
+// Command runs the command line with the system shell, and returns its
+// standard output, with surrounding whitespace trimmed, as the token.
+func Command(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("error running token_command: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("error running token_command: %w", err)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("error running token_command: no token written to stdout")
+	}
+	return token, nil
+}
+
+// Resolve sets the stored token of globals from the config file's token_command
+// or encrypted_token settings, in that order of precedence, unless a token was
+// already provided via flag, environment or the selected profile. The
+// passphrase of an encrypted token is prompted for if in is interactive;
+// callers which must never prompt should pass a nil reader.
+func Resolve(globals *config.Data, in io.Reader, out io.Writer) error {
+	if globals.Flag.Token != "" || globals.Env.Token != "" {
+		return nil
+	}
+	if p, ok := globals.ActiveProfile(); ok && p.Token != "" {
+		return nil
+	}
+
+	switch {
+	case globals.File.TokenCommand != "":
+		token, err := Command(globals.File.TokenCommand)
+		if err != nil {
+			return err
+		}
+		globals.StoredToken = token
+	case globals.File.EncryptedToken != "" && UsesKey(globals.File.EncryptedToken):
+		if globals.Env.CredentialsKey == "" {
+			return ErrNoKey
+		}
+		key, err := ParseKey(globals.Env.CredentialsKey)
+		if err != nil {
+			return err
+		}
+		token, err := DecryptWithKey(globals.File.EncryptedToken, key)
+		if err != nil {
+			return err
+		}
+		globals.StoredToken = token
+	case globals.File.EncryptedToken != "":
+		passphrase, err := Passphrase(globals.Env.CredentialsPassphrase, in, out, false)
+		if err != nil {
+			return err
+		}
+		token, err := Decrypt(globals.File.EncryptedToken, passphrase)
+		if err != nil {
+			return err
+		}
+		globals.StoredToken = token
+	}
+
+	return nil
+}
+
+// ErrNoPassphrase is returned by Passphrase when the passphrase isn't set in
+// the environment and can't be prompted for.
+var ErrNoPassphrase = fmt.Errorf("no passphrase for the encrypted token: set %s, or run interactively", config.EnvVarCredentialsPassphrase)
+
+// ErrNoKey is returned by Resolve when the token was encrypted with a key, which
+// isn't set in the environment.
+var ErrNoKey = fmt.Errorf("no key for the encrypted token: set %s", config.EnvVarCredentialsKey)
+
+// ErrPassphraseMismatch is returned by Passphrase when the confirmation of a
+// new passphrase doesn't match.
+var ErrPassphraseMismatch = errors.New("passphrases don't match")
+
+// Passphrase yields the passphrase for the encrypted token, preferring the
+// environment, and otherwise prompting the user for it. When choosing a new
+// passphrase, confirm should be true to have the user enter it twice.
+func Passphrase(env string, in io.Reader, out io.Writer, confirm bool) (string, error) {
+	if env != "" {
+		return env, nil
+	}
+	if !text.IsInteractive(in) {
+		return "", ErrNoPassphrase
+	}
+
+	passphrase, err := text.InputSecure(out, "Passphrase: ", in, validatePassphraseNotEmpty)
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" { // input ended before a passphrase was entered
+		return "", ErrNoPassphrase
+	}
+	text.Break(out)
+
+	if confirm {
+		again, err := text.InputSecure(out, "Confirm passphrase: ", in)
+		if err != nil {
+			return "", err
+		}
+		text.Break(out)
+		if again != passphrase {
+			return "", ErrPassphraseMismatch
+		}
+	}
+
+	return passphrase, nil
+}
+
+func validatePassphraseNotEmpty(s string) error {
+	if s == "" {
+		return errors.New("passphrase cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package credential_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/credential"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypted, err := credential.Encrypt("secret-token", "hunter2")
+	testutil.AssertNoError(t, err)
+	if strings.Contains(encrypted, "secret-token") {
+		t.Fatalf("encrypted token contains plaintext: %s", encrypted)
+	}
+
+	again, err := credential.Encrypt("secret-token", "hunter2")
+	testutil.AssertNoError(t, err)
+	if again == encrypted {
+		t.Fatal("encrypting twice produced the same result, want random salt and nonce")
+	}
+
+	token, err := credential.Decrypt(encrypted, "hunter2")
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "secret-token", token)
+
+	for _, testcase := range []struct {
+		name       string
+		encrypted  string
+		passphrase string
+		wantError  string
+	}{
+		{
+			name:       "wrong passphrase",
+			encrypted:  encrypted,
+			passphrase: "hunter3",
+			wantError:  credential.ErrDecrypt.Error(),
+		},
+		{
+			name:       "not base64",
+			encrypted:  "!!!",
+			passphrase: "hunter2",
+			wantError:  credential.ErrDecrypt.Error(),
+		},
+		{
+			name:       "truncated",
+			encrypted:  encrypted[:24],
+			passphrase: "hunter2",
+			wantError:  credential.ErrDecrypt.Error(),
+		},
+		{
+			name:       "unsupported version",
+			encrypted:  "AwAAAAAAAAAAAAAAAAAAAAA=",
+			passphrase: "hunter2",
+			wantError:  "unsupported version 3",
+		},
+		{
+			name:       "encrypted with key",
+			encrypted:  "AgAAAAAAAAAAAAAAAAAAAAA=",
+			passphrase: "hunter2",
+			wantError:  "encrypted with a key, not a passphrase",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, err := credential.Decrypt(testcase.encrypted, testcase.passphrase)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+		})
+	}
+}
+
+func TestEncryptDecryptWithKey(t *testing.T) {
+	key, err := credential.ParseKey(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)))
+	testutil.AssertNoError(t, err)
+
+	encrypted, err := credential.EncryptWithKey("secret-token", key)
+	testutil.AssertNoError(t, err)
+	if strings.Contains(encrypted, "secret-token") {
+		t.Fatalf("encrypted token contains plaintext: %s", encrypted)
+	}
+	testutil.AssertBool(t, true, credential.UsesKey(encrypted))
+
+	token, err := credential.DecryptWithKey(encrypted, key)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "secret-token", token)
+
+	_, err = credential.DecryptWithKey(encrypted, bytes.Repeat([]byte{2}, 32))
+	testutil.AssertErrorContains(t, err, credential.ErrDecrypt.Error())
+
+	withPassphrase, err := credential.Encrypt("secret-token", "hunter2")
+	testutil.AssertNoError(t, err)
+	testutil.AssertBool(t, false, credential.UsesKey(withPassphrase))
+	_, err = credential.DecryptWithKey(withPassphrase, key)
+	testutil.AssertErrorContains(t, err, "encrypted with a passphrase, not a key")
+
+	for _, invalid := range []string{"!!!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		_, err := credential.ParseKey(invalid)
+		testutil.AssertErrorContains(t, err, credential.ErrInvalidKey.Error())
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test commands assume a POSIX shell")
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		command   string
+		wantToken string
+		wantError string
+	}{
+		{
+			name:      "success",
+			command:   "echo '  abc123  '",
+			wantToken: "abc123",
+		},
+		{
+			name:      "failure",
+			command:   "echo 'vault is locked' >&2; exit 1",
+			wantError: "error running token_command: exit status 1: vault is locked",
+		},
+		{
+			name:      "no output",
+			command:   "true",
+			wantError: "no token written to stdout",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			token, err := credential.Command(testcase.command)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantToken, token)
+		})
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	for _, testcase := range []struct {
+		name           string
+		env            string
+		stdin          string
+		confirm        bool
+		wantPassphrase string
+		wantError      string
+	}{
+		{
+			name:           "from environment",
+			env:            "hunter2",
+			wantPassphrase: "hunter2",
+		},
+		{
+			name:           "interactive",
+			stdin:          "hunter2\n",
+			wantPassphrase: "hunter2",
+		},
+		{
+			name:           "interactive confirmed",
+			stdin:          "hunter2\nhunter2\n",
+			confirm:        true,
+			wantPassphrase: "hunter2",
+		},
+		{
+			name:      "interactive mismatch",
+			stdin:     "hunter2\nhunter3\n",
+			confirm:   true,
+			wantError: credential.ErrPassphraseMismatch.Error(),
+		},
+		{
+			name:      "no input",
+			wantError: credential.ErrNoPassphrase.Error(),
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var out bytes.Buffer
+			passphrase, err := credential.Passphrase(testcase.env, strings.NewReader(testcase.stdin), &out, testcase.confirm)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantPassphrase, passphrase)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package credential provides ways to store and retrieve the Fastly API token
+// other than as plaintext in the config file: encrypted with a passphrase, or
+// supplied by an external command such as a password manager.
+package credential
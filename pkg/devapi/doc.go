@@ -0,0 +1,3 @@
+// Package devapi contains the `dev-api` command, which serves an in-memory
+// fake of the Fastly API for offline development and demos.
+package devapi
@@ -0,0 +1,52 @@
+package devapi
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/fakeapi"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	common.Base
+	addr string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent common.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("dev-api", "Serve an in-memory fake of the Fastly API for offline use")
+	c.CmdClause.Flag("addr", "Address to listen on").Default("127.0.0.1:8080").StringVar(&c.addr)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	l, err := net.Listen("tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", c.addr, err)
+	}
+
+	server := fakeapi.NewWithListener(l)
+	defer server.Close()
+
+	text.Success(out, "Fake Fastly API listening on %s", server.URL)
+	text.Description(out, "To use it from another shell, run", fmt.Sprintf("export %s=%s %s=fake", config.EnvVarEndpoint, server.URL, config.EnvVarToken))
+	text.Info(out, "All state is held in memory, and lost when the server stops. Press Ctrl-C to stop.")
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	<-stop
+
+	text.Break(out)
+	return nil
+}
@@ -1,261 +1,145 @@
 package domain_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestDomainCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"domain", "create", "--version", "1", "--service-id", "123"},
-			api:       mock.API{CreateDomainFn: createDomainOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"domain", "create", "--version", "1", "--service-id", "123"},
+			API:  mock.API{CreateDomainFn: createDomainOK},
 		},
 		{
-			args:       []string{"domain", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{CreateDomainFn: createDomainOK},
-			wantOutput: "Created domain www.test.com (service 123 version 1)",
+			Args: []string{"domain", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{CreateDomainFn: createDomainOK},
 		},
 		{
-			args:      []string{"domain", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{CreateDomainFn: createDomainError},
-			wantError: errTest.Error(),
+			Args: []string{"domain", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{CreateDomainFn: createDomainError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDomainList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"domain", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListDomainsFn: listDomainsOK},
-			wantOutput: listDomainsShortOutput,
+			Args: []string{"domain", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDomainsFn: listDomainsOK},
 		},
 		{
-			args:       []string{"domain", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListDomainsFn: listDomainsOK},
-			wantOutput: listDomainsVerboseOutput,
+			Args: []string{"domain", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListDomainsFn: listDomainsOK},
 		},
 		{
-			args:       []string{"domain", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListDomainsFn: listDomainsOK},
-			wantOutput: listDomainsVerboseOutput,
+			Args: []string{"domain", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListDomainsFn: listDomainsOK},
 		},
 		{
-			args:       []string{"domain", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListDomainsFn: listDomainsOK},
-			wantOutput: listDomainsVerboseOutput,
+			Args: []string{"domain", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDomainsFn: listDomainsOK},
 		},
 		{
-			args:       []string{"-v", "domain", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListDomainsFn: listDomainsOK},
-			wantOutput: listDomainsVerboseOutput,
+			Args: []string{"-v", "domain", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDomainsFn: listDomainsOK},
 		},
 		{
-			args:      []string{"domain", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListDomainsFn: listDomainsError},
-			wantError: errTest.Error(),
+			Args: []string{"domain", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDomainsFn: listDomainsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDomainDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"domain", "describe", "--service-id", "123", "--version", "1"},
-			api:       mock.API{GetDomainFn: getDomainOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"domain", "describe", "--service-id", "123", "--version", "1"},
+			API:  mock.API{GetDomainFn: getDomainOK},
 		},
 		{
-			args:      []string{"domain", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{GetDomainFn: getDomainError},
-			wantError: errTest.Error(),
+			Args: []string{"domain", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{GetDomainFn: getDomainError},
 		},
 		{
-			args:       []string{"domain", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{GetDomainFn: getDomainOK},
-			wantOutput: describeDomainOutput,
+			Args: []string{"domain", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{GetDomainFn: getDomainOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDomainUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"domain", "update", "--service-id", "123", "--version", "2", "--new-name", "www.test.com", "--comment", ""},
-			api:       mock.API{UpdateDomainFn: updateDomainOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"domain", "update", "--service-id", "123", "--version", "2", "--new-name", "www.test.com", "--comment", ""},
+			API:  mock.API{UpdateDomainFn: updateDomainOK},
 		},
 		{
-			args:      []string{"domain", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com"},
-			api:       mock.API{UpdateDomainFn: updateDomainOK},
-			wantError: "error parsing arguments: must provide either --new-name or --comment to update domain",
+			Args: []string{"domain", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com"},
+			API:  mock.API{UpdateDomainFn: updateDomainOK},
 		},
 		{
-			args: []string{"domain", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"domain", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetDomainFn:    getDomainError,
 				UpdateDomainFn: updateDomainOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"domain", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"domain", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetDomainFn:    getDomainError,
 				UpdateDomainFn: updateDomainError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"domain", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"domain", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetDomainFn:    getDomainOK,
 				UpdateDomainFn: updateDomainOK,
 			},
-			wantOutput: "Updated domain www.example.com (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDomainDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"domain", "delete", "--service-id", "123", "--version", "1"},
-			api:       mock.API{DeleteDomainFn: deleteDomainOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"domain", "delete", "--service-id", "123", "--version", "1"},
+			API:  mock.API{DeleteDomainFn: deleteDomainOK},
 		},
 		{
-			args:      []string{"domain", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{DeleteDomainFn: deleteDomainError},
-			wantError: errTest.Error(),
+			Args: []string{"domain", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{DeleteDomainFn: deleteDomainError},
 		},
 		{
-			args:       []string{"domain", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{DeleteDomainFn: deleteDomainOK},
-			wantOutput: "Deleted domain www.test.com (service 123 version 1)",
+			Args: []string{"domain", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{DeleteDomainFn: deleteDomainOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -296,25 +180,6 @@ func listDomainsError(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
 	return nil, errTest
 }
 
-var listDomainsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME             COMMENT
-123      1        www.test.com     test
-123      1        www.example.com  example
-`) + "\n"
-
-var listDomainsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Domain 1/2
-		Name: www.test.com
-		Comment: test
-	Domain 2/2
-		Name: www.example.com
-		Comment: example
-`) + "\n\n"
-
 func getDomainOK(i *fastly.GetDomainInput) (*fastly.Domain, error) {
 	return &fastly.Domain{
 		ServiceID:      i.ServiceID,
@@ -328,13 +193,6 @@ func getDomainError(i *fastly.GetDomainInput) (*fastly.Domain, error) {
 	return nil, errTest
 }
 
-var describeDomainOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: www.test.com
-Comment: test
-`) + "\n"
-
 func updateDomainOK(i *fastly.UpdateDomainInput) (*fastly.Domain, error) {
 	return &fastly.Domain{
 		ServiceID:      i.ServiceID,
@@ -1,270 +1,151 @@
 package edgedictionary_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestDictionaryDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionary", "describe", "--version", "1", "--service-id", "123"},
-			api:       mock.API{GetDictionaryFn: describeDictionaryOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"dictionary", "describe", "--version", "1", "--service-id", "123"},
+			API:  mock.API{GetDictionaryFn: describeDictionaryOK},
 		},
 		{
-			args:       []string{"dictionary", "describe", "--version", "1", "--service-id", "123", "--name", "dict-1"},
-			api:        mock.API{GetDictionaryFn: describeDictionaryOK},
-			wantOutput: describeDictionaryOutput,
+			Args: []string{"dictionary", "describe", "--version", "1", "--service-id", "123", "--name", "dict-1"},
+			API:  mock.API{GetDictionaryFn: describeDictionaryOK},
 		},
 		{
-			args:       []string{"dictionary", "describe", "--version", "1", "--service-id", "123", "--name", "dict-1"},
-			api:        mock.API{GetDictionaryFn: describeDictionaryOKDeleted},
-			wantOutput: describeDictionaryOutputDeleted,
+			Args: []string{"dictionary", "describe", "--version", "1", "--service-id", "123", "--name", "dict-1"},
+			API:  mock.API{GetDictionaryFn: describeDictionaryOKDeleted},
 		},
 		{
-			args: []string{"dictionary", "describe", "--version", "1", "--service-id", "123", "--name", "dict-1", "--verbose"},
-			api: mock.API{
+			Args: []string{"dictionary", "describe", "--version", "1", "--service-id", "123", "--name", "dict-1", "--verbose"},
+			API: mock.API{
 				GetDictionaryFn:       describeDictionaryOK,
 				GetDictionaryInfoFn:   getDictionaryInfoOK,
 				ListDictionaryItemsFn: listDictionaryItemsOK,
 			},
-			wantOutput: describeDictionaryOutputVerbose,
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDictionaryCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionary", "create", "--version", "1", "--service-id", "123"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"dictionary", "create", "--version", "1", "--service-id", "123"},
 		},
 		{
-			args:       []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist"},
-			api:        mock.API{CreateDictionaryFn: createDictionaryOK},
-			wantOutput: createDictionaryOutput,
+			Args: []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist"},
+			API:  mock.API{CreateDictionaryFn: createDictionaryOK},
 		},
 		{
-			args:       []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist", "--write-only", "true"},
-			api:        mock.API{CreateDictionaryFn: createDictionaryOK},
-			wantOutput: createDictionaryOutputWriteOnly,
+			Args: []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist", "--write-only", "true"},
+			API:  mock.API{CreateDictionaryFn: createDictionaryOK},
 		},
 		{
-			args:      []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist", "--write-only", "fish"},
-			wantError: "strconv.ParseBool: parsing \"fish\": invalid syntax",
+			Args: []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist", "--write-only", "fish"},
 		},
 		{
-			args:      []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist"},
-			api:       mock.API{CreateDictionaryFn: createDictionaryDuplicate},
-			wantError: "Duplicate record",
+			Args: []string{"dictionary", "create", "--version", "1", "--service-id", "123", "--name", "denylist"},
+			API:  mock.API{CreateDictionaryFn: createDictionaryDuplicate},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDeleteDictionary(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionary", "delete", "--service-id", "123", "--version", "1"},
-			api:       mock.API{DeleteDictionaryFn: deleteDictionaryOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"dictionary", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
+			API:  mock.API{DeleteDictionaryFn: deleteDictionaryOK},
 		},
 		{
-			args:       []string{"dictionary", "delete", "--service-id", "123", "--version", "1", "--name", "allowlist"},
-			api:        mock.API{DeleteDictionaryFn: deleteDictionaryOK},
-			wantOutput: deleteDictionaryOutput,
+			Args: []string{"dictionary", "delete", "--service-id", "123", "--version", "1", "--name", "allowlist", "--auto-yes"},
+			API:  mock.API{DeleteDictionaryFn: deleteDictionaryOK},
 		},
 		{
-			args:      []string{"dictionary", "delete", "--service-id", "123", "--version", "1", "--name", "allowlist"},
-			api:       mock.API{DeleteDictionaryFn: deleteDictionaryError},
-			wantError: errTest.Error(),
+			Args: []string{"dictionary", "delete", "--service-id", "123", "--version", "1", "--name", "allowlist", "--auto-yes"},
+			API:  mock.API{DeleteDictionaryFn: deleteDictionaryError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestListDictionary(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionary", "list", "--version", "1"},
-			api:       mock.API{ListDictionariesFn: listDictionariesOk},
-			wantError: "error reading service: no service ID found",
+			Args: []string{"dictionary", "list", "--version", "1"},
+			API:  mock.API{ListDictionariesFn: listDictionariesOk},
 		},
 		{
-			args:      []string{"dictionary", "list", "--service-id", "123"},
-			api:       mock.API{DeleteDictionaryFn: deleteDictionaryOK},
-			wantError: "error parsing arguments: required flag --version not provided",
+			Args: []string{"dictionary", "list", "--service-id", "123"},
+			API:  mock.API{DeleteDictionaryFn: deleteDictionaryOK},
 		},
 		{
-			args:       []string{"dictionary", "list", "--version", "1", "--service-id", "123"},
-			api:        mock.API{ListDictionariesFn: listDictionariesOk},
-			wantOutput: listDictionariesOutput,
+			Args: []string{"dictionary", "list", "--version", "1", "--service-id", "123"},
+			API:  mock.API{ListDictionariesFn: listDictionariesOk},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestUpdateDictionary(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionary", "update", "--version", "1", "--name", "oldname", "--new-name", "newname"},
-			wantError: "error reading service: no service ID found",
+			Args: []string{"dictionary", "update", "--version", "1", "--name", "oldname", "--new-name", "newname"},
 		},
 		{
-			args:      []string{"dictionary", "update", "--service-id", "123", "--name", "oldname", "--new-name", "newname"},
-			wantError: "error parsing arguments: required flag --version not provided",
+			Args: []string{"dictionary", "update", "--service-id", "123", "--name", "oldname", "--new-name", "newname"},
 		},
 		{
-			args:      []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--new-name", "newname"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--new-name", "newname"},
 		},
 		{
-			args:      []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname"},
-			wantError: "error parsing arguments: required flag --new-name or --write-only not provided",
+			Args: []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname"},
 		},
 		{
-			args:       []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1"},
-			api:        mock.API{UpdateDictionaryFn: updateDictionaryNameOK},
-			wantOutput: updateDictionaryNameOutput,
+			Args: []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1"},
+			API:  mock.API{UpdateDictionaryFn: updateDictionaryNameOK},
 		},
 		{
-			args:       []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1", "--write-only", "true"},
-			api:        mock.API{UpdateDictionaryFn: updateDictionaryNameOK},
-			wantOutput: updateDictionaryNameOutput,
+			Args: []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1", "--write-only", "true"},
+			API:  mock.API{UpdateDictionaryFn: updateDictionaryNameOK},
 		},
 		{
-			args:       []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--write-only", "true"},
-			api:        mock.API{UpdateDictionaryFn: updateDictionaryWriteOnlyOK},
-			wantOutput: updateDictionaryOutput,
+			Args: []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--write-only", "true"},
+			API:  mock.API{UpdateDictionaryFn: updateDictionaryWriteOnlyOK},
 		},
 		{
-			args:       []string{"dictionary", "update", "-v", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1"},
-			api:        mock.API{UpdateDictionaryFn: updateDictionaryNameOK},
-			wantOutput: updateDictionaryOutputVerbose,
+			Args: []string{"dictionary", "update", "-v", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1"},
+			API:  mock.API{UpdateDictionaryFn: updateDictionaryNameOK},
 		},
 		{
-			args:      []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1"},
-			api:       mock.API{UpdateDictionaryFn: updateDictionaryError},
-			wantError: errTest.Error(),
+			Args: []string{"dictionary", "update", "--service-id", "123", "--version", "1", "--name", "oldname", "--new-name", "dict-1"},
+			API:  mock.API{UpdateDictionaryFn: updateDictionaryError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -418,23 +299,8 @@ func updateDictionaryError(i *fastly.UpdateDictionaryInput) (*fastly.Dictionary,
 
 var errTest = errors.New("an expected error ocurred")
 var errFail = errors.New("this error should not be returned and indicates a failure in the code")
-
-var createDictionaryOutput = "\nSUCCESS: Created dictionary denylist (service 123 version 1)\n"
-var createDictionaryOutputWriteOnly = "\nSUCCESS: Created dictionary denylist as write-only (service 123 version 1)\n"
-var deleteDictionaryOutput = "\nSUCCESS: Deleted dictionary allowlist (service 123 version 1)\n"
-var updateDictionaryOutput = "\nSUCCESS: Updated dictionary oldname (service 123 version 1)\n"
 var updateDictionaryNameOutput = "\nSUCCESS: Updated dictionary dict-1 (service 123 version 1)\n"
 
-var updateDictionaryOutputVerbose = strings.Join(
-	[]string{
-		"Fastly API token not provided",
-		"Fastly API endpoint: https://api.fastly.com",
-		"",
-		strings.TrimSpace(updateDictionaryNameOutput),
-		describeDictionaryOutput,
-	},
-	"\n")
-
 var describeDictionaryOutput = strings.TrimSpace(`
 Service ID: 123
 Version: 1
@@ -444,49 +310,3 @@ Write Only: false
 Created (UTC): 2001-02-03 04:05
 Last edited (UTC): 2001-02-03 04:05
 `) + "\n"
-
-var describeDictionaryOutputDeleted = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-ID: 456
-Name: dict-1
-Write Only: false
-Created (UTC): 2001-02-03 04:05
-Last edited (UTC): 2001-02-03 04:05
-Deleted (UTC): 2001-02-03 04:05
-`) + "\n"
-
-var describeDictionaryOutputVerbose = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-ID: 456
-Name: dict-1
-Write Only: false
-Created (UTC): 2001-02-03 04:05
-Last edited (UTC): 2001-02-03 04:05
-Digest: digest_hash
-Item Count: 2
-Item 1/2:
-	Item Key: foo
-	Item Value: bar
-Item 2/2:
-	Item Key: baz
-	Item Value: bear
-`) + "\n"
-
-var listDictionariesOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-ID: 456
-Name: dict-1
-Write Only: false
-Created (UTC): 2001-02-03 04:05
-Last edited (UTC): 2001-02-03 04:05
-ID: 456
-Name: dict-2
-Write Only: false
-Created (UTC): 2001-02-03 04:05
-Last edited (UTC): 2001-02-03 04:05
-`) + "\n"
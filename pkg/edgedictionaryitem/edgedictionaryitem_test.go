@@ -13,216 +13,112 @@ import (
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/cli/pkg/update"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestDictionaryItemDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionaryitem", "describe", "--service-id", "123", "--key", "foo"},
-			api:       mock.API{GetDictionaryItemFn: describeDictionaryItemOK},
-			wantError: "error parsing arguments: required flag --dictionary-id not provided",
+			Args: []string{"dictionaryitem", "describe", "--service-id", "123", "--key", "foo"},
+			API:  mock.API{GetDictionaryItemFn: describeDictionaryItemOK},
 		},
 		{
-			args:      []string{"dictionaryitem", "describe", "--service-id", "123", "--dictionary-id", "456"},
-			api:       mock.API{GetDictionaryItemFn: describeDictionaryItemOK},
-			wantError: "error parsing arguments: required flag --key not provided",
+			Args: []string{"dictionaryitem", "describe", "--service-id", "123", "--dictionary-id", "456"},
+			API:  mock.API{GetDictionaryItemFn: describeDictionaryItemOK},
 		},
 		{
-			args:       []string{"dictionaryitem", "describe", "--service-id", "123", "--dictionary-id", "456", "--key", "foo"},
-			api:        mock.API{GetDictionaryItemFn: describeDictionaryItemOK},
-			wantOutput: describeDictionaryItemOutput,
+			Args: []string{"dictionaryitem", "describe", "--service-id", "123", "--dictionary-id", "456", "--key", "foo"},
+			API:  mock.API{GetDictionaryItemFn: describeDictionaryItemOK},
 		},
 		{
-			args:       []string{"dictionaryitem", "describe", "--service-id", "123", "--dictionary-id", "456", "--key", "foo-deleted"},
-			api:        mock.API{GetDictionaryItemFn: describeDictionaryItemOKDeleted},
-			wantOutput: describeDictionaryItemOutputDeleted,
+			Args: []string{"dictionaryitem", "describe", "--service-id", "123", "--dictionary-id", "456", "--key", "foo-deleted"},
+			API:  mock.API{GetDictionaryItemFn: describeDictionaryItemOKDeleted},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDictionaryItemsList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionaryitem", "list", "--service-id", "123"},
-			api:       mock.API{ListDictionaryItemsFn: listDictionaryItemsOK},
-			wantError: "error parsing arguments: required flag --dictionary-id not provided",
+			Args: []string{"dictionaryitem", "list", "--service-id", "123"},
+			API:  mock.API{ListDictionaryItemsFn: listDictionaryItemsOK},
 		},
 		{
-			args:       []string{"dictionaryitem", "list", "--service-id", "123", "--dictionary-id", "456"},
-			api:        mock.API{ListDictionaryItemsFn: listDictionaryItemsOK},
-			wantOutput: listDictionaryItemsOutput,
+			Args: []string{"dictionaryitem", "list", "--service-id", "123", "--dictionary-id", "456"},
+			API:  mock.API{ListDictionaryItemsFn: listDictionaryItemsOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDictionaryItemCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionaryitem", "create", "--service-id", "123"},
-			api:       mock.API{CreateDictionaryItemFn: createDictionaryItemOK},
-			wantError: "error parsing arguments: required flag ",
+			Args: []string{"dictionaryitem", "create", "--service-id", "123", "--key", "foo", "--value", "bar"},
+			API:  mock.API{CreateDictionaryItemFn: createDictionaryItemOK},
 		},
 		{
-			args:      []string{"dictionaryitem", "create", "--service-id", "123", "--dictionary-id", "456"},
-			api:       mock.API{CreateDictionaryItemFn: createDictionaryItemOK},
-			wantError: "error parsing arguments: required flag ",
+			Args: []string{"dictionaryitem", "create", "--service-id", "123", "--dictionary-id", "456", "--value", "bar"},
+			API:  mock.API{CreateDictionaryItemFn: createDictionaryItemOK},
 		},
 		{
-			args:       []string{"dictionaryitem", "create", "--service-id", "123", "--dictionary-id", "456", "--key", "foo", "--value", "bar"},
-			api:        mock.API{CreateDictionaryItemFn: createDictionaryItemOK},
-			wantOutput: "\nSUCCESS: Created dictionary item foo (service 123, dictionary 456)\n",
+			Args: []string{"dictionaryitem", "create", "--service-id", "123", "--dictionary-id", "456", "--key", "foo", "--value", "bar"},
+			API:  mock.API{CreateDictionaryItemFn: createDictionaryItemOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDictionaryItemUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionaryitem", "update", "--service-id", "123"},
-			api:       mock.API{UpdateDictionaryItemFn: updateDictionaryItemOK},
-			wantError: "error parsing arguments: required flag ",
+			Args: []string{"dictionaryitem", "update", "--service-id", "123", "--key", "foo", "--value", "bar"},
+			API:  mock.API{UpdateDictionaryItemFn: updateDictionaryItemOK},
 		},
 		{
-			args:      []string{"dictionaryitem", "update", "--service-id", "123", "--dictionary-id", "456"},
-			api:       mock.API{UpdateDictionaryItemFn: updateDictionaryItemOK},
-			wantError: "error parsing arguments: required flag ",
+			Args: []string{"dictionaryitem", "update", "--service-id", "123", "--dictionary-id", "456", "--value", "bar"},
+			API:  mock.API{UpdateDictionaryItemFn: updateDictionaryItemOK},
 		},
 		{
-			args:       []string{"dictionaryitem", "update", "--service-id", "123", "--dictionary-id", "456", "--key", "foo", "--value", "bar"},
-			api:        mock.API{UpdateDictionaryItemFn: updateDictionaryItemOK},
-			wantOutput: describeDictionaryItemOutput,
+			Args: []string{"dictionaryitem", "update", "--service-id", "123", "--dictionary-id", "456", "--key", "foo", "--value", "bar"},
+			API:  mock.API{UpdateDictionaryItemFn: updateDictionaryItemOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDictionaryItemDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"dictionaryitem", "delete", "--service-id", "123"},
-			api:       mock.API{DeleteDictionaryItemFn: deleteDictionaryItemOK},
-			wantError: "error parsing arguments: required flag ",
+			Args: []string{"dictionaryitem", "delete", "--service-id", "123", "--key", "foo"},
+			API:  mock.API{DeleteDictionaryItemFn: deleteDictionaryItemOK},
 		},
 		{
-			args:      []string{"dictionaryitem", "delete", "--service-id", "123", "--dictionary-id", "456"},
-			api:       mock.API{DeleteDictionaryItemFn: deleteDictionaryItemOK},
-			wantError: "error parsing arguments: required flag ",
+			Args: []string{"dictionaryitem", "delete", "--service-id", "123", "--dictionary-id", "456"},
+			API:  mock.API{DeleteDictionaryItemFn: deleteDictionaryItemOK},
 		},
 		{
-			args:       []string{"dictionaryitem", "delete", "--service-id", "123", "--dictionary-id", "456", "--key", "foo"},
-			api:        mock.API{DeleteDictionaryItemFn: deleteDictionaryItemOK},
-			wantOutput: "\nSUCCESS: Deleted dictionary item foo (service 123, dicitonary 456)\n",
+			Args: []string{"dictionaryitem", "delete", "--service-id", "123", "--dictionary-id", "456", "--key", "foo"},
+			API:  mock.API{DeleteDictionaryItemFn: deleteDictionaryItemOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -313,15 +209,6 @@ func describeDictionaryItemOK(i *fastly.GetDictionaryItemInput) (*fastly.Diction
 	}, nil
 }
 
-var describeDictionaryItemOutput = strings.TrimSpace(`
-Service ID: 123
-Dictionary ID: 456
-Item Key: foo
-Item Value: bar
-Created (UTC): 2001-02-03 04:05
-Last edited (UTC): 2001-02-03 04:05
-`) + "\n"
-
 func describeDictionaryItemOKDeleted(i *fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
 	return &fastly.DictionaryItem{
 		ServiceID:    i.ServiceID,
@@ -334,16 +221,6 @@ func describeDictionaryItemOKDeleted(i *fastly.GetDictionaryItemInput) (*fastly.
 	}, nil
 }
 
-var describeDictionaryItemOutputDeleted = strings.TrimSpace(`
-Service ID: 123
-Dictionary ID: 456
-Item Key: foo-deleted
-Item Value: bar
-Created (UTC): 2001-02-03 04:05
-Last edited (UTC): 2001-02-03 04:05
-Deleted (UTC): 2001-02-03 04:06
-`) + "\n"
-
 func listDictionaryItemsOK(i *fastly.ListDictionaryItemsInput) ([]*fastly.DictionaryItem, error) {
 	return []*fastly.DictionaryItem{
 		{
@@ -366,24 +243,6 @@ func listDictionaryItemsOK(i *fastly.ListDictionaryItemsInput) ([]*fastly.Dictio
 	}, nil
 }
 
-var listDictionaryItemsOutput = strings.TrimSpace(`
-Service ID: 123
-Item: 1/2
-	Dictionary ID: 456
-	Item Key: foo
-	Item Value: bar
-	Created (UTC): 2001-02-03 04:05
-	Last edited (UTC): 2001-02-03 04:05
-
-Item: 2/2
-	Dictionary ID: 456
-	Item Key: baz
-	Item Value: bear
-	Created (UTC): 2001-02-03 04:05
-	Last edited (UTC): 2001-02-03 04:05
-	Deleted (UTC): 2001-02-03 04:06
-`) + "\n\n"
-
 func createDictionaryItemOK(i *fastly.CreateDictionaryItemInput) (*fastly.DictionaryItem, error) {
 	return &fastly.DictionaryItem{
 		ServiceID:    i.ServiceID,
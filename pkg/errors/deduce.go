@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
@@ -27,10 +28,30 @@ func Deduce(err error) RemediationError {
 		switch httpError.StatusCode {
 		case http.StatusUnauthorized:
 			remediation = AuthRemediation
+		case http.StatusForbidden:
+			remediation = ForbiddenRemediation
+		case http.StatusNotFound:
+			remediation = NotFoundRemediation
+		case http.StatusConflict:
+			if isLockedOrActive(*httpError) {
+				remediation = CloneRemediation
+			}
+		case http.StatusTooManyRequests:
+			var rateLimitError RateLimitError
+			errors.As(err, &rateLimitError) // zero Reset if not found
+			remediation = RateLimitRemediation(rateLimitError.Reset)
 		}
 		return RemediationError{Inner: SimplifyFastlyError(*httpError), Remediation: remediation}
 	}
 
+	if errors.Is(err, text.ErrNonInteractive) {
+		return RemediationError{Inner: err, Remediation: AutoYesRemediation}
+	}
+
+	if errors.Is(err, text.ErrNotConfirmed) {
+		return RemediationError{Inner: err}
+	}
+
 	if errors.Is(err, os.ErrNotExist) {
 		return RemediationError{Inner: err, Remediation: HostRemediation}
 	}
@@ -42,6 +63,18 @@ func Deduce(err error) RemediationError {
 	return RemediationError{Inner: err, Remediation: BugRemediation}
 }
 
+// isLockedOrActive determines whether a conflict was caused by an attempt to
+// modify a locked or active service version, which can never succeed.
+func isLockedOrActive(httpError fastly.HTTPError) bool {
+	for _, e := range httpError.Errors {
+		s := strings.ToLower(e.Title + " " + e.Detail)
+		if strings.Contains(s, "locked") || strings.Contains(s, "active") {
+			return true
+		}
+	}
+	return false
+}
+
 // SimplifyFastlyError reduces the potentially complex and multi-line Error
 // rendering of a fastly.HTTPError to something more palatable for a CLI.
 func SimplifyFastlyError(httpError fastly.HTTPError) error {
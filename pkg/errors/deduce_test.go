@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/testutil"
@@ -17,6 +18,14 @@ func TestDeduce(t *testing.T) {
 		re2             = errors.RemediationError{Inner: fmt.Errorf("bar"), Remediation: "Reticulate your splines."}
 		http503         = &fastly.HTTPError{StatusCode: http.StatusInternalServerError}
 		http401         = &fastly.HTTPError{StatusCode: http.StatusUnauthorized}
+		http403         = &fastly.HTTPError{StatusCode: http.StatusForbidden}
+		http404         = &fastly.HTTPError{StatusCode: http.StatusNotFound}
+		http409         = &fastly.HTTPError{StatusCode: http.StatusConflict, Errors: []*fastly.ErrorObject{{Title: "Conflict"}}}
+		http409Locked   = &fastly.HTTPError{StatusCode: http.StatusConflict, Errors: []*fastly.ErrorObject{{Title: "Version locked", Detail: "Cannot modify a locked version"}}}
+		http409Active   = &fastly.HTTPError{StatusCode: http.StatusConflict, Errors: []*fastly.ErrorObject{{Title: "Conflict", Detail: "Version 3 is active"}}}
+		http429         = &fastly.HTTPError{StatusCode: http.StatusTooManyRequests}
+		reset           = time.Date(2020, time.June, 1, 12, 0, 0, 0, time.UTC)
+		rateLimited     = errors.RateLimitError{Reset: reset, HTTPError: http429}
 		wrappedNotExist = fmt.Errorf("couldn't do the thing: %w", os.ErrNotExist)
 	)
 
@@ -45,6 +54,41 @@ func TestDeduce(t *testing.T) {
 			input: http401,
 			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http401), Remediation: errors.AuthRemediation},
 		},
+		{
+			name:  "fastly.HTTPError 403",
+			input: http403,
+			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http403), Remediation: errors.ForbiddenRemediation},
+		},
+		{
+			name:  "wrapped fastly.HTTPError 404",
+			input: fmt.Errorf("error getting service: %w", http404),
+			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http404), Remediation: errors.NotFoundRemediation},
+		},
+		{
+			name:  "fastly.HTTPError 409",
+			input: http409,
+			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http409)},
+		},
+		{
+			name:  "fastly.HTTPError 409 locked version",
+			input: http409Locked,
+			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http409Locked), Remediation: errors.CloneRemediation},
+		},
+		{
+			name:  "fastly.HTTPError 409 active version",
+			input: http409Active,
+			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http409Active), Remediation: errors.CloneRemediation},
+		},
+		{
+			name:  "fastly.HTTPError 429 without reset",
+			input: http429,
+			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http429), Remediation: errors.RateLimitRemediation(time.Time{})},
+		},
+		{
+			name:  "wrapped RateLimitError",
+			input: fmt.Errorf("error listing services: %w", rateLimited),
+			want:  errors.RemediationError{Inner: errors.SimplifyFastlyError(*http429), Remediation: "You have exceeded the Fastly API rate limit, which resets at Mon, 01 Jun 2020 12:00:00 UTC. Please try again after that time."},
+		},
 		{
 			name:  "wrapped os.ErrNotExist",
 			input: wrappedNotExist,
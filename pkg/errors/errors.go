@@ -1,6 +1,11 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/fastly/go-fastly/v2/fastly"
+)
 
 // ErrNoToken means no --token has been provided.
 var ErrNoToken = RemediationError{Inner: fmt.Errorf("no token provided"), Remediation: AuthRemediation}
@@ -8,3 +13,38 @@ var ErrNoToken = RemediationError{Inner: fmt.Errorf("no token provided"), Remedi
 // ErrNoServiceID means no --service-id or service_id package manifest value has
 // been provided.
 var ErrNoServiceID = RemediationError{Inner: fmt.Errorf("error reading service: no service ID found"), Remediation: ServiceIDRemediation}
+
+// RateLimitError is a Fastly SDK HTTPError for a 429 Too Many Requests
+// response, along with the time at which the rate limit resets. The SDK drops
+// response headers, so the error is constructed by an http.RoundTripper.
+type RateLimitError struct {
+	Reset     time.Time
+	HTTPError *fastly.HTTPError
+}
+
+// Error returns the error string of the underlying HTTPError.
+func (e RateLimitError) Error() string {
+	return e.HTTPError.Error()
+}
+
+// Unwrap returns the underlying HTTPError.
+func (e RateLimitError) Unwrap() error {
+	return e.HTTPError
+}
+
+// ExitError is the error of an external program, such as a plugin, which has
+// already reported its own failure. The CLI exits with the program's code.
+type ExitError struct {
+	Code  int
+	Inner error
+}
+
+// Error returns the error string of the underlying error.
+func (e ExitError) Error() string {
+	return e.Inner.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e ExitError) Unwrap() error {
+	return e.Inner
+}
@@ -0,0 +1,167 @@
+package errors
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// Exit codes returned by the CLI. These values are part of the public
+// interface, for use by scripts, and must not be changed once released.
+const (
+	// ExitOK indicates success.
+	ExitOK = 0
+
+	// ExitInternal indicates an unexpected error, which may be a bug.
+	ExitInternal = 1
+
+	// ExitUsage indicates invalid arguments or missing required input.
+	ExitUsage = 2
+
+	// ExitAuth indicates a missing, invalid or insufficiently privileged token.
+	ExitAuth = 3
+
+	// ExitNotFound indicates the requested resource doesn't exist.
+	ExitNotFound = 4
+
+	// ExitConflict indicates the request conflicts with the current state of
+	// the resource, e.g. it already exists or the version is locked.
+	ExitConflict = 5
+
+	// ExitRateLimited indicates the Fastly API rate limit was exceeded.
+	ExitRateLimited = 6
+
+	// ExitNetwork indicates a failure to communicate with the Fastly API.
+	ExitNetwork = 7
+
+	// ExitValidation indicates the input was rejected as invalid.
+	ExitValidation = 8
+)
+
+// ExitCode deduces the process exit code for err. The code of an ExitError
+// takes precedence, followed by the status code of a Fastly SDK HTTPError;
+// otherwise the code is derived from the remediation that Deduce would suggest.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitError ExitError
+	if errors.As(err, &exitError) {
+		return exitError.Code
+	}
+
+	if status := HTTPStatus(err); status != 0 {
+		switch {
+		case status == http.StatusUnauthorized, status == http.StatusForbidden:
+			return ExitAuth
+		case status == http.StatusNotFound:
+			return ExitNotFound
+		case status == http.StatusConflict:
+			return ExitConflict
+		case status == http.StatusTooManyRequests:
+			return ExitRateLimited
+		case status == http.StatusBadRequest, status == http.StatusUnprocessableEntity:
+			return ExitValidation
+		case status >= 500:
+			return ExitInternal
+		}
+	}
+
+	// Declining a confirmation prompt is treated the same as not providing
+	// --auto-yes when running non-interactively.
+	if errors.Is(err, text.ErrNotConfirmed) {
+		return ExitUsage
+	}
+
+	var netError net.Error
+	if errors.As(err, &netError) {
+		return ExitNetwork
+	}
+
+	for _, validationError := range validationErrors {
+		if errors.Is(err, validationError) {
+			return ExitValidation
+		}
+	}
+
+	re := Deduce(err)
+	switch {
+	case re.Inner == nil:
+		return ExitOK // e.g. usage was explicitly requested via --help
+	case re.Prefix != "":
+		return ExitUsage // only usage text is rendered as a prefix
+	}
+
+	switch re.Remediation {
+	case AuthRemediation, CredentialRemediation:
+		return ExitAuth
+	case NetworkRemediation:
+		return ExitNetwork
+	case ServiceIDRemediation, AutoYesRemediation:
+		return ExitUsage
+	}
+
+	return ExitInternal
+}
+
+// HTTPStatus returns the status code of the first Fastly SDK HTTPError in
+// err's chain, or 0 if there isn't one.
+func HTTPStatus(err error) int {
+	var httpError *fastly.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.StatusCode
+	}
+	return 0
+}
+
+// validationErrors are the errors returned by the Fastly SDK when it rejects
+// an input struct, before making any request.
+var validationErrors = []error{
+	fastly.ErrMissingServiceID,
+	fastly.ErrMissingStatus,
+	fastly.ErrMissingTag,
+	fastly.ErrMissingServiceVersion,
+	fastly.ErrMissingContent,
+	fastly.ErrMissingLogin,
+	fastly.ErrMissingName,
+	fastly.ErrMissingKey,
+	fastly.ErrMissingURL,
+	fastly.ErrMissingID,
+	fastly.ErrMissingDictionary,
+	fastly.ErrMissingItemKey,
+	fastly.ErrMissingFrom,
+	fastly.ErrMissingTo,
+	fastly.ErrMissingDirector,
+	fastly.ErrMissingBackend,
+	fastly.ErrMissingYear,
+	fastly.ErrMissingMonth,
+	fastly.ErrMissingNewName,
+	fastly.ErrMissingACLID,
+	fastly.ErrMissingIP,
+	fastly.ErrMissingCustomerID,
+	fastly.ErrMissingEventID,
+	fastly.ErrMissingWAFID,
+	fastly.ErrMissingWAFVersionNumber,
+	fastly.ErrMissingWAFVersionID,
+	fastly.ErrMissingWAFActiveRuleList,
+	fastly.ErrMissingWAFRuleExclusionNumber,
+	fastly.ErrMissingWAFRuleExclusion,
+	fastly.ErrMissingOWASPID,
+	fastly.ErrMissingRuleID,
+	fastly.ErrMissingConfigSetID,
+	fastly.ErrMissingWAFList,
+	fastly.ErrMissingPool,
+	fastly.ErrMissingServer,
+	fastly.ErrMissingAddress,
+	fastly.ErrMissingKMSKeyID,
+	fastly.ErrMissingCertBlob,
+	fastly.ErrMissingIntermediatesBlob,
+	fastly.ErrMissingTLSCertificate,
+	fastly.ErrMissingTLSConfiguration,
+	fastly.ErrMissingTLSDomain,
+	fastly.ErrBatchUpdateMaximumOperationsExceeded,
+}
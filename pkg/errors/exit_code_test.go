@@ -0,0 +1,172 @@
+package errors_test
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestExitCode(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		input      error
+		wantCode   int
+		wantStatus int
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			wantCode: errors.ExitOK,
+		},
+		{
+			name:     "usage requested",
+			input:    errors.RemediationError{Prefix: "USAGE"},
+			wantCode: errors.ExitOK,
+		},
+		{
+			name:     "usage with error",
+			input:    errors.RemediationError{Prefix: "USAGE", Inner: fmt.Errorf("error parsing arguments")},
+			wantCode: errors.ExitUsage,
+		},
+		{
+			name:     "no token",
+			input:    errors.ErrNoToken,
+			wantCode: errors.ExitAuth,
+		},
+		{
+			name:     "no service ID",
+			input:    errors.ErrNoServiceID,
+			wantCode: errors.ExitUsage,
+		},
+		{
+			name:     "non-interactive",
+			input:    fmt.Errorf("wrapped: %w", text.ErrNonInteractive),
+			wantCode: errors.ExitUsage,
+		},
+		{
+			name:     "not confirmed",
+			input:    text.ErrNotConfirmed,
+			wantCode: errors.ExitUsage,
+		},
+		{
+			name:       "fastly.HTTPError 401",
+			input:      &fastly.HTTPError{StatusCode: http.StatusUnauthorized},
+			wantCode:   errors.ExitAuth,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "fastly.HTTPError 403",
+			input:      &fastly.HTTPError{StatusCode: http.StatusForbidden},
+			wantCode:   errors.ExitAuth,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "wrapped fastly.HTTPError 404",
+			input:      fmt.Errorf("error getting service: %w", &fastly.HTTPError{StatusCode: http.StatusNotFound}),
+			wantCode:   errors.ExitNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "fastly.HTTPError 409",
+			input:      &fastly.HTTPError{StatusCode: http.StatusConflict},
+			wantCode:   errors.ExitConflict,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "fastly.HTTPError 429",
+			input:      &fastly.HTTPError{StatusCode: http.StatusTooManyRequests},
+			wantCode:   errors.ExitRateLimited,
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "fastly.HTTPError 422",
+			input:      &fastly.HTTPError{StatusCode: http.StatusUnprocessableEntity},
+			wantCode:   errors.ExitValidation,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "fastly.HTTPError 503",
+			input:      &fastly.HTTPError{StatusCode: http.StatusServiceUnavailable},
+			wantCode:   errors.ExitInternal,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "fastly input validation",
+			input:    fastly.ErrMissingServiceID,
+			wantCode: errors.ExitValidation,
+		},
+		{
+			name:     "wrapped fastly input validation",
+			input:    fmt.Errorf("error listing backends: %w", fastly.ErrMissingServiceVersion),
+			wantCode: errors.ExitValidation,
+		},
+		{
+			name:     "network error",
+			input:    fmt.Errorf("error executing API request: %w", &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}),
+			wantCode: errors.ExitNetwork,
+		},
+		{
+			name:     "temporary network error",
+			input:    isTemporary{fmt.Errorf("baz")},
+			wantCode: errors.ExitNetwork,
+		},
+		{
+			name:     "host error",
+			input:    fmt.Errorf("couldn't do the thing: %w", os.ErrNotExist),
+			wantCode: errors.ExitInternal,
+		},
+		{
+			name:     "external program",
+			input:    errors.ExitError{Code: 42, Inner: fmt.Errorf("exit status 42")},
+			wantCode: 42,
+		},
+		{
+			name:     "unknown error",
+			input:    fmt.Errorf("oops"),
+			wantCode: errors.ExitInternal,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			testutil.AssertEqual(t, testcase.wantCode, errors.ExitCode(testcase.input))
+			testutil.AssertEqual(t, testcase.wantStatus, errors.HTTPStatus(testcase.input))
+		})
+	}
+}
+
+func TestPrintJSON(t *testing.T) {
+	for _, testcase := range []struct {
+		name   string
+		input  errors.RemediationError
+		code   int
+		status int
+		want   string
+	}{
+		{
+			name:  "no remediation",
+			input: errors.RemediationError{Prefix: "USAGE", Inner: fmt.Errorf("foo")},
+			code:  errors.ExitUsage,
+			want:  `{"code":2,"message":"foo"}` + "\n",
+		},
+		{
+			name:   "remediation and status",
+			input:  errors.RemediationError{Inner: fmt.Errorf("bar"), Remediation: "Reticulate your splines.\n"},
+			code:   errors.ExitNotFound,
+			status: http.StatusNotFound,
+			want:   `{"code":4,"message":"bar","remediation":"Reticulate your splines.","http_status":404}` + "\n",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			testcase.input.PrintJSON(&buf, testcase.code, testcase.status)
+			testutil.AssertString(t, testcase.want, buf.String())
+		})
+	}
+}
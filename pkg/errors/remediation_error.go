@@ -1,9 +1,11 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/text"
@@ -48,6 +50,25 @@ func (re RemediationError) Print(w io.Writer) {
 	}
 }
 
+// PrintJSON writes the error to the io.Writer as a single JSON object, for
+// machine consumption. The exit code and HTTP status should be derived from the
+// original error via ExitCode and HTTPStatus. Any prefix is omitted, as it
+// only ever contains usage text intended for humans.
+func (re RemediationError) PrintJSON(w io.Writer, code, status int) {
+	v := struct {
+		Code        int    `json:"code"`
+		Message     string `json:"message"`
+		Remediation string `json:"remediation,omitempty"`
+		HTTPStatus  int    `json:"http_status,omitempty"`
+	}{
+		Code:        code,
+		Message:     re.Error(),
+		Remediation: strings.TrimRight(re.Remediation, "\r\n"),
+		HTTPStatus:  status,
+	}
+	json.NewEncoder(w).Encode(v) // a struct of strings and ints can't fail to encode
+}
+
 // AuthRemediation suggests checking the provided --token.
 var AuthRemediation = fmt.Sprintf(strings.Join([]string{
 	"This error may be caused by a missing, incorrect, or expired Fastly API token.",
@@ -56,12 +77,57 @@ var AuthRemediation = fmt.Sprintf(strings.Join([]string{
 	"Verify that the token is still valid via `fastly whoami`.",
 }, " "), config.EnvVarToken)
 
+// CredentialRemediation suggests checking how the API token is stored in the
+// config file, when it's encrypted or supplied by a command.
+var CredentialRemediation = fmt.Sprintf(strings.Join([]string{
+	"This error may be caused by an incorrect passphrase for the encrypted token in the config file,",
+	"or by a failing token_command. Provide the passphrase interactively or via the environment variable %s,",
+	"check the token_command in the config file, or re-run `fastly configure`.",
+}, " "), config.EnvVarCredentialsPassphrase)
+
 // NetworkRemediation suggests, somewhat unhelpfully, to try again later.
 var NetworkRemediation = strings.Join([]string{
 	"This error may be caused by transient network issues.",
 	"Please verify your network connection and try again.",
 }, " ")
 
+// ForbiddenRemediation suggests checking the scope and service access of the
+// provided token.
+var ForbiddenRemediation = strings.Join([]string{
+	"This error may be caused by a Fastly API token without the required scope,",
+	"or one which is restricted to other services.",
+	"Check the token's scope and services via `fastly whoami --verbose`,",
+	"and create a token with the required access if necessary.",
+}, " ")
+
+// NotFoundRemediation suggests checking the provided service ID and version.
+var NotFoundRemediation = strings.Join([]string{
+	"This error may be caused by an incorrect service ID or version number.",
+	"Check that you're supplying the right --service-id, either via the flag or your package manifest,",
+	"and a --version that exists. List available services via `fastly service list`,",
+	"and their versions via `fastly service-version list`.",
+}, " ")
+
+// CloneRemediation suggests cloning a locked or active service version, as
+// only draft versions can be modified.
+var CloneRemediation = strings.Join([]string{
+	"Locked and active service versions can't be modified.",
+	"Clone the version via `fastly service-version clone --version <version>`,",
+	"and make your changes to the new draft version instead.",
+}, " ")
+
+// RateLimitRemediation suggests waiting for the Fastly API rate limit to reset.
+// If the reset time is unknown, reset should be the zero time.
+func RateLimitRemediation(reset time.Time) string {
+	if reset.IsZero() {
+		return "You have exceeded the Fastly API rate limit. Please wait a while and try again."
+	}
+	return fmt.Sprintf(
+		"You have exceeded the Fastly API rate limit, which resets at %s. Please try again after that time.",
+		reset.Format(time.RFC1123),
+	)
+}
+
 // HostRemediation suggests there might be an issue with the local host.
 var HostRemediation = strings.Join([]string{
 	"This error may be caused by a problem with your host environment, for example",
@@ -75,6 +141,13 @@ var BugRemediation = strings.Join([]string{
 	"https://github.com/fastly/cli/issues/new?labels=bug&template=bug_report.md",
 }, " ")
 
+// AutoYesRemediation suggests confirming a destructive operation up front when
+// running non-interactively, e.g. from a script.
+var AutoYesRemediation = strings.Join([]string{
+	"This command requires confirmation. Run it from an interactive terminal,",
+	"or confirm ahead of time with the --auto-yes flag.",
+}, " ")
+
 // ServiceIDRemediation suggests provide a service ID via --service-id flag or
 // package manifest.
 var ServiceIDRemediation = strings.Join([]string{
@@ -0,0 +1,3 @@
+// Package events contains commands to inspect the audit log of changes made to
+// Fastly accounts and services, such as version activations.
+package events
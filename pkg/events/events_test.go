@@ -0,0 +1,123 @@
+package events_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil/golden"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestEventsList(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"events", "list"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--limit", "2"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--limit", "0"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--from", "2021-01-02T11:00:00Z"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--to", "2021-01-02T12:00Z"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--service-id", "123", "--event-type", "version.activate"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--format", "json"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--user-id", "nobody"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesOK},
+		},
+		{
+			Args: []string{"events", "list", "--service-id", "456"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsOK, ListServicesFn: listServicesError},
+		},
+		{
+			Args: []string{"events", "list", "--event-type", "version.activate"},
+			API:  mock.API{GetAPIEventsFn: getAPIEventsError},
+		},
+		{
+			Args: []string{"events", "list", "--from", "yesterday"},
+		},
+		{
+			Args: []string{"events", "list", "--follow", "--to", "2021-01-02T12:00Z"},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+// testEvents are the events of the account, newest first, as the API lists
+// them.
+var testEvents = []*fastly.Event{
+	testEvent("3", "2021-01-02T12:30:00Z", "version.activate", "123", "u1", "Version 3 was activated"),
+	testEvent("2", "2021-01-02T11:30:00Z", "user.login", "", "u2", "User logged in"),
+	testEvent("1", "2021-01-02T10:30:00Z", "version.activate", "456", "u1", "Version 2 was activated"),
+}
+
+func testEvent(id, createdAt, eventType, serviceID, userID, description string) *fastly.Event {
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		panic(err)
+	}
+	return &fastly.Event{ID: id, CreatedAt: &t, EventType: eventType, ServiceID: serviceID, UserID: userID, Description: description}
+}
+
+// getAPIEventsOK applies the filters to testEvents, and lists them in pages of
+// two.
+func getAPIEventsOK(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	var events []*fastly.Event
+	for _, e := range testEvents {
+		if (i.ServiceID == "" || i.ServiceID == e.ServiceID) &&
+			(i.EventType == "" || i.EventType == e.EventType) &&
+			(i.UserID == "" || i.UserID == e.UserID) {
+			events = append(events, e)
+		}
+	}
+	var resp fastly.GetAPIEventsResponse
+	start := (i.PageNumber - 1) * 2
+	if start < len(events) {
+		resp.Events = events[start:]
+	}
+	if len(resp.Events) > 2 {
+		resp.Events = resp.Events[:2]
+		resp.Links.Next = "next"
+	}
+	return resp, nil
+}
+
+func getAPIEventsError(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	return fastly.GetAPIEventsResponse{}, errTest
+}
+
+func listServicesOK(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+	return []*fastly.Service{
+		{ID: "123", Name: "Foo"},
+		{ID: "456", Name: "Bar"},
+	}, nil
+}
+
+func listServicesError(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+	return nil, errTest
+}
+
+var errTest = errors.New("fixture error")
@@ -0,0 +1,293 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// pageSize is how many events are requested at a time, and followInterval how
+// often new events are polled for by --follow.
+const (
+	pageSize       = 100
+	followInterval = 10 * time.Second
+)
+
+// ListCommand calls the Fastly API to list the events of the audit log.
+type ListCommand struct {
+	common.Base
+	manifest manifest.Data
+	input    fastly.GetAPIEventsFilterInput
+	from     common.OptionalString
+	to       common.OptionalString
+	limit    int
+	follow   bool
+	format   string
+
+	// now and sleep are replaced in tests.
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent common.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.now = time.Now
+	c.sleep = time.Sleep
+	c.manifest.File.Read(manifest.Filename)
+	c.CmdClause = parent.Command("list", "List events, such as version activations, oldest first")
+	c.CmdClause.Flag("service-id", "Only list events of this service").Short('s').StringVar(&c.manifest.Flag.ServiceID)
+	c.CmdClause.Flag("user-id", "Only list events caused by this user").StringVar(&c.input.UserID)
+	c.CmdClause.Flag("event-type", "Only list events of this type, e.g. version.activate").StringVar(&c.input.EventType)
+	c.CmdClause.Flag("from", "Only list events since this time, e.g. 2021-01-02T15:04Z, or 24h ago").Action(c.from.Set).StringVar(&c.from.Value)
+	c.CmdClause.Flag("to", "Only list events before this time, e.g. 2021-01-02T15:04Z, or 1h ago").Action(c.to.Set).StringVar(&c.to.Value)
+	c.CmdClause.Flag("limit", "Maximum number of events to list, or 0 for no limit").Default(fmt.Sprint(pageSize)).IntVar(&c.limit)
+	c.CmdClause.Flag("follow", "Keep listing new events as they happen, until interrupted").BoolVar(&c.follow)
+	c.CmdClause.Flag("format", "Output format (json)").EnumVar(&c.format, "json")
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	c.format = c.Globals.CommandFormat(c.format)
+
+	if serviceID, source := c.manifest.ServiceID(); source != manifest.SourceUndefined {
+		c.input.ServiceID = serviceID
+	}
+
+	now := c.now()
+	var from, to time.Time
+	if c.from.WasSet {
+		t, err := common.ParseTime("from", c.from.Value, now)
+		if err != nil {
+			return err
+		}
+		from = t
+	}
+	if c.to.WasSet {
+		if c.follow {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("error parsing arguments: --follow and --to can't be used together"),
+				Remediation: "Pass either --follow, or --to, but not both.",
+			}
+		}
+		t, err := common.ParseTime("to", c.to.Value, now)
+		if err != nil {
+			return err
+		}
+		to = t
+	}
+
+	events, err := c.list(from, to)
+	if err != nil {
+		return err
+	}
+	p := printer{out: out, format: c.format, names: newServiceNames(c.Globals.Client)}
+	if len(events) == 0 && !c.follow && c.format == "" {
+		text.Info(out, "No events found")
+		return nil
+	}
+	if err := p.print(events); err != nil {
+		return err
+	}
+	if !c.follow {
+		return nil
+	}
+
+	// New events are those at or after the latest event listed so far, which
+	// haven't been listed.
+	since := from
+	seen := make(map[string]bool)
+	for _, e := range events {
+		seen[e.ID] = true
+		since = *e.CreatedAt
+	}
+	for {
+		c.sleep(followInterval)
+		events, err := c.poll(since, seen)
+		if err != nil {
+			text.Error(out, "fetching events: %v", err)
+			continue
+		}
+		for _, e := range events {
+			since = *e.CreatedAt
+		}
+		if err := p.print(events); err != nil {
+			return err
+		}
+	}
+}
+
+// list returns the events in the time range, up to the limit, oldest first.
+func (c *ListCommand) list(from, to time.Time) ([]*fastly.Event, error) {
+	input := c.input
+	input.MaxResults = pageSize
+
+	var events []*fastly.Event
+	for input.PageNumber = 1; ; input.PageNumber++ {
+		resp, err := c.Globals.Client.GetAPIEvents(&input)
+		if err != nil {
+			return nil, fmt.Errorf("error listing events: %w", err)
+		}
+
+		var earlier bool
+		for _, e := range resp.Events {
+			switch {
+			case e.CreatedAt == nil:
+				continue
+			case !to.IsZero() && !e.CreatedAt.Before(to):
+				continue
+			case e.CreatedAt.Before(from):
+				earlier = true
+				continue
+			}
+			events = append(events, e)
+			if len(events) == c.limit {
+				break
+			}
+		}
+		full := c.limit > 0 && len(events) == c.limit
+
+		// Events are listed newest first, so once one precedes the time range,
+		// so do all those on later pages.
+		if earlier || full || len(resp.Events) == 0 || resp.Links.Next == "" {
+			break
+		}
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// poll returns the events at or after since which haven't been seen, oldest
+// first, and marks them as seen. Only the newest page of events is fetched, so
+// a burst of more events than fit on a page between polls isn't all listed.
+func (c *ListCommand) poll(since time.Time, seen map[string]bool) ([]*fastly.Event, error) {
+	input := c.input
+	input.MaxResults = pageSize
+	input.PageNumber = 1
+	resp, err := c.Globals.Client.GetAPIEvents(&input)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*fastly.Event
+	for _, e := range resp.Events {
+		if e.CreatedAt == nil || e.CreatedAt.Before(since) || seen[e.ID] {
+			continue
+		}
+		seen[e.ID] = true
+		events = append(events, e)
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+func sortEvents(events []*fastly.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(*events[j].CreatedAt)
+	})
+}
+
+// printer writes events as a table, or as JSON, one event per line.
+type printer struct {
+	out     io.Writer
+	format  string
+	names   *serviceNames
+	printed bool // whether the table header has been written
+}
+
+// eventJSON is an event as written by --format=json, with the name of its
+// service.
+type eventJSON struct {
+	ID          string                 `json:"id"`
+	CreatedAt   time.Time              `json:"created_at"`
+	EventType   string                 `json:"event_type"`
+	ServiceID   string                 `json:"service_id,omitempty"`
+	ServiceName string                 `json:"service_name,omitempty"`
+	UserID      string                 `json:"user_id,omitempty"`
+	IP          string                 `json:"ip,omitempty"`
+	Admin       bool                   `json:"admin"`
+	Description string                 `json:"description"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func (p *printer) print(events []*fastly.Event) error {
+	if p.format == "json" {
+		enc := json.NewEncoder(p.out)
+		for _, e := range events {
+			if err := enc.Encode(eventJSON{
+				ID:          e.ID,
+				CreatedAt:   e.CreatedAt.UTC(),
+				EventType:   e.EventType,
+				ServiceID:   e.ServiceID,
+				ServiceName: p.names.name(e.ServiceID),
+				UserID:      e.UserID,
+				IP:          e.IP,
+				Admin:       e.Admin,
+				Description: e.Description,
+				Metadata:    e.Metadata,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(events) == 0 && p.printed {
+		return nil
+	}
+	tw := text.NewTable(p.out)
+	if !p.printed {
+		tw.AddHeader("TIME", "EVENT TYPE", "SERVICE ID", "SERVICE NAME", "USER ID", "DESCRIPTION")
+		p.printed = true
+	}
+	for _, e := range events {
+		tw.AddLine(e.CreatedAt.UTC().Format(time.RFC3339), e.EventType, e.ServiceID, p.names.name(e.ServiceID), e.UserID, e.Description)
+	}
+	tw.Print()
+	return nil
+}
+
+// serviceNames resolves service IDs to names. Services are listed again on
+// meeting an unknown ID, such as that of a service created since they were
+// last listed, but only once per ID, as deleted services are never listed.
+type serviceNames struct {
+	client api.Interface
+	names  map[string]string
+	tried  map[string]bool
+}
+
+func newServiceNames(client api.Interface) *serviceNames {
+	return &serviceNames{client: client, names: make(map[string]string), tried: make(map[string]bool)}
+}
+
+// name returns the name of a service, or "" if it's unknown. Names are only
+// informative, so failing to list services isn't an error.
+func (n *serviceNames) name(id string) string {
+	if id == "" {
+		return ""
+	}
+	if name, ok := n.names[id]; ok {
+		return name
+	}
+	if !n.tried[id] {
+		n.tried[id] = true
+		if services, err := n.client.ListServices(&fastly.ListServicesInput{}); err == nil {
+			for _, s := range services {
+				n.names[s.ID] = s.Name
+			}
+		}
+	}
+	return n.names[id]
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// TestPoll polls for new events as --follow does, with the newest page of
+// events growing between polls.
+func TestPoll(t *testing.T) {
+	at := func(minute int) *time.Time {
+		t := time.Date(2021, 1, 2, 12, minute, 0, 0, time.UTC)
+		return &t
+	}
+	var page []*fastly.Event
+	c := ListCommand{}
+	c.Globals = &config.Data{Client: mock.API{
+		GetAPIEventsFn: func(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+			testutil.AssertEqual(t, 1, i.PageNumber)
+			return fastly.GetAPIEventsResponse{Events: page}, nil
+		},
+	}}
+
+	// Event 1 was listed before following began.
+	since := *at(1)
+	seen := map[string]bool{"1": true}
+	ids := func(events []*fastly.Event) (ids []string) {
+		for _, e := range events {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+
+	page = []*fastly.Event{
+		{ID: "1", CreatedAt: at(1)},
+		{ID: "0", CreatedAt: at(0)},
+	}
+	events, err := c.poll(since, seen)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string(nil), ids(events))
+
+	// Events are returned oldest first, including those created in the same
+	// second as the latest seen event.
+	page = []*fastly.Event{
+		{ID: "3", CreatedAt: at(2)},
+		{ID: "2", CreatedAt: at(1)},
+		{ID: "1", CreatedAt: at(1)},
+		{ID: "0", CreatedAt: at(0)},
+	}
+	events, err = c.poll(since, seen)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string{"2", "3"}, ids(events))
+
+	events, err = c.poll(*at(2), seen)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, []string(nil), ids(events))
+}
@@ -0,0 +1,8 @@
+// Package fakeapi provides a stateful, in-memory fake of the Fastly API.
+// It models services and their versions, including clone, activate and lock
+// semantics, along with each version's domains, backends, dictionaries,
+// logging endpoints and other name-keyed resources, dictionary items,
+// Compute@Edge packages, and log tailing sessions. Commands can be run end to
+// end against it through the real go-fastly client, by pointing the API
+// endpoint at the server's URL.
+package fakeapi
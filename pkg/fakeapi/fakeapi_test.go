@@ -0,0 +1,176 @@
+package fakeapi_test
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/fastly/cli/pkg/fakeapi"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestVersionLifecycle(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+	client := newClient(t, server)
+
+	s, err := client.CreateService(&fastly.CreateServiceInput{Name: "example", Type: "wasm"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "service000001", s.ID)
+	testutil.AssertEqual(t, 1, len(s.Versions))
+
+	b, err := client.CreateBackend(&fastly.CreateBackendInput{
+		ServiceID:      s.ID,
+		ServiceVersion: 1,
+		Name:           "origin",
+		Address:        "example.com",
+		Port:           443,
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "example.com", b.Address)
+	testutil.AssertEqual(t, uint(443), b.Port)
+	testutil.AssertEqual(t, 1, b.ServiceVersion)
+
+	v, err := client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: s.ID, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	testutil.AssertBool(t, true, v.Active)
+	testutil.AssertBool(t, true, v.Locked)
+
+	_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: s.ID, ServiceVersion: 1, Name: "other", Address: "example.org"})
+	assertStatus(t, http.StatusConflict, err)
+
+	v, err = client.CloneVersion(&fastly.CloneVersionInput{ServiceID: s.ID, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, v.Number)
+	testutil.AssertBool(t, false, v.Active)
+	testutil.AssertBool(t, false, v.Locked)
+
+	newName := "primary"
+	b, err = client.UpdateBackend(&fastly.UpdateBackendInput{ServiceID: s.ID, ServiceVersion: 2, Name: "origin", NewName: &newName})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "primary", b.Name)
+	testutil.AssertString(t, "example.com", b.Address)
+
+	backends, err := client.ListBackends(&fastly.ListBackendsInput{ServiceID: s.ID, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(backends))
+	testutil.AssertString(t, "origin", backends[0].Name)
+
+	_, err = client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: s.ID, ServiceVersion: 2})
+	testutil.AssertNoError(t, err)
+
+	d, err := client.GetServiceDetails(&fastly.GetServiceInput{ID: s.ID})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, d.ActiveVersion.Number)
+	testutil.AssertBool(t, true, d.Versions[0].Locked)
+	testutil.AssertBool(t, false, d.Versions[0].Active)
+
+	err = client.DeleteService(&fastly.DeleteServiceInput{ID: s.ID})
+	assertStatus(t, http.StatusConflict, err)
+
+	_, err = client.DeactivateVersion(&fastly.DeactivateVersionInput{ServiceID: s.ID, ServiceVersion: 2})
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, client.DeleteService(&fastly.DeleteServiceInput{ID: s.ID}))
+
+	_, err = client.GetService(&fastly.GetServiceInput{ID: s.ID})
+	assertStatus(t, http.StatusNotFound, err)
+}
+
+func TestDictionaryItems(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+	client := newClient(t, server)
+	serviceID := server.AddService("example", "vcl")
+
+	d, err := client.CreateDictionary(&fastly.CreateDictionaryInput{ServiceID: serviceID, ServiceVersion: 1, Name: "settings"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "dict000001", d.ID)
+
+	_, err = client.CreateDictionaryItem(&fastly.CreateDictionaryItemInput{ServiceID: serviceID, DictionaryID: d.ID, ItemKey: "a", ItemValue: "1"})
+	testutil.AssertNoError(t, err)
+
+	err = client.BatchModifyDictionaryItems(&fastly.BatchModifyDictionaryItemsInput{
+		ServiceID:    serviceID,
+		DictionaryID: d.ID,
+		Items: []*fastly.BatchDictionaryItem{
+			{Operation: fastly.UpsertBatchOperation, ItemKey: "a", ItemValue: "2"},
+			{Operation: fastly.CreateBatchOperation, ItemKey: "b", ItemValue: "3"},
+		},
+	})
+	testutil.AssertNoError(t, err)
+
+	items, err := client.ListDictionaryItems(&fastly.ListDictionaryItemsInput{ServiceID: serviceID, DictionaryID: d.ID})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(items))
+	testutil.AssertString(t, "2", items[0].ItemValue)
+	testutil.AssertString(t, "3", items[1].ItemValue)
+
+	err = client.BatchModifyDictionaryItems(&fastly.BatchModifyDictionaryItemsInput{
+		ServiceID:    serviceID,
+		DictionaryID: d.ID,
+		Items: []*fastly.BatchDictionaryItem{
+			{Operation: fastly.DeleteBatchOperation, ItemKey: "a"},
+			{Operation: fastly.DeleteBatchOperation, ItemKey: "missing"},
+		},
+	})
+	assertStatus(t, http.StatusNotFound, err)
+
+	_, err = client.GetDictionaryItem(&fastly.GetDictionaryItemInput{ServiceID: serviceID, DictionaryID: d.ID, ItemKey: "a"})
+	testutil.AssertNoError(t, err) // the failed batch wasn't partially applied
+}
+
+func TestPackage(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+	client := newClient(t, server)
+	serviceID := server.AddService("example", "wasm")
+
+	_, err := client.GetPackage(&fastly.GetPackageInput{ServiceID: serviceID, ServiceVersion: 1})
+	assertStatus(t, http.StatusNotFound, err)
+
+	path := testutil.MakeTempFile(t, "package contents")
+	defer os.RemoveAll(path)
+
+	p, err := client.UpdatePackage(&fastly.UpdatePackageInput{ServiceID: serviceID, ServiceVersion: 1, PackagePath: path})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, int64(len("package contents")), p.Metadata.Size)
+	testutil.AssertString(t, fmt.Sprintf("%x", sha512.Sum512([]byte("package contents"))), p.Metadata.HashSum)
+
+	p, err = client.GetPackage(&fastly.GetPackageInput{ServiceID: serviceID, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, fmt.Sprintf("%x", sha512.Sum512([]byte("package contents"))), p.Metadata.HashSum)
+}
+
+func TestAuthentication(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/service")
+	testutil.AssertNoError(t, err)
+	defer resp.Body.Close()
+	ioutil.ReadAll(resp.Body)
+	testutil.AssertEqual(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func newClient(t *testing.T, server *fakeapi.Server) *fastly.Client {
+	t.Helper()
+	client, err := fastly.NewClientForEndpoint("token", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func assertStatus(t *testing.T, want int, err error) {
+	t.Helper()
+	var httpError *fastly.HTTPError
+	if !errors.As(err, &httpError) {
+		t.Fatalf("want HTTP error with status %d, have %v", want, err)
+	}
+	testutil.AssertEqual(t, want, httpError.StatusCode)
+}
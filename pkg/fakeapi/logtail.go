@@ -0,0 +1,112 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Log is a line written to stdout or stderr by a Compute@Edge service while
+// handling a request.
+type Log struct {
+	RequestID string
+	Stream    string
+	Message   string
+	Time      time.Time
+}
+
+// AddLogs adds a batch of logs written by a service, which its log tailing
+// sessions receive.
+func (s *Server) AddLogs(serviceID string, logs ...Log) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	batch := make([]object, 0, len(logs))
+	for i, l := range logs {
+		batch = append(batch, object{
+			"sequence_number":          i,
+			"request_start_from_epoch": l.Time.UnixNano() / int64(time.Microsecond),
+			"stream":                   l.Stream,
+			"id":                       l.RequestID,
+			"message":                  l.Message,
+		})
+	}
+	s.logs[serviceID] = append(s.logs[serviceID], object{"batch_id": s.nextID("batch"), "logs": batch})
+}
+
+// LogTails returns the number of open log tailing sessions.
+func (s *Server) LogTails() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tails)
+}
+
+// logTail is a log tailing session of a service. It receives the batches added
+// since it was created, or earlier batches when asked for logs from a time.
+type logTail struct {
+	serviceID string
+	start     int // index of the first batch added since its creation
+}
+
+// routeLogTails serves log tailing sessions, under
+// /service/<id>/log_stream/managed/instance_output. Sessions are created by a
+// POST, which returns their ID, and their batches are read by a GET of
+// <session ID>?cursor=<cursor>, until the session is deleted. Every response
+// includes the cursor from which to read next.
+func (s *Server) routeLogTails(r *http.Request, svc *service, seg []string) (interface{}, error) {
+	if svc.kind != "wasm" {
+		return nil, badRequest(fmt.Sprintf("Service %s isn't a Compute@Edge service, so its logs can't be tailed", svc.id))
+	}
+	if len(seg) == 0 {
+		if r.Method != http.MethodPost {
+			return nil, methodNotAllowed(r)
+		}
+		id := s.nextID("logtail")
+		s.tails[id] = &logTail{serviceID: svc.id, start: len(s.logs[svc.id])}
+		return object{"id": id, "service_id": svc.id}, nil
+	}
+
+	tail, ok := s.tails[seg[0]]
+	if !ok || tail.serviceID != svc.id || len(seg) > 1 {
+		return nil, notFound("Record not found", fmt.Sprintf("Couldn't find log tailing session %s of service %s", seg[0], svc.id))
+	}
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+		next := tail.start
+		var from int64
+		if v := query.Get("from"); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, badRequest(fmt.Sprintf("Invalid time %s", v))
+			}
+			next, from = 0, n*int64(time.Second/time.Microsecond)
+		}
+		if v := query.Get("cursor"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 || n > len(s.logs[svc.id]) {
+				return nil, badRequest(fmt.Sprintf("Invalid cursor %s", v))
+			}
+			next = n
+		}
+
+		batches := []object{}
+		for _, b := range s.logs[svc.id][next:] {
+			var logs []object
+			for _, l := range b["logs"].([]object) {
+				if l["request_start_from_epoch"].(int64) >= from {
+					logs = append(logs, l)
+				}
+			}
+			if len(logs) > 0 {
+				batches = append(batches, object{"batch_id": b["batch_id"], "logs": logs})
+			}
+		}
+		return object{"batches": batches, "cursor": strconv.Itoa(len(s.logs[svc.id]))}, nil
+	case http.MethodDelete:
+		delete(s.tails, seg[0])
+		return statusOK(), nil
+	}
+	return nil, methodNotAllowed(r)
+}
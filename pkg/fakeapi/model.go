@@ -0,0 +1,204 @@
+package fakeapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// object is a single API resource, as rendered to JSON.
+type object map[string]interface{}
+
+// copy returns a shallow copy of the object, which is enough as all values
+// are scalars.
+func (o object) copy() object {
+	c := make(object, len(o))
+	for k, v := range o {
+		c[k] = v
+	}
+	return c
+}
+
+// collection is an ordered set of objects keyed by name.
+type collection struct {
+	names []string
+	items map[string]object
+}
+
+func newCollection() *collection {
+	return &collection{items: map[string]object{}}
+}
+
+func (c *collection) get(name string) (object, bool) {
+	o, ok := c.items[name]
+	return o, ok
+}
+
+func (c *collection) put(name string, o object) {
+	if _, ok := c.items[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.items[name] = o
+}
+
+func (c *collection) rename(from, to string) {
+	for i, name := range c.names {
+		if name == from {
+			c.names[i] = to
+		}
+	}
+	c.items[to] = c.items[from]
+	delete(c.items, from)
+}
+
+func (c *collection) remove(name string) bool {
+	if _, ok := c.items[name]; !ok {
+		return false
+	}
+	for i, n := range c.names {
+		if n == name {
+			c.names = append(c.names[:i], c.names[i+1:]...)
+			break
+		}
+	}
+	delete(c.items, name)
+	return true
+}
+
+func (c *collection) list() []object {
+	list := make([]object, 0, len(c.names))
+	for _, name := range c.names {
+		list = append(list, c.items[name])
+	}
+	return list
+}
+
+func (c *collection) clone() *collection {
+	n := newCollection()
+	for _, name := range c.names {
+		n.put(name, c.items[name].copy())
+	}
+	return n
+}
+
+// version is a configuration version of a service. Its resources are keyed by
+// their path relative to the version, e.g. "backend" or "logging/syslog".
+type version struct {
+	number    int
+	comment   string
+	active    bool
+	locked    bool
+	createdAt time.Time
+	updatedAt time.Time
+	resources map[string]*collection
+	pkg       object
+}
+
+func (v *version) collection(kind string) *collection {
+	c, ok := v.resources[kind]
+	if !ok {
+		c = newCollection()
+		v.resources[kind] = c
+	}
+	return c
+}
+
+// editable returns an error if the version can't be modified, as only draft
+// versions can be.
+func (v *version) editable(serviceID string) error {
+	switch {
+	case v.active:
+		return conflict("Version active", fmt.Sprintf("Version %d of service %s is active and can't be modified", v.number, serviceID))
+	case v.locked:
+		return conflict("Version locked", fmt.Sprintf("Version %d of service %s is locked and can't be modified", v.number, serviceID))
+	}
+	return nil
+}
+
+func (v *version) render(serviceID string) object {
+	return object{
+		"number":     v.number,
+		"service_id": serviceID,
+		"comment":    v.comment,
+		"active":     v.active,
+		"locked":     v.locked,
+		"deployed":   v.active,
+		"staging":    false,
+		"testing":    false,
+		"created_at": timestamp(v.createdAt),
+		"updated_at": timestamp(v.updatedAt),
+	}
+}
+
+// service is a Fastly service and all of its versions, ordered by number.
+type service struct {
+	id        string
+	name      string
+	kind      string
+	comment   string
+	createdAt time.Time
+	updatedAt time.Time
+	versions  []*version
+}
+
+func (s *service) version(number int) (*version, error) {
+	if number < 1 || number > len(s.versions) {
+		return nil, notFound("Record not found", fmt.Sprintf("Couldn't find version %d of service %s", number, s.id))
+	}
+	return s.versions[number-1], nil
+}
+
+func (s *service) activeVersion() *version {
+	for _, v := range s.versions {
+		if v.active {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *service) render() object {
+	var active int
+	if v := s.activeVersion(); v != nil {
+		active = v.number
+	}
+	versions := make([]object, 0, len(s.versions))
+	for _, v := range s.versions {
+		versions = append(versions, v.render(s.id))
+	}
+	return object{
+		"id":          s.id,
+		"name":        s.name,
+		"type":        s.kind,
+		"comment":     s.comment,
+		"customer_id": CustomerID,
+		"created_at":  timestamp(s.createdAt),
+		"updated_at":  timestamp(s.updatedAt),
+		"version":     active,
+		"versions":    versions,
+	}
+}
+
+func (s *service) renderDetails() object {
+	o := s.render()
+	if v := s.activeVersion(); v != nil {
+		o["active_version"] = v.render(s.id)
+	}
+	o["version"] = s.versions[len(s.versions)-1].render(s.id)
+	return o
+}
+
+// dictionaryIDs returns the IDs of every dictionary in any version of the
+// service, as dictionary items are shared by all versions.
+func (s *service) dictionaryIDs() map[string]bool {
+	ids := map[string]bool{}
+	for _, v := range s.versions {
+		for _, d := range v.collection("dictionary").list() {
+			ids[fmt.Sprint(d["id"])] = true
+		}
+	}
+	return ids
+}
+
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
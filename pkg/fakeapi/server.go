@@ -0,0 +1,696 @@
+package fakeapi
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// The fixed identities of the account the fake API authenticates every token
+// as. Any non-empty token is accepted.
+const (
+	CustomerID = "customer000001"
+	UserID     = "user000001"
+	UserLogin  = "user@example.com"
+	UserName   = "Fake User"
+	TokenID    = "token000001"
+)
+
+// epoch is the time of the fake clock when a Server starts. Each change ticks
+// it forward by a second, so timestamps are deterministic and ordered.
+var epoch = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Server is an HTTP server which fakes the Fastly API. Point a go-fastly client
+// at its URL to use it.
+type Server struct {
+	URL  string // base URL of the form http://ipaddr:port, with no trailing slash
+	http *http.Server
+
+	mu       sync.Mutex
+	clock    time.Time
+	ids      map[string]int
+	services []*service
+	items    map[string]*collection // dictionary items, by dictionary ID
+	logs     map[string][]object    // batches of logs, by service ID
+	tails    map[string]*logTail    // log tailing sessions, by ID
+}
+
+// New starts and returns a new Server listening on a loopback address chosen
+// by the system. The caller should call Close when finished.
+func New() *Server {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("fakeapi: failed to listen on a port: %v", err))
+	}
+	return NewWithListener(l)
+}
+
+// NewWithListener starts and returns a new Server on the listener, e.g. to
+// serve from a well-known address. The caller should call Close when finished.
+func NewWithListener(l net.Listener) *Server {
+	s := newServer()
+	s.URL = "http://" + l.Addr().String()
+	s.http = &http.Server{Handler: s}
+	go s.http.Serve(l)
+	return s
+}
+
+// Close shuts down the server, closing its listener and any connections.
+func (s *Server) Close() {
+	s.http.Close()
+}
+
+func newServer() *Server {
+	return &Server{
+		clock: epoch,
+		ids:   map[string]int{},
+		items: map[string]*collection{},
+		logs:  map[string][]object{},
+		tails: map[string]*logTail{},
+	}
+}
+
+// AddService creates a service with a single draft version, and returns its
+// ID. It's a shortcut for tests that need a service to exist up front.
+func (s *Server) AddService(name, serviceType string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addService(name, serviceType, "").id
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Fastly-Key") == "" {
+		writeJSON(w, http.StatusUnauthorized, object{"msg": "Provide credentials", "detail": "Missing Fastly-Key header"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	body, err := s.route(r, segments)
+	if err != nil {
+		e, ok := err.(*apiError)
+		if !ok {
+			e = &apiError{status: http.StatusBadRequest, msg: "Bad request", detail: err.Error()}
+		}
+		writeJSON(w, e.status, object{"msg": e.msg, "detail": e.detail})
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *Server) route(r *http.Request, seg []string) (interface{}, error) {
+	switch {
+	case match(seg, "verify") && r.Method == http.MethodGet:
+		return s.verify(), nil
+	case match(seg, "tokens", "self") && r.Method == http.MethodGet:
+		return s.token(), nil
+	case match(seg, "current_user") && r.Method == http.MethodGet,
+		match(seg, "user", UserID) && r.Method == http.MethodGet:
+		return s.user(), nil
+	case match(seg, "service"):
+		return s.routeServices(r)
+	case match(seg, "service", "search") && r.Method == http.MethodGet:
+		return s.searchService(r.URL.Query().Get("name"))
+	case len(seg) >= 2 && seg[0] == "service":
+		svc, err := s.service(seg[1])
+		if err != nil {
+			return nil, err
+		}
+		return s.routeService(r, svc, seg[2:])
+	}
+	return nil, notFound("Not found", fmt.Sprintf("%s %s isn't supported by the fake API", r.Method, r.URL.Path))
+}
+
+func (s *Server) routeServices(r *http.Request) (interface{}, error) {
+	switch r.Method {
+	case http.MethodGet:
+		list := make([]object, 0, len(s.services))
+		for _, svc := range s.services {
+			list = append(list, svc.render())
+		}
+		return list, nil
+	case http.MethodPost:
+		form, err := parseForm(r)
+		if err != nil {
+			return nil, err
+		}
+		name := str(form["name"])
+		if name == "" {
+			return nil, badRequest("Name is required")
+		}
+		if _, err := s.searchService(name); err == nil {
+			return nil, conflict("Duplicate record", fmt.Sprintf("A service named %s already exists", name))
+		}
+		return s.addService(name, str(form["type"]), str(form["comment"])).render(), nil
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) routeService(r *http.Request, svc *service, seg []string) (interface{}, error) {
+	switch {
+	case len(seg) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			return svc.render(), nil
+		case http.MethodPut:
+			form, err := parseForm(r)
+			if err != nil {
+				return nil, err
+			}
+			if name, ok := form["name"]; ok {
+				svc.name = str(name)
+			}
+			if comment, ok := form["comment"]; ok {
+				svc.comment = str(comment)
+			}
+			svc.updatedAt = s.tick()
+			return svc.render(), nil
+		case http.MethodDelete:
+			if v := svc.activeVersion(); v != nil {
+				return nil, conflict("Version active", fmt.Sprintf("Deactivate version %d of service %s before deleting it", v.number, svc.id))
+			}
+			for i, other := range s.services {
+				if other == svc {
+					s.services = append(s.services[:i], s.services[i+1:]...)
+					break
+				}
+			}
+			return statusOK(), nil
+		}
+	case match(seg, "details") && r.Method == http.MethodGet:
+		return svc.renderDetails(), nil
+	case match(seg, "version"):
+		return s.routeVersions(r, svc)
+	case len(seg) >= 2 && seg[0] == "version":
+		number, err := strconv.Atoi(seg[1])
+		if err != nil {
+			return nil, badRequest(fmt.Sprintf("Invalid version number %s", seg[1]))
+		}
+		v, err := svc.version(number)
+		if err != nil {
+			return nil, err
+		}
+		return s.routeVersion(r, svc, v, seg[2:])
+	case len(seg) >= 3 && seg[0] == "dictionary":
+		if !svc.dictionaryIDs()[seg[1]] {
+			return nil, notFound("Record not found", fmt.Sprintf("Couldn't find dictionary %s", seg[1]))
+		}
+		return s.routeDictionaryItems(r, svc, seg[1], seg[2:])
+	case len(seg) >= 3 && seg[0] == "log_stream" && seg[1] == "managed" && seg[2] == "instance_output":
+		return s.routeLogTails(r, svc, seg[3:])
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) routeVersions(r *http.Request, svc *service) (interface{}, error) {
+	switch r.Method {
+	case http.MethodGet:
+		list := make([]object, 0, len(svc.versions))
+		for _, v := range svc.versions {
+			list = append(list, v.render(svc.id))
+		}
+		return list, nil
+	case http.MethodPost:
+		form, err := parseForm(r)
+		if err != nil {
+			return nil, err
+		}
+		return s.addVersion(svc, str(form["comment"])).render(svc.id), nil
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) routeVersion(r *http.Request, svc *service, v *version, seg []string) (interface{}, error) {
+	switch {
+	case len(seg) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			return v.render(svc.id), nil
+		case http.MethodPut:
+			form, err := parseForm(r)
+			if err != nil {
+				return nil, err
+			}
+			// Comments can be changed even on locked versions.
+			if comment, ok := form["comment"]; ok {
+				v.comment = str(comment)
+			}
+			v.updatedAt = s.tick()
+			return v.render(svc.id), nil
+		}
+	case match(seg, "activate") && r.Method == http.MethodPut:
+		for _, other := range svc.versions {
+			other.active = false
+		}
+		v.active, v.locked = true, true
+		v.updatedAt = s.tick()
+		svc.updatedAt = v.updatedAt
+		return v.render(svc.id), nil
+	case match(seg, "deactivate") && r.Method == http.MethodPut:
+		if !v.active {
+			return nil, badRequest(fmt.Sprintf("Version %d of service %s isn't active", v.number, svc.id))
+		}
+		v.active = false
+		v.updatedAt = s.tick()
+		svc.updatedAt = v.updatedAt
+		return v.render(svc.id), nil
+	case match(seg, "lock") && r.Method == http.MethodPut:
+		v.locked = true
+		v.updatedAt = s.tick()
+		return v.render(svc.id), nil
+	case match(seg, "clone") && r.Method == http.MethodPut:
+		clone := s.addVersion(svc, v.comment)
+		for kind, c := range v.resources {
+			clone.resources[kind] = c.clone()
+		}
+		if v.pkg != nil {
+			clone.pkg = v.pkg.copy()
+		}
+		return clone.render(svc.id), nil
+	case match(seg, "validate") && r.Method == http.MethodGet:
+		return object{"status": "ok", "errors": []string{}, "warnings": []string{}}, nil
+	case match(seg, "package"):
+		return s.routePackage(r, svc, v)
+	case len(seg) >= 1:
+		kind, name := seg[0], seg[1:]
+		if kind == "logging" && len(seg) >= 2 {
+			kind, name = "logging/"+seg[1], seg[2:]
+		}
+		switch len(name) {
+		case 0:
+			return s.routeResources(r, svc, v, kind)
+		case 1:
+			return s.routeResource(r, svc, v, kind, name[0])
+		}
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) routeResources(r *http.Request, svc *service, v *version, kind string) (interface{}, error) {
+	c := v.collection(kind)
+	switch r.Method {
+	case http.MethodGet:
+		list := make([]object, 0, len(c.names))
+		for _, o := range c.list() {
+			list = append(list, renderResource(o, svc, v))
+		}
+		return list, nil
+	case http.MethodPost:
+		if err := v.editable(svc.id); err != nil {
+			return nil, err
+		}
+		form, err := parseForm(r)
+		if err != nil {
+			return nil, err
+		}
+		name := str(form["name"])
+		if name == "" {
+			return nil, badRequest("Name is required")
+		}
+		if _, ok := c.get(name); ok {
+			return nil, conflict("Duplicate record", fmt.Sprintf("A %s named %s already exists in version %d", kind, name, v.number))
+		}
+		if kind == "dictionary" {
+			form["id"] = s.nextID("dict")
+		}
+		now := timestamp(s.tick())
+		form["created_at"], form["updated_at"] = now, now
+		c.put(name, form)
+		v.updatedAt = s.clock
+		return renderResource(form, svc, v), nil
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) routeResource(r *http.Request, svc *service, v *version, kind, name string) (interface{}, error) {
+	c := v.collection(kind)
+	o, ok := c.get(name)
+	if !ok {
+		return nil, notFound("Record not found", fmt.Sprintf("Couldn't find %s '%s' in version %d of service %s", kind, name, v.number, svc.id))
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		return renderResource(o, svc, v), nil
+	case http.MethodPut:
+		if err := v.editable(svc.id); err != nil {
+			return nil, err
+		}
+		form, err := parseForm(r)
+		if err != nil {
+			return nil, err
+		}
+		if newName := str(form["name"]); newName != "" && newName != name {
+			if _, ok := c.get(newName); ok {
+				return nil, conflict("Duplicate record", fmt.Sprintf("A %s named %s already exists in version %d", kind, newName, v.number))
+			}
+			c.rename(name, newName)
+		}
+		for k, val := range form {
+			o[k] = val
+		}
+		o["updated_at"] = timestamp(s.tick())
+		v.updatedAt = s.clock
+		return renderResource(o, svc, v), nil
+	case http.MethodDelete:
+		if err := v.editable(svc.id); err != nil {
+			return nil, err
+		}
+		c.remove(name)
+		v.updatedAt = s.tick()
+		return statusOK(), nil
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) routePackage(r *http.Request, svc *service, v *version) (interface{}, error) {
+	switch r.Method {
+	case http.MethodGet:
+		if v.pkg == nil {
+			return nil, notFound("Record not found", fmt.Sprintf("No package uploaded to version %d of service %s", v.number, svc.id))
+		}
+		return renderResource(v.pkg, svc, v), nil
+	case http.MethodPut:
+		if err := v.editable(svc.id); err != nil {
+			return nil, err
+		}
+		f, header, err := r.FormFile("package")
+		if err != nil {
+			return nil, badRequest(fmt.Sprintf("Missing package: %v", err))
+		}
+		defer f.Close()
+		data, err := ioutil.ReadAll(f)
+		if err != nil {
+			return nil, badRequest(fmt.Sprintf("Error reading package: %v", err))
+		}
+
+		now := timestamp(s.tick())
+		v.pkg = object{
+			"id": s.nextID("package"),
+			"metadata": object{
+				"name":    strings.TrimSuffix(header.Filename, ".tar.gz"),
+				"size":    len(data),
+				"hashsum": fmt.Sprintf("%x", sha512.Sum512(data)),
+			},
+			"created_at": now,
+			"updated_at": now,
+		}
+		v.updatedAt = s.clock
+		return renderResource(v.pkg, svc, v), nil
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) routeDictionaryItems(r *http.Request, svc *service, dictionaryID string, seg []string) (interface{}, error) {
+	items, ok := s.items[dictionaryID]
+	if !ok {
+		items = newCollection()
+		s.items[dictionaryID] = items
+	}
+	render := func(o object) object {
+		o = o.copy()
+		o["service_id"], o["dictionary_id"] = svc.id, dictionaryID
+		return o
+	}
+
+	switch {
+	case match(seg, "items") && r.Method == http.MethodGet:
+		list := make([]object, 0, len(items.names))
+		for _, o := range items.list() {
+			list = append(list, render(o))
+		}
+		return list, nil
+	case match(seg, "items") && r.Method == http.MethodPatch:
+		var batch struct {
+			Items []struct {
+				Op        string `json:"op"`
+				ItemKey   string `json:"item_key"`
+				ItemValue string `json:"item_value"`
+			} `json:"items"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			return nil, badRequest(fmt.Sprintf("Invalid JSON: %v", err))
+		}
+		// Validate the whole batch before applying any of it.
+		for _, item := range batch.Items {
+			_, exists := items.get(item.ItemKey)
+			switch {
+			case item.Op == "create" && exists:
+				return nil, conflict("Duplicate record", fmt.Sprintf("Item %s already exists", item.ItemKey))
+			case (item.Op == "update" || item.Op == "delete") && !exists:
+				return nil, notFound("Record not found", fmt.Sprintf("Couldn't find item %s", item.ItemKey))
+			case item.Op != "create" && item.Op != "update" && item.Op != "upsert" && item.Op != "delete":
+				return nil, badRequest(fmt.Sprintf("Invalid operation %s", item.Op))
+			}
+		}
+		for _, item := range batch.Items {
+			if item.Op == "delete" {
+				items.remove(item.ItemKey)
+				continue
+			}
+			s.putItem(items, item.ItemKey, item.ItemValue)
+		}
+		return statusOK(), nil
+	case match(seg, "item") && r.Method == http.MethodPost:
+		form, err := parseForm(r)
+		if err != nil {
+			return nil, err
+		}
+		key := str(form["item_key"])
+		if key == "" {
+			return nil, badRequest("Item key is required")
+		}
+		if _, ok := items.get(key); ok {
+			return nil, conflict("Duplicate record", fmt.Sprintf("Item %s already exists", key))
+		}
+		return render(s.putItem(items, key, str(form["item_value"]))), nil
+	case len(seg) == 2 && seg[0] == "item":
+		key := seg[1]
+		o, ok := items.get(key)
+		if !ok {
+			return nil, notFound("Record not found", fmt.Sprintf("Couldn't find item %s", key))
+		}
+		switch r.Method {
+		case http.MethodGet:
+			return render(o), nil
+		case http.MethodPut:
+			form, err := parseForm(r)
+			if err != nil {
+				return nil, err
+			}
+			return render(s.putItem(items, key, str(form["item_value"]))), nil
+		case http.MethodDelete:
+			items.remove(key)
+			return statusOK(), nil
+		}
+	}
+	return nil, methodNotAllowed(r)
+}
+
+func (s *Server) putItem(items *collection, key, value string) object {
+	now := timestamp(s.tick())
+	o, ok := items.get(key)
+	if !ok {
+		o = object{"item_key": key, "created_at": now}
+		items.put(key, o)
+	}
+	o["item_value"], o["updated_at"] = value, now
+	return o
+}
+
+func (s *Server) verify() object {
+	services := map[string]string{}
+	for _, svc := range s.services {
+		services[svc.id] = svc.name
+	}
+	return object{
+		"customer": object{"id": CustomerID, "name": "Fake Customer"},
+		"user":     object{"id": UserID, "name": UserName, "login": UserLogin},
+		"services": services,
+		"token": object{
+			"id":         TokenID,
+			"name":       "Fake token",
+			"created_at": timestamp(epoch),
+			"scope":      "global",
+		},
+	}
+}
+
+func (s *Server) token() object {
+	return object{
+		"id":          TokenID,
+		"name":        "Fake token",
+		"user_id":     UserID,
+		"customer_id": CustomerID,
+		"services":    []string{},
+		"scope":       "global",
+		"created_at":  timestamp(epoch),
+	}
+}
+
+func (s *Server) user() object {
+	return object{
+		"id":          UserID,
+		"login":       UserLogin,
+		"name":        UserName,
+		"role":        "superuser",
+		"customer_id": CustomerID,
+		"created_at":  timestamp(epoch),
+	}
+}
+
+func (s *Server) service(id string) (*service, error) {
+	for _, svc := range s.services {
+		if svc.id == id {
+			return svc, nil
+		}
+	}
+	return nil, notFound("Record not found", fmt.Sprintf("Couldn't find service %s", id))
+}
+
+func (s *Server) searchService(name string) (interface{}, error) {
+	for _, svc := range s.services {
+		if svc.name == name {
+			return svc.render(), nil
+		}
+	}
+	// The real API responds with a 400 rather than a 404.
+	return nil, badRequest(fmt.Sprintf("Couldn't find service named %s", name))
+}
+
+func (s *Server) addService(name, serviceType, comment string) *service {
+	if serviceType == "" {
+		serviceType = "vcl"
+	}
+	now := s.tick()
+	svc := &service{
+		id:        s.nextID("service"),
+		name:      name,
+		kind:      serviceType,
+		comment:   comment,
+		createdAt: now,
+		updatedAt: now,
+	}
+	s.addVersion(svc, "")
+	s.services = append(s.services, svc)
+	return svc
+}
+
+func (s *Server) addVersion(svc *service, comment string) *version {
+	now := s.tick()
+	v := &version{
+		number:    len(svc.versions) + 1,
+		comment:   comment,
+		createdAt: now,
+		updatedAt: now,
+		resources: map[string]*collection{},
+	}
+	svc.versions = append(svc.versions, v)
+	return v
+}
+
+// tick advances the fake clock, and returns the new time.
+func (s *Server) tick() time.Time {
+	s.clock = s.clock.Add(time.Second)
+	return s.clock
+}
+
+// nextID returns a new, predictable ID for a resource of the given kind, e.g.
+// "service000001" for the first service.
+func (s *Server) nextID(kind string) string {
+	s.ids[kind]++
+	return fmt.Sprintf("%s%06d", kind, s.ids[kind])
+}
+
+// renderResource renders a version's resource, which always includes the
+// service ID and version number it belongs to.
+func renderResource(o object, svc *service, v *version) object {
+	o = o.copy()
+	o["service_id"], o["version"] = svc.id, v.number
+	return o
+}
+
+// parseForm parses a form encoded request body into an object. go-fastly
+// encodes fields without form tags, such as the ServiceID, under their Go
+// names, which aren't part of the API, so they're dropped.
+func parseForm(r *http.Request) (object, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, badRequest(fmt.Sprintf("Invalid form: %v", err))
+	}
+	o := object{}
+	for k, v := range r.PostForm {
+		if k == "" || unicode.IsUpper(rune(k[0])) || len(v) == 0 {
+			continue
+		}
+		o[k] = v[0]
+	}
+	return o, nil
+}
+
+func match(seg []string, want ...string) bool {
+	if len(seg) != len(want) {
+		return false
+	}
+	for i := range want {
+		if seg[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func str(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func statusOK() object {
+	return object{"status": "ok"}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body) // objects of scalars can't fail to encode
+}
+
+// apiError is an error response in the format of the legacy Fastly API, which
+// go-fastly decodes into a fastly.HTTPError.
+type apiError struct {
+	status int
+	msg    string
+	detail string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.status, e.msg, e.detail)
+}
+
+func badRequest(detail string) error {
+	return &apiError{status: http.StatusBadRequest, msg: "Bad request", detail: detail}
+}
+
+func notFound(msg, detail string) error {
+	return &apiError{status: http.StatusNotFound, msg: msg, detail: detail}
+}
+
+func conflict(msg, detail string) error {
+	return &apiError{status: http.StatusConflict, msg: msg, detail: detail}
+}
+
+func methodNotAllowed(r *http.Request) error {
+	return &apiError{status: http.StatusMethodNotAllowed, msg: "Method not allowed", detail: fmt.Sprintf("%s %s isn't supported by the fake API", r.Method, r.URL.Path)}
+}
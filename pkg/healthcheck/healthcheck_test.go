@@ -1,256 +1,141 @@
 package healthcheck_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestHealthCheckCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"healthcheck", "create", "--version", "1", "--service-id", "123"},
-			api:       mock.API{CreateHealthCheckFn: createHealthCheckOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"healthcheck", "create", "--version", "1", "--service-id", "123"},
+			API:  mock.API{CreateHealthCheckFn: createHealthCheckOK},
 		},
 		{
-			args:      []string{"healthcheck", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{CreateHealthCheckFn: createHealthCheckError},
-			wantError: errTest.Error(),
+			Args: []string{"healthcheck", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{CreateHealthCheckFn: createHealthCheckError},
 		},
 		{
-			args:       []string{"healthcheck", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{CreateHealthCheckFn: createHealthCheckOK},
-			wantOutput: "Created healthcheck www.test.com (service 123 version 1)",
+			Args: []string{"healthcheck", "create", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{CreateHealthCheckFn: createHealthCheckOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHealthCheckList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"healthcheck", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHealthChecksFn: listHealthChecksOK},
-			wantOutput: listHealthChecksShortOutput,
+			Args: []string{"healthcheck", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHealthChecksFn: listHealthChecksOK},
 		},
 		{
-			args:       []string{"healthcheck", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListHealthChecksFn: listHealthChecksOK},
-			wantOutput: listHealthChecksVerboseOutput,
+			Args: []string{"healthcheck", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListHealthChecksFn: listHealthChecksOK},
 		},
 		{
-			args:       []string{"healthcheck", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListHealthChecksFn: listHealthChecksOK},
-			wantOutput: listHealthChecksVerboseOutput,
+			Args: []string{"healthcheck", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListHealthChecksFn: listHealthChecksOK},
 		},
 		{
-			args:       []string{"healthcheck", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHealthChecksFn: listHealthChecksOK},
-			wantOutput: listHealthChecksVerboseOutput,
+			Args: []string{"healthcheck", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHealthChecksFn: listHealthChecksOK},
 		},
 		{
-			args:       []string{"-v", "healthcheck", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHealthChecksFn: listHealthChecksOK},
-			wantOutput: listHealthChecksVerboseOutput,
+			Args: []string{"-v", "healthcheck", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHealthChecksFn: listHealthChecksOK},
 		},
 		{
-			args:      []string{"healthcheck", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListHealthChecksFn: listHealthChecksError},
-			wantError: errTest.Error(),
+			Args: []string{"healthcheck", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHealthChecksFn: listHealthChecksError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHealthCheckDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"healthcheck", "describe", "--service-id", "123", "--version", "1"},
-			api:       mock.API{GetHealthCheckFn: getHealthCheckOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"healthcheck", "describe", "--service-id", "123", "--version", "1"},
+			API:  mock.API{GetHealthCheckFn: getHealthCheckOK},
 		},
 		{
-			args:      []string{"healthcheck", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{GetHealthCheckFn: getHealthCheckError},
-			wantError: errTest.Error(),
+			Args: []string{"healthcheck", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{GetHealthCheckFn: getHealthCheckError},
 		},
 		{
-			args:       []string{"healthcheck", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{GetHealthCheckFn: getHealthCheckOK},
-			wantOutput: describeHealthCheckOutput,
+			Args: []string{"healthcheck", "describe", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{GetHealthCheckFn: getHealthCheckOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHealthCheckUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"healthcheck", "update", "--service-id", "123", "--version", "2", "--new-name", "www.test.com", "--comment", ""},
-			api:       mock.API{UpdateHealthCheckFn: updateHealthCheckOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"healthcheck", "update", "--service-id", "123", "--version", "2", "--new-name", "www.test.com", "--comment", ""},
+			API:  mock.API{UpdateHealthCheckFn: updateHealthCheckOK},
 		},
 		{
-			args: []string{"healthcheck", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"healthcheck", "update", "--service-id", "123", "--version", "2", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetHealthCheckFn:    getHealthCheckError,
 				UpdateHealthCheckFn: updateHealthCheckOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"healthcheck", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"healthcheck", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetHealthCheckFn:    getHealthCheckError,
 				UpdateHealthCheckFn: updateHealthCheckError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"healthcheck", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
-			api: mock.API{
+			Args: []string{"healthcheck", "update", "--service-id", "123", "--version", "1", "--name", "www.test.com", "--new-name", "www.example.com"},
+			API: mock.API{
 				GetHealthCheckFn:    getHealthCheckOK,
 				UpdateHealthCheckFn: updateHealthCheckOK,
 			},
-			wantOutput: "Updated healthcheck www.example.com (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHealthCheckDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"healthcheck", "delete", "--service-id", "123", "--version", "1"},
-			api:       mock.API{DeleteHealthCheckFn: deleteHealthCheckOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"healthcheck", "delete", "--service-id", "123", "--version", "1"},
+			API:  mock.API{DeleteHealthCheckFn: deleteHealthCheckOK},
 		},
 		{
-			args:      []string{"healthcheck", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:       mock.API{DeleteHealthCheckFn: deleteHealthCheckError},
-			wantError: errTest.Error(),
+			Args: []string{"healthcheck", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{DeleteHealthCheckFn: deleteHealthCheckError},
 		},
 		{
-			args:       []string{"healthcheck", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
-			api:        mock.API{DeleteHealthCheckFn: deleteHealthCheckOK},
-			wantOutput: "Deleted healthcheck www.test.com (service 123 version 1)",
+			Args: []string{"healthcheck", "delete", "--service-id", "123", "--version", "1", "--name", "www.test.com"},
+			API:  mock.API{DeleteHealthCheckFn: deleteHealthCheckOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -299,45 +184,6 @@ func listHealthChecksError(i *fastly.ListHealthChecksInput) ([]*fastly.HealthChe
 	return nil, errTest
 }
 
-var listHealthChecksShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME     METHOD  HOST             PATH
-123      1        test     HEAD    www.test.com     /health
-123      1        example  HEAD    www.example.com  /health
-`) + "\n"
-
-var listHealthChecksVerboseOutput = strings.Join([]string{
-	"Fastly API token not provided",
-	"Fastly API endpoint: https://api.fastly.com",
-	"Service ID: 123",
-	"Version: 1",
-	"	Healthcheck 1/2",
-	"		Name: test",
-	"		Comment: test",
-	"		Method: HEAD",
-	"		Host: www.test.com",
-	"		Path: /health",
-	"		HTTP version: ",
-	"		Timeout: 0",
-	"		Check interval: 0",
-	"		Expected response: 0",
-	"		Window: 0",
-	"		Threshold: 0",
-	"		Initial: 0",
-	"	Healthcheck 2/2",
-	"		Name: example",
-	"		Comment: example",
-	"		Method: HEAD",
-	"		Host: www.example.com",
-	"		Path: /health",
-	"		HTTP version: ",
-	"		Timeout: 0",
-	"		Check interval: 0",
-	"		Expected response: 0",
-	"		Window: 0",
-	"		Threshold: 0",
-	"		Initial: 0",
-}, "\n") + "\n\n"
-
 func getHealthCheckOK(i *fastly.GetHealthCheckInput) (*fastly.HealthCheck, error) {
 	return &fastly.HealthCheck{
 		ServiceID:      i.ServiceID,
@@ -354,23 +200,6 @@ func getHealthCheckError(i *fastly.GetHealthCheckInput) (*fastly.HealthCheck, er
 	return nil, errTest
 }
 
-var describeHealthCheckOutput = strings.Join([]string{
-	"Service ID: 123",
-	"Version: 1",
-	"Name: test",
-	"Comment: test",
-	"Method: HEAD",
-	"Host: www.test.com",
-	"Path: /healthcheck",
-	"HTTP version: ",
-	"Timeout: 0",
-	"Check interval: 0",
-	"Expected response: 0",
-	"Window: 0",
-	"Threshold: 0",
-	"Initial: 0",
-}, "\n") + "\n"
-
 func updateHealthCheckOK(i *fastly.UpdateHealthCheckInput) (*fastly.HealthCheck, error) {
 	return &fastly.HealthCheck{
 		ServiceID:      i.ServiceID,
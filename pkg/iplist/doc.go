@@ -0,0 +1,3 @@
+// Package iplist contains a command to export the published IP ranges of the
+// Fastly network, for allowlisting at origins.
+package iplist
@@ -0,0 +1,113 @@
+package iplist
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// formats maps the values of --format to the line written for each range.
+var formats = map[string]string{
+	"cidr":     "%s",
+	"nginx":    "allow %s;",
+	"iptables": "-A INPUT -s %s -j ACCEPT",
+}
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	common.Base
+	ipv6   bool
+	format string
+	diff   common.OptionalString
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent common.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("ip-list", "List the IP ranges of the Fastly network, for allowlisting at origins")
+	c.CmdClause.Flag("ipv6", "List IPv6 ranges, rather than IPv4 ranges").BoolVar(&c.ipv6)
+	c.CmdClause.Flag("format", "Output format (cidr, nginx, iptables)").Default("cidr").EnumVar(&c.format, "cidr", "nginx", "iptables")
+	c.CmdClause.Flag("diff", "Only list ranges added or removed since this earlier export, in any format").Action(c.diff.Set).StringVar(&c.diff.Value)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	list := c.Globals.Client.IPs
+	if c.ipv6 {
+		list = c.Globals.Client.IPsV6
+	}
+	ranges, err := list()
+	if err != nil {
+		return fmt.Errorf("error listing IP ranges: %w", err)
+	}
+
+	if !c.diff.WasSet {
+		for _, r := range ranges {
+			fmt.Fprintf(out, formats[c.format]+"\n", r)
+		}
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(c.diff.Value)
+	if err != nil {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("error reading --diff: %w", err),
+			Remediation: "Provide the path to a file written by an earlier ip-list.",
+		}
+	}
+	previous := parseRanges(string(data), c.ipv6)
+
+	current := make(map[string]bool, len(ranges))
+	for _, r := range ranges {
+		current[r] = true
+	}
+	var changes int
+	for _, r := range ranges {
+		if !previous.has[r] {
+			fmt.Fprintf(out, "+ "+formats[c.format]+"\n", r)
+			changes++
+		}
+	}
+	for _, r := range previous.ranges {
+		if !current[r] {
+			fmt.Fprintf(out, "- "+formats[c.format]+"\n", r)
+			changes++
+		}
+	}
+	if changes == 0 {
+		text.Info(out, "No ranges were added or removed since the earlier export")
+	}
+	return nil
+}
+
+// ranges is a list of IP ranges, in order, without duplicates.
+type ranges struct {
+	ranges []string
+	has    map[string]bool
+}
+
+// parseRanges returns the ranges of one IP version in an export, in any
+// format, as those are the only words which parse as CIDR notation.
+func parseRanges(s string, ipv6 bool) ranges {
+	rs := ranges{has: make(map[string]bool)}
+	for _, word := range strings.Fields(s) {
+		word = strings.TrimSuffix(word, ";")
+		ip, _, err := net.ParseCIDR(word)
+		if err != nil || (ip.To4() == nil) != ipv6 || rs.has[word] {
+			continue
+		}
+		rs.ranges = append(rs.ranges, word)
+		rs.has[word] = true
+	}
+	return rs
+}
@@ -0,0 +1,107 @@
+package iplist_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestIPList(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"ip-list"},
+			API:  mock.API{IPsFn: ipsOK},
+		},
+		{
+			Args: []string{"ip-list", "--format", "nginx"},
+			API:  mock.API{IPsFn: ipsOK},
+		},
+		{
+			Args: []string{"ip-list", "--format", "iptables"},
+			API:  mock.API{IPsFn: ipsOK},
+		},
+		{
+			Args: []string{"ip-list", "--ipv6"},
+			API:  mock.API{IPsV6Fn: ipsV6OK},
+		},
+		{
+			Args: []string{"ip-list", "--ipv6", "--format", "cidr"},
+			API:  mock.API{IPsV6Fn: ipsError},
+		},
+		{
+			Args: []string{"ip-list", "--format", "apache"},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+// TestIPListDiff compares the ranges with earlier exports, which are
+// temporary files, so subtests are named rather than derived from arguments.
+func TestIPListDiff(t *testing.T) {
+	for _, testcase := range []struct {
+		name   string
+		args   []string
+		export string
+	}{
+		{
+			name:   "cidr",
+			args:   []string{"ip-list"},
+			export: "23.235.32.0/20\n43.249.72.0/22\n104.156.80.0/20\n",
+		},
+		{
+			name:   "nginx",
+			args:   []string{"ip-list", "--format", "nginx"},
+			export: "allow 23.235.32.0/20;\nallow 103.244.50.0/24;\nallow 104.156.80.0/20;\n",
+		},
+		{
+			name:   "iptables with both versions",
+			args:   []string{"ip-list", "--format", "iptables"},
+			export: "-A INPUT -s 23.235.32.0/20 -j ACCEPT\n-A INPUT -s 43.249.72.0/22 -j ACCEPT\n-A INPUT -s 103.244.50.0/24 -j ACCEPT\n-A INPUT -s 2a04:4e40::/32 -j ACCEPT\n",
+		},
+		{
+			name:   "ipv6",
+			args:   []string{"ip-list", "--ipv6"},
+			export: "23.235.32.0/20\n2a04:4e40::/32\n2a04:4e42::/32\n",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			path := testutil.MakeTempFile(t, testcase.export)
+			defer os.RemoveAll(path)
+
+			golden.Run(t, golden.Scenario{
+				Args: append(testcase.args, "--diff", path),
+				API:  mock.API{IPsFn: ipsOK, IPsV6Fn: ipsV6OK},
+			})
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		golden.Run(t, golden.Scenario{
+			Args: []string{"ip-list", "--diff", "/nonexistent/ips.txt"},
+			API:  mock.API{IPsFn: ipsOK},
+		})
+	})
+}
+
+func ipsOK() (fastly.IPAddrs, error) {
+	return fastly.IPAddrs{"23.235.32.0/20", "43.249.72.0/22", "103.244.50.0/24"}, nil
+}
+
+func ipsV6OK() (fastly.IPAddrs, error) {
+	return fastly.IPAddrs{"2a04:4e40::/32", "2a04:4e42::/32"}, nil
+}
+
+func ipsError() (fastly.IPAddrs, error) {
+	return nil, errTest
+}
+
+var errTest = errors.New("fixture error")
@@ -1,260 +1,143 @@
 package azureblob_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestBlobStorageCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--sas-token", "abc"},
-			wantError: "error parsing arguments: required flag --container not provided",
+			Args: []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--sas-token", "abc"},
 		},
 		{
-			args:      []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--container", "log", "--sas-token", "abc"},
-			wantError: "error parsing arguments: required flag --account-name not provided",
+			Args: []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--container", "log", "--sas-token", "abc"},
 		},
 		{
-			args:      []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--container", "log"},
-			wantError: "error parsing arguments: required flag --sas-token not provided",
+			Args: []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--container", "log"},
 		},
 		{
-			args:       []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--container", "log", "--sas-token", "abc"},
-			api:        mock.API{CreateBlobStorageFn: createBlobStorageOK},
-			wantOutput: "Created Azure Blob Storage logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--container", "log", "--sas-token", "abc"},
+			API:  mock.API{CreateBlobStorageFn: createBlobStorageOK},
 		},
 		{
-			args:      []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--container", "log", "--sas-token", "abc"},
-			api:       mock.API{CreateBlobStorageFn: createBlobStorageError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "azureblob", "create", "--service-id", "123", "--version", "1", "--name", "log", "--account-name", "account", "--container", "log", "--sas-token", "abc"},
+			API:  mock.API{CreateBlobStorageFn: createBlobStorageError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBlobStorageList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListBlobStoragesFn: listBlobStoragesOK},
-			wantOutput: listBlobStoragesShortOutput,
+			Args: []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBlobStoragesFn: listBlobStoragesOK},
 		},
 		{
-			args:       []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListBlobStoragesFn: listBlobStoragesOK},
-			wantOutput: listBlobStoragesVerboseOutput,
+			Args: []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListBlobStoragesFn: listBlobStoragesOK},
 		},
 		{
-			args:       []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListBlobStoragesFn: listBlobStoragesOK},
-			wantOutput: listBlobStoragesVerboseOutput,
+			Args: []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListBlobStoragesFn: listBlobStoragesOK},
 		},
 		{
-			args:       []string{"logging", "azureblob", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListBlobStoragesFn: listBlobStoragesOK},
-			wantOutput: listBlobStoragesVerboseOutput,
+			Args: []string{"logging", "azureblob", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBlobStoragesFn: listBlobStoragesOK},
 		},
 		{
-			args:       []string{"logging", "-v", "azureblob", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListBlobStoragesFn: listBlobStoragesOK},
-			wantOutput: listBlobStoragesVerboseOutput,
+			Args: []string{"logging", "-v", "azureblob", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBlobStoragesFn: listBlobStoragesOK},
 		},
 		{
-			args:      []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListBlobStoragesFn: listBlobStoragesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "azureblob", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListBlobStoragesFn: listBlobStoragesError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBlobStorageDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "azureblob", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "azureblob", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "azureblob", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetBlobStorageFn: getBlobStorageError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "azureblob", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetBlobStorageFn: getBlobStorageError},
 		},
 		{
-			args:       []string{"logging", "azureblob", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetBlobStorageFn: getBlobStorageOK},
-			wantOutput: describeBlobStorageOutput,
+			Args: []string{"logging", "azureblob", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetBlobStorageFn: getBlobStorageOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBlobStorageUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetBlobStorageFn:    getBlobStorageError,
 				UpdateBlobStorageFn: updateBlobStorageOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetBlobStorageFn:    getBlobStorageOK,
 				UpdateBlobStorageFn: updateBlobStorageError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "azureblob", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetBlobStorageFn:    getBlobStorageOK,
 				UpdateBlobStorageFn: updateBlobStorageOK,
 			},
-			wantOutput: "Updated Azure Blob Storage logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestBlobStorageDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "azureblob", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "azureblob", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "azureblob", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteBlobStorageFn: deleteBlobStorageError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "azureblob", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteBlobStorageFn: deleteBlobStorageError},
 		},
 		{
-			args:       []string{"logging", "azureblob", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteBlobStorageFn: deleteBlobStorageOK},
-			wantOutput: "Deleted Azure Blob Storage logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "azureblob", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteBlobStorageFn: deleteBlobStorageOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -333,53 +216,6 @@ func listBlobStoragesError(i *fastly.ListBlobStoragesInput) ([]*fastly.BlobStora
 	return nil, errTest
 }
 
-var listBlobStoragesShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listBlobStoragesVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	BlobStorage 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Container: container
-		Account name: account
-		SAS token: token
-		Path: /logs
-		Period: 3600
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-		Public key: `+pgpPublicKey()+`
-	BlobStorage 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Container: analytics
-		Account name: account
-		SAS token: token
-		Path: /logs
-		Period: 86400
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-		Public key: `+pgpPublicKey()+`
-`) + "\n\n"
-
 func getBlobStorageOK(i *fastly.GetBlobStorageInput) (*fastly.BlobStorage, error) {
 	return &fastly.BlobStorage{
 		ServiceID:         i.ServiceID,
@@ -405,25 +241,6 @@ func getBlobStorageError(i *fastly.GetBlobStorageInput) (*fastly.BlobStorage, er
 	return nil, errTest
 }
 
-var describeBlobStorageOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Container: container
-Account name: account
-SAS token: token
-Path: /logs
-Period: 3600
-GZip level: 9
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Message type: classic
-Timestamp format: %Y-%m-%dT%H:%M:%S.000
-Placement: none
-Public key: `+pgpPublicKey()+`
-`) + "\n"
-
 func updateBlobStorageOK(i *fastly.UpdateBlobStorageInput) (*fastly.BlobStorage, error) {
 	return &fastly.BlobStorage{
 		ServiceID:         i.ServiceID,
@@ -1,260 +1,143 @@
 package cloudfiles_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestCloudfilesCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo"},
-			wantError: "error parsing arguments: required flag --user not provided",
+			Args: []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo"},
 		},
 		{
-			args:      []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--access-key", "foo"},
-			wantError: "error parsing arguments: required flag --bucket not provided",
+			Args: []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--access-key", "foo"},
 		},
 		{
-			args:      []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--bucket", "log"},
-			wantError: "error parsing arguments: required flag --access-key not provided",
+			Args: []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--bucket", "log"},
 		},
 		{
-			args:       []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--bucket", "log", "--access-key", "foo"},
-			api:        mock.API{CreateCloudfilesFn: createCloudfilesOK},
-			wantOutput: "Created Cloudfiles logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--bucket", "log", "--access-key", "foo"},
+			API:  mock.API{CreateCloudfilesFn: createCloudfilesOK},
 		},
 		{
-			args:      []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--bucket", "log", "--access-key", "foo"},
-			api:       mock.API{CreateCloudfilesFn: createCloudfilesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "cloudfiles", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "username", "--bucket", "log", "--access-key", "foo"},
+			API:  mock.API{CreateCloudfilesFn: createCloudfilesError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestCloudfilesList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListCloudfilesFn: listCloudfilesOK},
-			wantOutput: listCloudfilesShortOutput,
+			Args: []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListCloudfilesFn: listCloudfilesOK},
 		},
 		{
-			args:       []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListCloudfilesFn: listCloudfilesOK},
-			wantOutput: listCloudfilesVerboseOutput,
+			Args: []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListCloudfilesFn: listCloudfilesOK},
 		},
 		{
-			args:       []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListCloudfilesFn: listCloudfilesOK},
-			wantOutput: listCloudfilesVerboseOutput,
+			Args: []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListCloudfilesFn: listCloudfilesOK},
 		},
 		{
-			args:       []string{"logging", "cloudfiles", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListCloudfilesFn: listCloudfilesOK},
-			wantOutput: listCloudfilesVerboseOutput,
+			Args: []string{"logging", "cloudfiles", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListCloudfilesFn: listCloudfilesOK},
 		},
 		{
-			args:       []string{"logging", "-v", "cloudfiles", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListCloudfilesFn: listCloudfilesOK},
-			wantOutput: listCloudfilesVerboseOutput,
+			Args: []string{"logging", "-v", "cloudfiles", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListCloudfilesFn: listCloudfilesOK},
 		},
 		{
-			args:      []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListCloudfilesFn: listCloudfilesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "cloudfiles", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListCloudfilesFn: listCloudfilesError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestCloudfilesDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "cloudfiles", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "cloudfiles", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "cloudfiles", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetCloudfilesFn: getCloudfilesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "cloudfiles", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetCloudfilesFn: getCloudfilesError},
 		},
 		{
-			args:       []string{"logging", "cloudfiles", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetCloudfilesFn: getCloudfilesOK},
-			wantOutput: describeCloudfilesOutput,
+			Args: []string{"logging", "cloudfiles", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetCloudfilesFn: getCloudfilesOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestCloudfilesUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetCloudfilesFn:    getCloudfilesError,
 				UpdateCloudfilesFn: updateCloudfilesOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetCloudfilesFn:    getCloudfilesOK,
 				UpdateCloudfilesFn: updateCloudfilesError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "cloudfiles", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetCloudfilesFn:    getCloudfilesOK,
 				UpdateCloudfilesFn: updateCloudfilesOK,
 			},
-			wantOutput: "Updated Cloudfiles logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestCloudfilesDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "cloudfiles", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "cloudfiles", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "cloudfiles", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteCloudfilesFn: deleteCloudfilesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "cloudfiles", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteCloudfilesFn: deleteCloudfilesError},
 		},
 		{
-			args:       []string{"logging", "cloudfiles", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteCloudfilesFn: deleteCloudfilesOK},
-			wantOutput: "Deleted Cloudfiles logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "cloudfiles", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteCloudfilesFn: deleteCloudfilesOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -325,55 +208,6 @@ func listCloudfilesError(i *fastly.ListCloudfilesInput) ([]*fastly.Cloudfiles, e
 	return nil, errTest
 }
 
-var listCloudfilesShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listCloudfilesVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Cloudfiles 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		User: username
-		Access key: 1234
-		Bucket: my-logs
-		Path: logs/
-		Region: ORD
-		Placement: none
-		Period: 3600
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Public key: `+pgpPublicKey()+`
-	Cloudfiles 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		User: username
-		Access key: 1234
-		Bucket: analytics
-		Path: logs/
-		Region: ORD
-		Placement: none
-		Period: 86400
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Public key: `+pgpPublicKey()+`
-`) + "\n\n"
-
 func getCloudfilesOK(i *fastly.GetCloudfilesInput) (*fastly.Cloudfiles, error) {
 	return &fastly.Cloudfiles{
 		ServiceID:         i.ServiceID,
@@ -400,26 +234,6 @@ func getCloudfilesError(i *fastly.GetCloudfilesInput) (*fastly.Cloudfiles, error
 	return nil, errTest
 }
 
-var describeCloudfilesOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-User: username
-Access key: 1234
-Bucket: my-logs
-Path: logs/
-Region: ORD
-Placement: none
-Period: 3600
-GZip level: 9
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Message type: classic
-Timestamp format: %Y-%m-%dT%H:%M:%S.000
-Public key: `+pgpPublicKey()+`
-`) + "\n"
-
 func updateCloudfilesOK(i *fastly.UpdateCloudfilesInput) (*fastly.Cloudfiles, error) {
 	return &fastly.Cloudfiles{
 		ServiceID:         i.ServiceID,
@@ -1,252 +1,137 @@
 package datadog_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestDatadogCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "datadog", "create", "--service-id", "123", "--version", "1", "--name", "log"},
-			wantError: "error parsing arguments: required flag --auth-token not provided",
+			Args: []string{"logging", "datadog", "create", "--service-id", "123", "--version", "1", "--name", "log"},
 		},
 		{
-			args:       []string{"logging", "datadog", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			api:        mock.API{CreateDatadogFn: createDatadogOK},
-			wantOutput: "Created Datadog logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "datadog", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
+			API:  mock.API{CreateDatadogFn: createDatadogOK},
 		},
 		{
-			args:      []string{"logging", "datadog", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			api:       mock.API{CreateDatadogFn: createDatadogError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "datadog", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
+			API:  mock.API{CreateDatadogFn: createDatadogError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDatadogList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListDatadogFn: listDatadogsOK},
-			wantOutput: listDatadogsShortOutput,
+			Args: []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDatadogFn: listDatadogsOK},
 		},
 		{
-			args:       []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListDatadogFn: listDatadogsOK},
-			wantOutput: listDatadogsVerboseOutput,
+			Args: []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListDatadogFn: listDatadogsOK},
 		},
 		{
-			args:       []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListDatadogFn: listDatadogsOK},
-			wantOutput: listDatadogsVerboseOutput,
+			Args: []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListDatadogFn: listDatadogsOK},
 		},
 		{
-			args:       []string{"logging", "datadog", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListDatadogFn: listDatadogsOK},
-			wantOutput: listDatadogsVerboseOutput,
+			Args: []string{"logging", "datadog", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDatadogFn: listDatadogsOK},
 		},
 		{
-			args:       []string{"logging", "-v", "datadog", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListDatadogFn: listDatadogsOK},
-			wantOutput: listDatadogsVerboseOutput,
+			Args: []string{"logging", "-v", "datadog", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDatadogFn: listDatadogsOK},
 		},
 		{
-			args:      []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListDatadogFn: listDatadogsError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "datadog", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListDatadogFn: listDatadogsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDatadogDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "datadog", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "datadog", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "datadog", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetDatadogFn: getDatadogError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "datadog", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetDatadogFn: getDatadogError},
 		},
 		{
-			args:       []string{"logging", "datadog", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetDatadogFn: getDatadogOK},
-			wantOutput: describeDatadogOutput,
+			Args: []string{"logging", "datadog", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetDatadogFn: getDatadogOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDatadogUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetDatadogFn:    getDatadogError,
 				UpdateDatadogFn: updateDatadogOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetDatadogFn:    getDatadogOK,
 				UpdateDatadogFn: updateDatadogError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "datadog", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetDatadogFn:    getDatadogOK,
 				UpdateDatadogFn: updateDatadogOK,
 			},
-			wantOutput: "Updated Datadog logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestDatadogDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "datadog", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "datadog", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "datadog", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteDatadogFn: deleteDatadogError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "datadog", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteDatadogFn: deleteDatadogError},
 		},
 		{
-			args:       []string{"logging", "datadog", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteDatadogFn: deleteDatadogOK},
-			wantOutput: "Deleted Datadog logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "datadog", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteDatadogFn: deleteDatadogOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -301,39 +186,6 @@ func listDatadogsError(i *fastly.ListDatadogInput) ([]*fastly.Datadog, error) {
 	return nil, errTest
 }
 
-var listDatadogsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listDatadogsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Datadog 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Token: abc
-		Region: US
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-	Datadog 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Token: abc
-		Region: US
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-`) + "\n\n"
-
 func getDatadogOK(i *fastly.GetDatadogInput) (*fastly.Datadog, error) {
 	return &fastly.Datadog{
 		ServiceID:         i.ServiceID,
@@ -352,18 +204,6 @@ func getDatadogError(i *fastly.GetDatadogInput) (*fastly.Datadog, error) {
 	return nil, errTest
 }
 
-var describeDatadogOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Token: abc
-Region: US
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Placement: none
-`) + "\n"
-
 func updateDatadogOK(i *fastly.UpdateDatadogInput) (*fastly.Datadog, error) {
 	return &fastly.Datadog{
 		ServiceID:         i.ServiceID,
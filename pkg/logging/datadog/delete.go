@@ -1,6 +1,7 @@
 package datadog
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/common"
@@ -38,6 +39,11 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 	c.Input.ServiceID = serviceID
 
+	prompt := fmt.Sprintf("Delete Datadog logging endpoint %s (service %s version %d)?", c.Input.Name, c.Input.ServiceID, c.Input.ServiceVersion)
+	if err := text.Confirm(out, in, c.Globals.AutoYes(), prompt, c.Input.Name); err != nil {
+		return err
+	}
+
 	if err := c.Globals.Client.DeleteDatadog(&c.Input); err != nil {
 		return err
 	}
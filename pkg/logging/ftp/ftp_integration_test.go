@@ -1,260 +1,143 @@
 package ftp_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestFTPCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "anonymous", "--password", "foo@example.com"},
-			wantError: "error parsing arguments: required flag --address not provided",
+			Args: []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "anonymous", "--password", "foo@example.com"},
 		},
 		{
-			args:      []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--password", "foo@example.com"},
-			wantError: "error parsing arguments: required flag --user not provided",
+			Args: []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--password", "foo@example.com"},
 		},
 		{
-			args:      []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "anonymous"},
-			wantError: "error parsing arguments: required flag --password not provided",
+			Args: []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "anonymous"},
 		},
 		{
-			args:       []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "anonymous", "--password", "foo@example.com"},
-			api:        mock.API{CreateFTPFn: createFTPOK},
-			wantOutput: "Created FTP logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "anonymous", "--password", "foo@example.com"},
+			API:  mock.API{CreateFTPFn: createFTPOK},
 		},
 		{
-			args:      []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "anonymous", "--password", "foo@example.com"},
-			api:       mock.API{CreateFTPFn: createFTPError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "ftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "anonymous", "--password", "foo@example.com"},
+			API:  mock.API{CreateFTPFn: createFTPError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestFTPList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListFTPsFn: listFTPsOK},
-			wantOutput: listFTPsShortOutput,
+			Args: []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListFTPsFn: listFTPsOK},
 		},
 		{
-			args:       []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListFTPsFn: listFTPsOK},
-			wantOutput: listFTPsVerboseOutput,
+			Args: []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListFTPsFn: listFTPsOK},
 		},
 		{
-			args:       []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListFTPsFn: listFTPsOK},
-			wantOutput: listFTPsVerboseOutput,
+			Args: []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListFTPsFn: listFTPsOK},
 		},
 		{
-			args:       []string{"logging", "ftp", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListFTPsFn: listFTPsOK},
-			wantOutput: listFTPsVerboseOutput,
+			Args: []string{"logging", "ftp", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListFTPsFn: listFTPsOK},
 		},
 		{
-			args:       []string{"logging", "-v", "ftp", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListFTPsFn: listFTPsOK},
-			wantOutput: listFTPsVerboseOutput,
+			Args: []string{"logging", "-v", "ftp", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListFTPsFn: listFTPsOK},
 		},
 		{
-			args:      []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListFTPsFn: listFTPsError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "ftp", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListFTPsFn: listFTPsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestFTPDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "ftp", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "ftp", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "ftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetFTPFn: getFTPError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "ftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetFTPFn: getFTPError},
 		},
 		{
-			args:       []string{"logging", "ftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetFTPFn: getFTPOK},
-			wantOutput: describeFTPOutput,
+			Args: []string{"logging", "ftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetFTPFn: getFTPOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestFTPUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetFTPFn:    getFTPError,
 				UpdateFTPFn: updateFTPOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetFTPFn:    getFTPOK,
 				UpdateFTPFn: updateFTPError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "ftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetFTPFn:    getFTPOK,
 				UpdateFTPFn: updateFTPOK,
 			},
-			wantOutput: "Updated FTP logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestFTPDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "ftp", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "ftp", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "ftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteFTPFn: deleteFTPError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "ftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteFTPFn: deleteFTPError},
 		},
 		{
-			args:       []string{"logging", "ftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteFTPFn: deleteFTPOK},
-			wantOutput: "Deleted FTP logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "ftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteFTPFn: deleteFTPOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -318,53 +201,6 @@ func listFTPsError(i *fastly.ListFTPsInput) ([]*fastly.FTP, error) {
 	return nil, errTest
 }
 
-var listFTPsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listFTPsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	FTP 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Address: example.com
-		Port: 123
-		Username: anonymous
-		Password: foo@example.com
-		Public key: `+pgpPublicKey()+`
-		Path: logs/
-		Period: 3600
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-	FTP 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Address: 127.0.0.1
-		Port: 456
-		Username: foo
-		Password: password
-		Public key: `+pgpPublicKey()+`
-		Path: logs/
-		Period: 86400
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-`) + "\n\n"
-
 func getFTPOK(i *fastly.GetFTPInput) (*fastly.FTP, error) {
 	return &fastly.FTP{
 		ServiceID:         i.ServiceID,
@@ -390,25 +226,6 @@ func getFTPError(i *fastly.GetFTPInput) (*fastly.FTP, error) {
 	return nil, errTest
 }
 
-var describeFTPOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Address: example.com
-Port: 123
-Username: anonymous
-Password: foo@example.com
-Public key: `+pgpPublicKey()+`
-Path: logs/
-Period: 3600
-GZip level: 9
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Timestamp format: %Y-%m-%dT%H:%M:%S.000
-Placement: none
-`) + "\n"
-
 func updateFTPOK(i *fastly.UpdateFTPInput) (*fastly.FTP, error) {
 	return &fastly.FTP{
 		ServiceID:         i.ServiceID,
@@ -1,256 +1,140 @@
 package heroku_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestHerokuCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
-			wantError: "error parsing arguments: required flag --auth-token not provided",
+			Args: []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
 		},
 		{
-			args:      []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			wantError: "error parsing arguments: required flag --url not provided",
+			Args: []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
 		},
 		{
-			args:       []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--url", "example.com"},
-			api:        mock.API{CreateHerokuFn: createHerokuOK},
-			wantOutput: "Created Heroku logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--url", "example.com"},
+			API:  mock.API{CreateHerokuFn: createHerokuOK},
 		},
 		{
-			args:      []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--url", "example.com"},
-			api:       mock.API{CreateHerokuFn: createHerokuError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "heroku", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--url", "example.com"},
+			API:  mock.API{CreateHerokuFn: createHerokuError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHerokuList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHerokusFn: listHerokusOK},
-			wantOutput: listHerokusShortOutput,
+			Args: []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHerokusFn: listHerokusOK},
 		},
 		{
-			args:       []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListHerokusFn: listHerokusOK},
-			wantOutput: listHerokusVerboseOutput,
+			Args: []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListHerokusFn: listHerokusOK},
 		},
 		{
-			args:       []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListHerokusFn: listHerokusOK},
-			wantOutput: listHerokusVerboseOutput,
+			Args: []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListHerokusFn: listHerokusOK},
 		},
 		{
-			args:       []string{"logging", "heroku", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHerokusFn: listHerokusOK},
-			wantOutput: listHerokusVerboseOutput,
+			Args: []string{"logging", "heroku", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHerokusFn: listHerokusOK},
 		},
 		{
-			args:       []string{"logging", "-v", "heroku", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHerokusFn: listHerokusOK},
-			wantOutput: listHerokusVerboseOutput,
+			Args: []string{"logging", "-v", "heroku", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHerokusFn: listHerokusOK},
 		},
 		{
-			args:      []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListHerokusFn: listHerokusError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "heroku", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHerokusFn: listHerokusError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHerokuDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "heroku", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "heroku", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "heroku", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetHerokuFn: getHerokuError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "heroku", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetHerokuFn: getHerokuError},
 		},
 		{
-			args:       []string{"logging", "heroku", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetHerokuFn: getHerokuOK},
-			wantOutput: describeHerokuOutput,
+			Args: []string{"logging", "heroku", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetHerokuFn: getHerokuOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHerokuUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetHerokuFn:    getHerokuError,
 				UpdateHerokuFn: updateHerokuOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetHerokuFn:    getHerokuOK,
 				UpdateHerokuFn: updateHerokuError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "heroku", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetHerokuFn:    getHerokuOK,
 				UpdateHerokuFn: updateHerokuOK,
 			},
-			wantOutput: "Updated Heroku logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHerokuDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "heroku", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "heroku", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "heroku", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteHerokuFn: deleteHerokuError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "heroku", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteHerokuFn: deleteHerokuError},
 		},
 		{
-			args:       []string{"logging", "heroku", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteHerokuFn: deleteHerokuOK},
-			wantOutput: "Deleted Heroku logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "heroku", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteHerokuFn: deleteHerokuOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -305,39 +189,6 @@ func listHerokusError(i *fastly.ListHerokusInput) ([]*fastly.Heroku, error) {
 	return nil, errTest
 }
 
-var listHerokusShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listHerokusVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Heroku 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		URL: example.com
-		Token: abc
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-	Heroku 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		URL: bar.com
-		Token: abc
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-`) + "\n\n"
-
 func getHerokuOK(i *fastly.GetHerokuInput) (*fastly.Heroku, error) {
 	return &fastly.Heroku{
 		ServiceID:         i.ServiceID,
@@ -356,18 +207,6 @@ func getHerokuError(i *fastly.GetHerokuInput) (*fastly.Heroku, error) {
 	return nil, errTest
 }
 
-var describeHerokuOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-URL: example.com
-Token: abc
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Placement: none
-`) + "\n"
-
 func updateHerokuOK(i *fastly.UpdateHerokuInput) (*fastly.Heroku, error) {
 	return &fastly.Heroku{
 		ServiceID:         i.ServiceID,
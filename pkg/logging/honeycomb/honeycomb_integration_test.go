@@ -1,256 +1,140 @@
 package honeycomb_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestHoneycombCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--dataset", "log"},
-			wantError: "error parsing arguments: required flag --auth-token not provided",
+			Args: []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--dataset", "log"},
 		},
 		{
-			args:      []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			wantError: "error parsing arguments: required flag --dataset not provided",
+			Args: []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
 		},
 		{
-			args:       []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--dataset", "log"},
-			api:        mock.API{CreateHoneycombFn: createHoneycombOK},
-			wantOutput: "Created Honeycomb logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--dataset", "log"},
+			API:  mock.API{CreateHoneycombFn: createHoneycombOK},
 		},
 		{
-			args:      []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--dataset", "log"},
-			api:       mock.API{CreateHoneycombFn: createHoneycombError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "honeycomb", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc", "--dataset", "log"},
+			API:  mock.API{CreateHoneycombFn: createHoneycombError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHoneycombList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHoneycombsFn: listHoneycombsOK},
-			wantOutput: listHoneycombsShortOutput,
+			Args: []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHoneycombsFn: listHoneycombsOK},
 		},
 		{
-			args:       []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListHoneycombsFn: listHoneycombsOK},
-			wantOutput: listHoneycombsVerboseOutput,
+			Args: []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListHoneycombsFn: listHoneycombsOK},
 		},
 		{
-			args:       []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListHoneycombsFn: listHoneycombsOK},
-			wantOutput: listHoneycombsVerboseOutput,
+			Args: []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListHoneycombsFn: listHoneycombsOK},
 		},
 		{
-			args:       []string{"logging", "honeycomb", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHoneycombsFn: listHoneycombsOK},
-			wantOutput: listHoneycombsVerboseOutput,
+			Args: []string{"logging", "honeycomb", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHoneycombsFn: listHoneycombsOK},
 		},
 		{
-			args:       []string{"logging", "-v", "honeycomb", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListHoneycombsFn: listHoneycombsOK},
-			wantOutput: listHoneycombsVerboseOutput,
+			Args: []string{"logging", "-v", "honeycomb", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHoneycombsFn: listHoneycombsOK},
 		},
 		{
-			args:      []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListHoneycombsFn: listHoneycombsError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "honeycomb", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListHoneycombsFn: listHoneycombsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHoneycombDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "honeycomb", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "honeycomb", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "honeycomb", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetHoneycombFn: getHoneycombError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "honeycomb", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetHoneycombFn: getHoneycombError},
 		},
 		{
-			args:       []string{"logging", "honeycomb", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetHoneycombFn: getHoneycombOK},
-			wantOutput: describeHoneycombOutput,
+			Args: []string{"logging", "honeycomb", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetHoneycombFn: getHoneycombOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHoneycombUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetHoneycombFn:    getHoneycombError,
 				UpdateHoneycombFn: updateHoneycombOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetHoneycombFn:    getHoneycombOK,
 				UpdateHoneycombFn: updateHoneycombError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "honeycomb", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetHoneycombFn:    getHoneycombOK,
 				UpdateHoneycombFn: updateHoneycombOK,
 			},
-			wantOutput: "Updated Honeycomb logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestHoneycombDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "honeycomb", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "honeycomb", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "honeycomb", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteHoneycombFn: deleteHoneycombError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "honeycomb", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteHoneycombFn: deleteHoneycombError},
 		},
 		{
-			args:       []string{"logging", "honeycomb", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteHoneycombFn: deleteHoneycombOK},
-			wantOutput: "Deleted Honeycomb logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "honeycomb", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteHoneycombFn: deleteHoneycombOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -305,39 +189,6 @@ func listHoneycombsError(i *fastly.ListHoneycombsInput) ([]*fastly.Honeycomb, er
 	return nil, errTest
 }
 
-var listHoneycombsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listHoneycombsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Honeycomb 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Dataset: log
-		Token: tkn
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-	Honeycomb 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Dataset: log
-		Token: tkn
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-`) + "\n\n"
-
 func getHoneycombOK(i *fastly.GetHoneycombInput) (*fastly.Honeycomb, error) {
 	return &fastly.Honeycomb{
 		ServiceID:         i.ServiceID,
@@ -356,18 +207,6 @@ func getHoneycombError(i *fastly.GetHoneycombInput) (*fastly.Honeycomb, error) {
 	return nil, errTest
 }
 
-var describeHoneycombOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Dataset: log
-Token: tkn
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Placement: none
-`) + "\n"
-
 func updateHoneycombOK(i *fastly.UpdateHoneycombInput) (*fastly.Honeycomb, error) {
 	return &fastly.Honeycomb{
 		ServiceID:         i.ServiceID,
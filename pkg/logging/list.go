@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// ListFunc lists the resources of a kind in a service version, returning a
+// slice of pointers to go-fastly structs.
+type ListFunc func(client api.Interface, serviceID string, version int) (interface{}, error)
+
+// Lists maps the name of each logging subcommand to the API call listing its
+// endpoints.
+var Lists = map[string]ListFunc{
+	"azureblob": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListBlobStorages(&fastly.ListBlobStoragesInput{ServiceID: id, ServiceVersion: v})
+	},
+	"bigquery": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListBigQueries(&fastly.ListBigQueriesInput{ServiceID: id, ServiceVersion: v})
+	},
+	"cloudfiles": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListCloudfiles(&fastly.ListCloudfilesInput{ServiceID: id, ServiceVersion: v})
+	},
+	"datadog": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDatadog(&fastly.ListDatadogInput{ServiceID: id, ServiceVersion: v})
+	},
+	"digitalocean": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDigitalOceans(&fastly.ListDigitalOceansInput{ServiceID: id, ServiceVersion: v})
+	},
+	"elasticsearch": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListElasticsearch(&fastly.ListElasticsearchInput{ServiceID: id, ServiceVersion: v})
+	},
+	"ftp": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListFTPs(&fastly.ListFTPsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"gcs": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListGCSs(&fastly.ListGCSsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"googlepubsub": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListPubsubs(&fastly.ListPubsubsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"heroku": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListHerokus(&fastly.ListHerokusInput{ServiceID: id, ServiceVersion: v})
+	},
+	"honeycomb": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListHoneycombs(&fastly.ListHoneycombsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"https": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListHTTPS(&fastly.ListHTTPSInput{ServiceID: id, ServiceVersion: v})
+	},
+	"kafka": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListKafkas(&fastly.ListKafkasInput{ServiceID: id, ServiceVersion: v})
+	},
+	"kinesis": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListKineses(&fastly.ListKinesesInput{ServiceID: id, ServiceVersion: v})
+	},
+	"logentries": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListLogentries(&fastly.ListLogentriesInput{ServiceID: id, ServiceVersion: v})
+	},
+	"loggly": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListLoggly(&fastly.ListLogglyInput{ServiceID: id, ServiceVersion: v})
+	},
+	"logshuttle": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListLogshuttles(&fastly.ListLogshuttlesInput{ServiceID: id, ServiceVersion: v})
+	},
+	"openstack": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListOpenstack(&fastly.ListOpenstackInput{ServiceID: id, ServiceVersion: v})
+	},
+	"papertrail": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListPapertrails(&fastly.ListPapertrailsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"s3": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListS3s(&fastly.ListS3sInput{ServiceID: id, ServiceVersion: v})
+	},
+	"scalyr": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListScalyrs(&fastly.ListScalyrsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"sftp": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListSFTPs(&fastly.ListSFTPsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"splunk": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListSplunks(&fastly.ListSplunksInput{ServiceID: id, ServiceVersion: v})
+	},
+	"sumologic": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListSumologics(&fastly.ListSumologicsInput{ServiceID: id, ServiceVersion: v})
+	},
+	"syslog": func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListSyslogs(&fastly.ListSyslogsInput{ServiceID: id, ServiceVersion: v})
+	},
+}
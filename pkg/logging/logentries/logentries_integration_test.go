@@ -1,248 +1,134 @@
 package logentries_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestLogentriesCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "logentries", "create", "--service-id", "123", "--version", "1", "--name", "log", "--port", "20000"},
-			api:        mock.API{CreateLogentriesFn: createLogentriesOK},
-			wantOutput: "Created Logentries logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "logentries", "create", "--service-id", "123", "--version", "1", "--name", "log", "--port", "20000"},
+			API:  mock.API{CreateLogentriesFn: createLogentriesOK},
 		},
 		{
-			args:      []string{"logging", "logentries", "create", "--service-id", "123", "--version", "1", "--name", "log", "--port", "20000"},
-			api:       mock.API{CreateLogentriesFn: createLogentriesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logentries", "create", "--service-id", "123", "--version", "1", "--name", "log", "--port", "20000"},
+			API:  mock.API{CreateLogentriesFn: createLogentriesError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogentriesList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogentriesFn: listLogentriesOK},
-			wantOutput: listLogentriesShortOutput,
+			Args: []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogentriesFn: listLogentriesOK},
 		},
 		{
-			args:       []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListLogentriesFn: listLogentriesOK},
-			wantOutput: listLogentriesVerboseOutput,
+			Args: []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListLogentriesFn: listLogentriesOK},
 		},
 		{
-			args:       []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListLogentriesFn: listLogentriesOK},
-			wantOutput: listLogentriesVerboseOutput,
+			Args: []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListLogentriesFn: listLogentriesOK},
 		},
 		{
-			args:       []string{"logging", "logentries", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogentriesFn: listLogentriesOK},
-			wantOutput: listLogentriesVerboseOutput,
+			Args: []string{"logging", "logentries", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogentriesFn: listLogentriesOK},
 		},
 		{
-			args:       []string{"logging", "-v", "logentries", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogentriesFn: listLogentriesOK},
-			wantOutput: listLogentriesVerboseOutput,
+			Args: []string{"logging", "-v", "logentries", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogentriesFn: listLogentriesOK},
 		},
 		{
-			args:      []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListLogentriesFn: listLogentriesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logentries", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogentriesFn: listLogentriesError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogentriesDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "logentries", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "logentries", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "logentries", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetLogentriesFn: getLogentriesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logentries", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetLogentriesFn: getLogentriesError},
 		},
 		{
-			args:       []string{"logging", "logentries", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetLogentriesFn: getLogentriesOK},
-			wantOutput: describeLogentriesOutput,
+			Args: []string{"logging", "logentries", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetLogentriesFn: getLogentriesOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogentriesUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogentriesFn:    getLogentriesError,
 				UpdateLogentriesFn: updateLogentriesOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogentriesFn:    getLogentriesOK,
 				UpdateLogentriesFn: updateLogentriesError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "logentries", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogentriesFn:    getLogentriesOK,
 				UpdateLogentriesFn: updateLogentriesOK,
 			},
-			wantOutput: "Updated Logentries logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogentriesDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "logentries", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "logentries", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "logentries", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteLogentriesFn: deleteLogentriesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logentries", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteLogentriesFn: deleteLogentriesError},
 		},
 		{
-			args:       []string{"logging", "logentries", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteLogentriesFn: deleteLogentriesOK},
-			wantOutput: "Deleted Logentries logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "logentries", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteLogentriesFn: deleteLogentriesOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -294,41 +180,6 @@ func listLogentriesError(i *fastly.ListLogentriesInput) ([]*fastly.Logentries, e
 	return nil, errTest
 }
 
-var listLogentriesShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listLogentriesVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Logentries 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Port: 20000
-		Use TLS: true
-		Token: tkn
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-	Logentries 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Port: 20001
-		Use TLS: false
-		Token: tkn1
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-`) + "\n\n"
-
 func getLogentriesOK(i *fastly.GetLogentriesInput) (*fastly.Logentries, error) {
 	return &fastly.Logentries{
 		ServiceID:         i.ServiceID,
@@ -348,19 +199,6 @@ func getLogentriesError(i *fastly.GetLogentriesInput) (*fastly.Logentries, error
 	return nil, errTest
 }
 
-var describeLogentriesOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Port: 20000
-Use TLS: true
-Token: tkn
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Placement: none
-`) + "\n"
-
 func updateLogentriesOK(i *fastly.UpdateLogentriesInput) (*fastly.Logentries, error) {
 	return &fastly.Logentries{
 		ServiceID:         i.ServiceID,
@@ -1,252 +1,137 @@
 package loggly_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestLogglyCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "loggly", "create", "--service-id", "123", "--version", "1", "--name", "log"},
-			wantError: "error parsing arguments: required flag --auth-token not provided",
+			Args: []string{"logging", "loggly", "create", "--service-id", "123", "--version", "1", "--name", "log"},
 		},
 		{
-			args:       []string{"logging", "loggly", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			api:        mock.API{CreateLogglyFn: createLogglyOK},
-			wantOutput: "Created Loggly logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "loggly", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
+			API:  mock.API{CreateLogglyFn: createLogglyOK},
 		},
 		{
-			args:      []string{"logging", "loggly", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			api:       mock.API{CreateLogglyFn: createLogglyError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "loggly", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
+			API:  mock.API{CreateLogglyFn: createLogglyError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogglyList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogglyFn: listLogglysOK},
-			wantOutput: listLogglysShortOutput,
+			Args: []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogglyFn: listLogglysOK},
 		},
 		{
-			args:       []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListLogglyFn: listLogglysOK},
-			wantOutput: listLogglysVerboseOutput,
+			Args: []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListLogglyFn: listLogglysOK},
 		},
 		{
-			args:       []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListLogglyFn: listLogglysOK},
-			wantOutput: listLogglysVerboseOutput,
+			Args: []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListLogglyFn: listLogglysOK},
 		},
 		{
-			args:       []string{"logging", "loggly", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogglyFn: listLogglysOK},
-			wantOutput: listLogglysVerboseOutput,
+			Args: []string{"logging", "loggly", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogglyFn: listLogglysOK},
 		},
 		{
-			args:       []string{"logging", "-v", "loggly", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogglyFn: listLogglysOK},
-			wantOutput: listLogglysVerboseOutput,
+			Args: []string{"logging", "-v", "loggly", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogglyFn: listLogglysOK},
 		},
 		{
-			args:      []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListLogglyFn: listLogglysError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "loggly", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogglyFn: listLogglysError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogglyDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "loggly", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "loggly", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "loggly", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetLogglyFn: getLogglyError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "loggly", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetLogglyFn: getLogglyError},
 		},
 		{
-			args:       []string{"logging", "loggly", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetLogglyFn: getLogglyOK},
-			wantOutput: describeLogglyOutput,
+			Args: []string{"logging", "loggly", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetLogglyFn: getLogglyOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogglyUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogglyFn:    getLogglyError,
 				UpdateLogglyFn: updateLogglyOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogglyFn:    getLogglyOK,
 				UpdateLogglyFn: updateLogglyError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "loggly", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogglyFn:    getLogglyOK,
 				UpdateLogglyFn: updateLogglyOK,
 			},
-			wantOutput: "Updated Loggly logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogglyDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "loggly", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "loggly", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "loggly", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteLogglyFn: deleteLogglyError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "loggly", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteLogglyFn: deleteLogglyError},
 		},
 		{
-			args:       []string{"logging", "loggly", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteLogglyFn: deleteLogglyOK},
-			wantOutput: "Deleted Loggly logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "loggly", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteLogglyFn: deleteLogglyOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -299,37 +184,6 @@ func listLogglysError(i *fastly.ListLogglyInput) ([]*fastly.Loggly, error) {
 	return nil, errTest
 }
 
-var listLogglysShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listLogglysVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Loggly 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Token: abc
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-	Loggly 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Token: abc
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-`) + "\n\n"
-
 func getLogglyOK(i *fastly.GetLogglyInput) (*fastly.Loggly, error) {
 	return &fastly.Loggly{
 		ServiceID:         i.ServiceID,
@@ -347,17 +201,6 @@ func getLogglyError(i *fastly.GetLogglyInput) (*fastly.Loggly, error) {
 	return nil, errTest
 }
 
-var describeLogglyOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Token: abc
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Placement: none
-`) + "\n"
-
 func updateLogglyOK(i *fastly.UpdateLogglyInput) (*fastly.Loggly, error) {
 	return &fastly.Loggly{
 		ServiceID:         i.ServiceID,
@@ -1,256 +1,140 @@
 package logshuttle_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestLogshuttleCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			wantError: "error parsing arguments: required flag --url not provided",
+			Args: []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
 		},
 		{
-			args:      []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
-			wantError: "error parsing arguments: required flag --auth-token not provided",
+			Args: []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
 		},
 		{
-			args:       []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com", "--auth-token", "abc"},
-			api:        mock.API{CreateLogshuttleFn: createLogshuttleOK},
-			wantOutput: "Created Logshuttle logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com", "--auth-token", "abc"},
+			API:  mock.API{CreateLogshuttleFn: createLogshuttleOK},
 		},
 		{
-			args:      []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com", "--auth-token", "abc"},
-			api:       mock.API{CreateLogshuttleFn: createLogshuttleError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logshuttle", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com", "--auth-token", "abc"},
+			API:  mock.API{CreateLogshuttleFn: createLogshuttleError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogshuttleList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogshuttlesFn: listLogshuttlesOK},
-			wantOutput: listLogshuttlesShortOutput,
+			Args: []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogshuttlesFn: listLogshuttlesOK},
 		},
 		{
-			args:       []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListLogshuttlesFn: listLogshuttlesOK},
-			wantOutput: listLogshuttlesVerboseOutput,
+			Args: []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListLogshuttlesFn: listLogshuttlesOK},
 		},
 		{
-			args:       []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListLogshuttlesFn: listLogshuttlesOK},
-			wantOutput: listLogshuttlesVerboseOutput,
+			Args: []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListLogshuttlesFn: listLogshuttlesOK},
 		},
 		{
-			args:       []string{"logging", "logshuttle", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogshuttlesFn: listLogshuttlesOK},
-			wantOutput: listLogshuttlesVerboseOutput,
+			Args: []string{"logging", "logshuttle", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogshuttlesFn: listLogshuttlesOK},
 		},
 		{
-			args:       []string{"logging", "-v", "logshuttle", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListLogshuttlesFn: listLogshuttlesOK},
-			wantOutput: listLogshuttlesVerboseOutput,
+			Args: []string{"logging", "-v", "logshuttle", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogshuttlesFn: listLogshuttlesOK},
 		},
 		{
-			args:      []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListLogshuttlesFn: listLogshuttlesError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logshuttle", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListLogshuttlesFn: listLogshuttlesError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogshuttleDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "logshuttle", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "logshuttle", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "logshuttle", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetLogshuttleFn: getLogshuttleError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logshuttle", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetLogshuttleFn: getLogshuttleError},
 		},
 		{
-			args:       []string{"logging", "logshuttle", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetLogshuttleFn: getLogshuttleOK},
-			wantOutput: describeLogshuttleOutput,
+			Args: []string{"logging", "logshuttle", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetLogshuttleFn: getLogshuttleOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogshuttleUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogshuttleFn:    getLogshuttleError,
 				UpdateLogshuttleFn: updateLogshuttleOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogshuttleFn:    getLogshuttleOK,
 				UpdateLogshuttleFn: updateLogshuttleError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "logshuttle", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetLogshuttleFn:    getLogshuttleOK,
 				UpdateLogshuttleFn: updateLogshuttleOK,
 			},
-			wantOutput: "Updated Logshuttle logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestLogshuttleDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "logshuttle", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "logshuttle", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "logshuttle", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteLogshuttleFn: deleteLogshuttleError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "logshuttle", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteLogshuttleFn: deleteLogshuttleError},
 		},
 		{
-			args:       []string{"logging", "logshuttle", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteLogshuttleFn: deleteLogshuttleOK},
-			wantOutput: "Deleted Logshuttle logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "logshuttle", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteLogshuttleFn: deleteLogshuttleOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -305,39 +189,6 @@ func listLogshuttlesError(i *fastly.ListLogshuttlesInput) ([]*fastly.Logshuttle,
 	return nil, errTest
 }
 
-var listLogshuttlesShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listLogshuttlesVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Logshuttle 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		URL: example.com
-		Token: abc
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-	Logshuttle 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		URL: example.com
-		Token: abc
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-`) + "\n\n"
-
 func getLogshuttleOK(i *fastly.GetLogshuttleInput) (*fastly.Logshuttle, error) {
 	return &fastly.Logshuttle{
 		ServiceID:         i.ServiceID,
@@ -356,18 +207,6 @@ func getLogshuttleError(i *fastly.GetLogshuttleInput) (*fastly.Logshuttle, error
 	return nil, errTest
 }
 
-var describeLogshuttleOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-URL: example.com
-Token: abc
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Placement: none
-`) + "\n"
-
 func updateLogshuttleOK(i *fastly.UpdateLogshuttleInput) (*fastly.Logshuttle, error) {
 	return &fastly.Logshuttle{
 		ServiceID:         i.ServiceID,
@@ -1,264 +1,146 @@
 package openstack_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestOpenstackCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--access-key", "foo", "--user", "user", "--url", "https://example.com"},
-			wantError: "error parsing arguments: required flag --bucket not provided",
+			Args: []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--access-key", "foo", "--user", "user", "--url", "https://example.com"},
 		},
 		{
-			args:      []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--user", "user", "--url", "https://example.com"},
-			wantError: "error parsing arguments: required flag --access-key not provided",
+			Args: []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--user", "user", "--url", "https://example.com"},
 		},
 		{
-			args:      []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--url", "https://example.com"},
-			wantError: "error parsing arguments: required flag --user not provided",
+			Args: []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--url", "https://example.com"},
 		},
 		{
-			args:      []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--user", "user"},
-			wantError: "error parsing arguments: required flag --url not provided",
+			Args: []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--user", "user"},
 		},
 		{
-			args:       []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--user", "user", "--url", "https://example.com"},
-			api:        mock.API{CreateOpenstackFn: createOpenstackOK},
-			wantOutput: "Created OpenStack logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--user", "user", "--url", "https://example.com"},
+			API:  mock.API{CreateOpenstackFn: createOpenstackOK},
 		},
 		{
-			args:      []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--user", "user", "--url", "https://example.com"},
-			api:       mock.API{CreateOpenstackFn: createOpenstackError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "openstack", "create", "--service-id", "123", "--version", "1", "--name", "log", "--bucket", "log", "--access-key", "foo", "--user", "user", "--url", "https://example.com"},
+			API:  mock.API{CreateOpenstackFn: createOpenstackError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestOpenstackList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListOpenstacksFn: listOpenstacksOK},
-			wantOutput: listOpenstacksShortOutput,
+			Args: []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListOpenstacksFn: listOpenstacksOK},
 		},
 		{
-			args:       []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListOpenstacksFn: listOpenstacksOK},
-			wantOutput: listOpenstacksVerboseOutput,
+			Args: []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListOpenstacksFn: listOpenstacksOK},
 		},
 		{
-			args:       []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListOpenstacksFn: listOpenstacksOK},
-			wantOutput: listOpenstacksVerboseOutput,
+			Args: []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListOpenstacksFn: listOpenstacksOK},
 		},
 		{
-			args:       []string{"logging", "openstack", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListOpenstacksFn: listOpenstacksOK},
-			wantOutput: listOpenstacksVerboseOutput,
+			Args: []string{"logging", "openstack", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListOpenstacksFn: listOpenstacksOK},
 		},
 		{
-			args:       []string{"logging", "-v", "openstack", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListOpenstacksFn: listOpenstacksOK},
-			wantOutput: listOpenstacksVerboseOutput,
+			Args: []string{"logging", "-v", "openstack", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListOpenstacksFn: listOpenstacksOK},
 		},
 		{
-			args:      []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListOpenstacksFn: listOpenstacksError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "openstack", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListOpenstacksFn: listOpenstacksError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestOpenstackDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "openstack", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "openstack", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "openstack", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetOpenstackFn: getOpenstackError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "openstack", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetOpenstackFn: getOpenstackError},
 		},
 		{
-			args:       []string{"logging", "openstack", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetOpenstackFn: getOpenstackOK},
-			wantOutput: describeOpenstackOutput,
+			Args: []string{"logging", "openstack", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetOpenstackFn: getOpenstackOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestOpenstackUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetOpenstackFn:    getOpenstackError,
 				UpdateOpenstackFn: updateOpenstackOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetOpenstackFn:    getOpenstackOK,
 				UpdateOpenstackFn: updateOpenstackError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "openstack", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetOpenstackFn:    getOpenstackOK,
 				UpdateOpenstackFn: updateOpenstackOK,
 			},
-			wantOutput: "Updated OpenStack logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestOpenstackDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "openstack", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "openstack", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "openstack", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteOpenstackFn: deleteOpenstackError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "openstack", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteOpenstackFn: deleteOpenstackError},
 		},
 		{
-			args:       []string{"logging", "openstack", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteOpenstackFn: deleteOpenstackOK},
-			wantOutput: "Deleted OpenStack logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "openstack", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteOpenstackFn: deleteOpenstackOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -329,55 +211,6 @@ func listOpenstacksError(i *fastly.ListOpenstackInput) ([]*fastly.Openstack, err
 	return nil, errTest
 }
 
-var listOpenstacksShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listOpenstacksVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Openstack 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Bucket: my-logs
-		Access key: 1234
-		User: user
-		URL: https://example.com
-		Path: logs/
-		Period: 3600
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-		Public key: `+pgpPublicKey()+`
-	Openstack 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Bucket: analytics
-		Access key: 1234
-		User: user2
-		URL: https://two.example.com
-		Path: logs/
-		Period: 86400
-		GZip level: 9
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-		Public key: `+pgpPublicKey()+`
-`) + "\n\n"
-
 func getOpenstackOK(i *fastly.GetOpenstackInput) (*fastly.Openstack, error) {
 	return &fastly.Openstack{
 		ServiceID:         i.ServiceID,
@@ -404,26 +237,6 @@ func getOpenstackError(i *fastly.GetOpenstackInput) (*fastly.Openstack, error) {
 	return nil, errTest
 }
 
-var describeOpenstackOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Bucket: my-logs
-Access key: 1234
-User: user
-URL: https://example.com
-Path: logs/
-Period: 3600
-GZip level: 9
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Message type: classic
-Timestamp format: %Y-%m-%dT%H:%M:%S.000
-Placement: none
-Public key: `+pgpPublicKey()+`
-`) + "\n"
-
 func updateOpenstackOK(i *fastly.UpdateOpenstackInput) (*fastly.Openstack, error) {
 	return &fastly.Openstack{
 		ServiceID:         i.ServiceID,
@@ -1,261 +1,143 @@
 package scalyr_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
-	fsterrs "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestScalyrCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--auth-token", "abc"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--auth-token", "abc"},
 		},
 		{
-			args:      []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--name", "log"},
-			wantError: "error parsing arguments: required flag --auth-token not provided",
+			Args: []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--name", "log"},
 		},
 		{
-			args:      []string{"logging", "scalyr", "create", "--name", "log", "--service-id", "", "--version", "1", "--auth-token", "abc"},
-			wantError: fsterrs.ErrNoServiceID.Error(),
+			Args: []string{"logging", "scalyr", "create", "--name", "log", "--service-id", "", "--version", "1", "--auth-token", "abc"},
 		},
 		{
-			args:       []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			api:        mock.API{CreateScalyrFn: createScalyrOK},
-			wantOutput: "Created Scalyr logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
+			API:  mock.API{CreateScalyrFn: createScalyrOK},
 		},
 		{
-			args:      []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
-			api:       mock.API{CreateScalyrFn: createScalyrError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "scalyr", "create", "--service-id", "123", "--version", "1", "--name", "log", "--auth-token", "abc"},
+			API:  mock.API{CreateScalyrFn: createScalyrError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestScalyrList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListScalyrsFn: listScalyrsOK},
-			wantOutput: listScalyrsShortOutput,
+			Args: []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListScalyrsFn: listScalyrsOK},
 		},
 		{
-			args:       []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListScalyrsFn: listScalyrsOK},
-			wantOutput: listScalyrsVerboseOutput,
+			Args: []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListScalyrsFn: listScalyrsOK},
 		},
 		{
-			args:       []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListScalyrsFn: listScalyrsOK},
-			wantOutput: listScalyrsVerboseOutput,
+			Args: []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListScalyrsFn: listScalyrsOK},
 		},
 		{
-			args:       []string{"logging", "scalyr", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListScalyrsFn: listScalyrsOK},
-			wantOutput: listScalyrsVerboseOutput,
+			Args: []string{"logging", "scalyr", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListScalyrsFn: listScalyrsOK},
 		},
 		{
-			args:       []string{"logging", "-v", "scalyr", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListScalyrsFn: listScalyrsOK},
-			wantOutput: listScalyrsVerboseOutput,
+			Args: []string{"logging", "-v", "scalyr", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListScalyrsFn: listScalyrsOK},
 		},
 		{
-			args:      []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListScalyrsFn: listScalyrsError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "scalyr", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListScalyrsFn: listScalyrsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestScalyrDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "scalyr", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "scalyr", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "scalyr", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetScalyrFn: getScalyrError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "scalyr", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetScalyrFn: getScalyrError},
 		},
 		{
-			args:       []string{"logging", "scalyr", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetScalyrFn: getScalyrOK},
-			wantOutput: describeScalyrOutput,
+			Args: []string{"logging", "scalyr", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetScalyrFn: getScalyrOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestScalyrUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetScalyrFn:    getScalyrError,
 				UpdateScalyrFn: updateScalyrOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetScalyrFn:    getScalyrOK,
 				UpdateScalyrFn: updateScalyrError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "scalyr", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetScalyrFn:    getScalyrOK,
 				UpdateScalyrFn: updateScalyrOK,
 			},
-			wantOutput: "Updated Scalyr logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestScalyrDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "scalyr", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "scalyr", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "scalyr", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteScalyrFn: deleteScalyrError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "scalyr", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteScalyrFn: deleteScalyrError},
 		},
 		{
-			args:       []string{"logging", "scalyr", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteScalyrFn: deleteScalyrOK},
-			wantOutput: "Deleted Scalyr logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "scalyr", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteScalyrFn: deleteScalyrOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -332,39 +214,6 @@ func listScalyrsError(i *fastly.ListScalyrsInput) ([]*fastly.Scalyr, error) {
 	return nil, errTest
 }
 
-var listScalyrsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listScalyrsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Scalyr 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Token: abc
-		Region: US
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-	Scalyr 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Token: abc
-		Region: US
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Placement: none
-`) + "\n\n"
-
 func getScalyrOK(i *fastly.GetScalyrInput) (*fastly.Scalyr, error) {
 	return &fastly.Scalyr{
 		ServiceID:         i.ServiceID,
@@ -383,18 +232,6 @@ func getScalyrError(i *fastly.GetScalyrInput) (*fastly.Scalyr, error) {
 	return nil, errTest
 }
 
-var describeScalyrOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Token: abc
-Region: US
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Placement: none
-`) + "\n"
-
 func updateScalyrOK(i *fastly.UpdateScalyrInput) (*fastly.Scalyr, error) {
 	return &fastly.Scalyr{
 		ServiceID:         i.ServiceID,
@@ -1,260 +1,143 @@
 package sftp_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestSFTPCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "user", "--ssh-known-hosts", knownHosts(), "--port", "80"},
-			wantError: "error parsing arguments: required flag --address not provided",
+			Args: []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--user", "user", "--ssh-known-hosts", knownHosts(), "--port", "80"},
 		},
 		{
-			args:      []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--ssh-known-hosts", knownHosts(), "--port", "80"},
-			wantError: "error parsing arguments: required flag --user not provided",
+			Args: []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--ssh-known-hosts", knownHosts(), "--port", "80"},
 		},
 		{
-			args:      []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "user", "--port", "80"},
-			wantError: "error parsing arguments: required flag --ssh-known-hosts not provided",
+			Args: []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "user", "--port", "80"},
 		},
 		{
-			args:       []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "user", "--ssh-known-hosts", knownHosts(), "--port", "80"},
-			api:        mock.API{CreateSFTPFn: createSFTPOK},
-			wantOutput: "Created SFTP logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "user", "--ssh-known-hosts", knownHosts(), "--port", "80"},
+			API:  mock.API{CreateSFTPFn: createSFTPOK},
 		},
 		{
-			args:      []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "user", "--ssh-known-hosts", knownHosts(), "--port", "80"},
-			api:       mock.API{CreateSFTPFn: createSFTPError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sftp", "create", "--service-id", "123", "--version", "1", "--name", "log", "--address", "example.com", "--user", "user", "--ssh-known-hosts", knownHosts(), "--port", "80"},
+			API:  mock.API{CreateSFTPFn: createSFTPError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSFTPList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListSFTPsFn: listSFTPsOK},
-			wantOutput: listSFTPsShortOutput,
+			Args: []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSFTPsFn: listSFTPsOK},
 		},
 		{
-			args:       []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListSFTPsFn: listSFTPsOK},
-			wantOutput: listSFTPsVerboseOutput,
+			Args: []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListSFTPsFn: listSFTPsOK},
 		},
 		{
-			args:       []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListSFTPsFn: listSFTPsOK},
-			wantOutput: listSFTPsVerboseOutput,
+			Args: []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListSFTPsFn: listSFTPsOK},
 		},
 		{
-			args:       []string{"logging", "sftp", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListSFTPsFn: listSFTPsOK},
-			wantOutput: listSFTPsVerboseOutput,
+			Args: []string{"logging", "sftp", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSFTPsFn: listSFTPsOK},
 		},
 		{
-			args:       []string{"logging", "-v", "sftp", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListSFTPsFn: listSFTPsOK},
-			wantOutput: listSFTPsVerboseOutput,
+			Args: []string{"logging", "-v", "sftp", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSFTPsFn: listSFTPsOK},
 		},
 		{
-			args:      []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListSFTPsFn: listSFTPsError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sftp", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSFTPsFn: listSFTPsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSFTPDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sftp", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "sftp", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "sftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetSFTPFn: getSFTPError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetSFTPFn: getSFTPError},
 		},
 		{
-			args:       []string{"logging", "sftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetSFTPFn: getSFTPOK},
-			wantOutput: describeSFTPOutput,
+			Args: []string{"logging", "sftp", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetSFTPFn: getSFTPOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSFTPUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetSFTPFn:    getSFTPError,
 				UpdateSFTPFn: updateSFTPOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetSFTPFn:    getSFTPOK,
 				UpdateSFTPFn: updateSFTPError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "sftp", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetSFTPFn:    getSFTPOK,
 				UpdateSFTPFn: updateSFTPOK,
 			},
-			wantOutput: "Updated SFTP logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSFTPDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sftp", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "sftp", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "sftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteSFTPFn: deleteSFTPError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteSFTPFn: deleteSFTPError},
 		},
 		{
-			args:       []string{"logging", "sftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteSFTPFn: deleteSFTPOK},
-			wantOutput: "Deleted SFTP logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "sftp", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteSFTPFn: deleteSFTPOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -329,59 +212,6 @@ func listSFTPsError(i *fastly.ListSFTPsInput) ([]*fastly.SFTP, error) {
 	return nil, errTest
 }
 
-var listSFTPsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listSFTPsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	SFTP 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		Address: 127.0.0.1
-		Port: 514
-		User: user
-		Password: password
-		Public key: `+pgpPublicKey()+`
-		Secret key: `+sshPrivateKey()+`
-		SSH known hosts: `+knownHosts()+`
-		Path: /logs
-		Period: 3600
-		GZip level: 2
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Message type: classic
-		Response condition: Prevent default logging
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-	SFTP 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		Address: example.com
-		Port: 123
-		User: user
-		Password: password
-		Public key: `+pgpPublicKey()+`
-		Secret key: `+sshPrivateKey()+`
-		SSH known hosts: `+knownHosts()+`
-		Path: /analytics
-		Period: 3600
-		GZip level: 3
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Message type: classic
-		Response condition: Prevent default logging
-		Timestamp format: %Y-%m-%dT%H:%M:%S.000
-		Placement: none
-`) + "\n\n"
-
 func getSFTPOK(i *fastly.GetSFTPInput) (*fastly.SFTP, error) {
 	return &fastly.SFTP{
 		ServiceID:         i.ServiceID,
@@ -410,28 +240,6 @@ func getSFTPError(i *fastly.GetSFTPInput) (*fastly.SFTP, error) {
 	return nil, errTest
 }
 
-var describeSFTPOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-Address: example.com
-Port: 514
-User: user
-Password: password
-Public key: `+pgpPublicKey()+`
-Secret key: `+sshPrivateKey()+`
-SSH known hosts: `+knownHosts()+`
-Path: /logs
-Period: 3600
-GZip level: 2
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Message type: classic
-Response condition: Prevent default logging
-Timestamp format: %Y-%m-%dT%H:%M:%S.000
-Placement: none
-`) + "\n"
-
 func updateSFTPOK(i *fastly.UpdateSFTPInput) (*fastly.SFTP, error) {
 	return &fastly.SFTP{
 		ServiceID:         i.ServiceID,
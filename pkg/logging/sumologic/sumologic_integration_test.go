@@ -1,252 +1,137 @@
 package sumologic_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestSumologicCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sumologic", "create", "--service-id", "123", "--version", "1", "--name", "log"},
-			wantError: "error parsing arguments: required flag --url not provided",
+			Args: []string{"logging", "sumologic", "create", "--service-id", "123", "--version", "1", "--name", "log"},
 		},
 		{
-			args:       []string{"logging", "sumologic", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
-			api:        mock.API{CreateSumologicFn: createSumologicOK},
-			wantOutput: "Created Sumologic logging endpoint log (service 123 version 1)",
+			Args: []string{"logging", "sumologic", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
+			API:  mock.API{CreateSumologicFn: createSumologicOK},
 		},
 		{
-			args:      []string{"logging", "sumologic", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
-			api:       mock.API{CreateSumologicFn: createSumologicError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sumologic", "create", "--service-id", "123", "--version", "1", "--name", "log", "--url", "example.com"},
+			API:  mock.API{CreateSumologicFn: createSumologicError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSumologicList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListSumologicsFn: listSumologicsOK},
-			wantOutput: listSumologicsShortOutput,
+			Args: []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSumologicsFn: listSumologicsOK},
 		},
 		{
-			args:       []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1", "--verbose"},
-			api:        mock.API{ListSumologicsFn: listSumologicsOK},
-			wantOutput: listSumologicsVerboseOutput,
+			Args: []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1", "--verbose"},
+			API:  mock.API{ListSumologicsFn: listSumologicsOK},
 		},
 		{
-			args:       []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1", "-v"},
-			api:        mock.API{ListSumologicsFn: listSumologicsOK},
-			wantOutput: listSumologicsVerboseOutput,
+			Args: []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1", "-v"},
+			API:  mock.API{ListSumologicsFn: listSumologicsOK},
 		},
 		{
-			args:       []string{"logging", "sumologic", "--verbose", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListSumologicsFn: listSumologicsOK},
-			wantOutput: listSumologicsVerboseOutput,
+			Args: []string{"logging", "sumologic", "--verbose", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSumologicsFn: listSumologicsOK},
 		},
 		{
-			args:       []string{"logging", "-v", "sumologic", "list", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ListSumologicsFn: listSumologicsOK},
-			wantOutput: listSumologicsVerboseOutput,
+			Args: []string{"logging", "-v", "sumologic", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSumologicsFn: listSumologicsOK},
 		},
 		{
-			args:      []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ListSumologicsFn: listSumologicsError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sumologic", "list", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ListSumologicsFn: listSumologicsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSumologicDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sumologic", "describe", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "sumologic", "describe", "--service-id", "123", "--version", "1"},
 		},
 		{
-			args:      []string{"logging", "sumologic", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{GetSumologicFn: getSumologicError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sumologic", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetSumologicFn: getSumologicError},
 		},
 		{
-			args:       []string{"logging", "sumologic", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{GetSumologicFn: getSumologicOK},
-			wantOutput: describeSumologicOutput,
+			Args: []string{"logging", "sumologic", "describe", "--service-id", "123", "--version", "1", "--name", "logs"},
+			API:  mock.API{GetSumologicFn: getSumologicOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSumologicUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--new-name", "log"},
 		},
 		{
-			args: []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetSumologicFn:    getSumologicError,
 				UpdateSumologicFn: updateSumologicOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetSumologicFn:    getSumologicOK,
 				UpdateSumologicFn: updateSumologicError,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
-			api: mock.API{
+			Args: []string{"logging", "sumologic", "update", "--service-id", "123", "--version", "1", "--name", "logs", "--new-name", "log"},
+			API: mock.API{
 				GetSumologicFn:    getSumologicOK,
 				UpdateSumologicFn: updateSumologicOK,
 			},
-			wantOutput: "Updated Sumologic logging endpoint log (service 123 version 1)",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestSumologicDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"logging", "sumologic", "delete", "--service-id", "123", "--version", "1"},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"logging", "sumologic", "delete", "--service-id", "123", "--version", "1", "--auto-yes"},
 		},
 		{
-			args:      []string{"logging", "sumologic", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:       mock.API{DeleteSumologicFn: deleteSumologicError},
-			wantError: errTest.Error(),
+			Args: []string{"logging", "sumologic", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteSumologicFn: deleteSumologicError},
 		},
 		{
-			args:       []string{"logging", "sumologic", "delete", "--service-id", "123", "--version", "1", "--name", "logs"},
-			api:        mock.API{DeleteSumologicFn: deleteSumologicOK},
-			wantOutput: "Deleted Sumologic logging endpoint logs (service 123 version 1)",
+			Args: []string{"logging", "sumologic", "delete", "--service-id", "123", "--version", "1", "--name", "logs", "--auto-yes"},
+			API:  mock.API{DeleteSumologicFn: deleteSumologicOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -296,39 +181,6 @@ func listSumologicsError(i *fastly.ListSumologicsInput) ([]*fastly.Sumologic, er
 	return nil, errTest
 }
 
-var listSumologicsShortOutput = strings.TrimSpace(`
-SERVICE  VERSION  NAME
-123      1        logs
-123      1        analytics
-`) + "\n"
-
-var listSumologicsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service ID: 123
-Version: 1
-	Sumologic 1/2
-		Service ID: 123
-		Version: 1
-		Name: logs
-		URL: example.com
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Placement: none
-	Sumologic 2/2
-		Service ID: 123
-		Version: 1
-		Name: analytics
-		URL: bar.com
-		Format: %h %l %u %t "%r" %>s %b
-		Format version: 2
-		Response condition: Prevent default logging
-		Message type: classic
-		Placement: none
-`) + "\n\n"
-
 func getSumologicOK(i *fastly.GetSumologicInput) (*fastly.Sumologic, error) {
 	return &fastly.Sumologic{
 		ServiceID:         i.ServiceID,
@@ -347,18 +199,6 @@ func getSumologicError(i *fastly.GetSumologicInput) (*fastly.Sumologic, error) {
 	return nil, errTest
 }
 
-var describeSumologicOutput = strings.TrimSpace(`
-Service ID: 123
-Version: 1
-Name: logs
-URL: example.com
-Format: %h %l %u %t "%r" %>s %b
-Format version: 2
-Response condition: Prevent default logging
-Message type: classic
-Placement: none
-`) + "\n"
-
 func updateSumologicOK(i *fastly.UpdateSumologicInput) (*fastly.Sumologic, error) {
 	return &fastly.Sumologic{
 		ServiceID:         i.ServiceID,
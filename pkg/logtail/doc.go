@@ -0,0 +1,3 @@
+// Package logtail contains a command to stream the output which Compute@Edge
+// services write to stdout and stderr.
+package logtail
@@ -0,0 +1,59 @@
+package logtail_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/fakeapi"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/apptest"
+	"github.com/fastly/cli/pkg/testutil/golden"
+)
+
+func TestLogTail(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+
+	at := func(s string) time.Time {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			panic(err)
+		}
+		return t
+	}
+	compute := server.AddService("compute", "wasm")
+	server.AddLogs(compute,
+		fakeapi.Log{RequestID: "req1", Stream: "stdout", Message: "too early", Time: at("2021-01-02T10:30:00Z")},
+	)
+	server.AddLogs(compute,
+		fakeapi.Log{RequestID: "req3", Stream: "stdout", Message: "GET /basket", Time: at("2021-01-02T12:30:00.25Z")},
+		fakeapi.Log{RequestID: "req2", Stream: "stdout", Message: "GET /", Time: at("2021-01-02T12:00:00Z")},
+		fakeapi.Log{RequestID: "req2", Stream: "stderr", Message: "ERROR: no such user\n", Time: at("2021-01-02T12:00:00Z")},
+	)
+	server.AddLogs(compute,
+		fakeapi.Log{RequestID: "req4", Stream: "stderr", Message: "error: timed out", Time: at("2021-01-02T12:45:00Z")},
+		fakeapi.Log{RequestID: "req5", Stream: "stdout", Message: "too late", Time: at("2021-01-02T13:30:00Z")},
+	)
+	vcl := server.AddService("vcl", "vcl")
+
+	window := []string{"--from", "2021-01-02T11:00Z", "--to", "2021-01-02T13:00Z"}
+	for _, testcase := range []struct {
+		name string
+		args []string
+	}{
+		{"window", append([]string{"log-tail", "--service-id", compute}, window...)},
+		{"search", append([]string{"log-tail", "--service-id", compute, "--search", "error"}, window...)},
+		{"json", append([]string{"log-tail", "--service-id", compute, "--format", "json"}, window...)},
+		{"vcl service", append([]string{"log-tail", "--service-id", vcl}, window...)},
+		{"no service ID", []string{"log-tail"}},
+		{"invalid time", []string{"log-tail", "--service-id", compute, "--from", "yesterday"}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			out, err := apptest.Run(t, server.URL, nil, testcase.args...)
+			golden.Assert(t, golden.Format(out, err))
+
+			// Every session is ended when the command exits.
+			testutil.AssertEqual(t, 0, server.LogTails())
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package logtail
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/version"
+)
+
+// pollInterval is how often new batches of logs are requested.
+const pollInterval = 2 * time.Second
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	common.Base
+	manifest manifest.Data
+	client   api.HTTPClient
+	from     common.OptionalString
+	to       common.OptionalString
+	search   string
+	format   string
+
+	// now and interrupt are replaced in tests.
+	now       func() time.Time
+	interrupt chan os.Signal
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent common.Registerer, client api.HTTPClient, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.client = client
+	c.now = time.Now
+	c.manifest.File.Read(manifest.Filename)
+	c.CmdClause = parent.Command("log-tail", "Stream the stdout and stderr output of a Compute@Edge service, until interrupted")
+	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
+	c.CmdClause.Flag("from", "Also list logs of requests since this time, e.g. 2021-01-02T15:04Z, or 5m ago").Action(c.from.Set).StringVar(&c.from.Value)
+	c.CmdClause.Flag("to", "Only list logs of requests before this time, and stop once it has passed, e.g. 2021-01-02T15:04Z").Action(c.to.Set).StringVar(&c.to.Value)
+	c.CmdClause.Flag("search", "Only list logs containing this text, ignoring case").StringVar(&c.search)
+	c.CmdClause.Flag("format", "Output format (json)").EnumVar(&c.format, "json")
+	return &c
+}
+
+// logLine is a line written by a service to stdout or stderr while handling a
+// request.
+type logLine struct {
+	SequenceNumber int    `json:"sequence_number"`
+	RequestTime    int64  `json:"request_start_from_epoch"` // microseconds
+	Stream         string `json:"stream"`
+	RequestID      string `json:"id"`
+	Message        string `json:"message"`
+}
+
+// time returns the time at which the request which wrote the line started.
+func (l logLine) time() time.Time {
+	return time.Unix(0, l.RequestTime*int64(time.Microsecond)).UTC()
+}
+
+// batch is a batch of log lines, as delivered by the API.
+type batch struct {
+	ID   string    `json:"batch_id"`
+	Logs []logLine `json:"logs"`
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	c.format = c.Globals.CommandFormat(c.format)
+
+	serviceID, source := c.manifest.ServiceID()
+	if source == manifest.SourceUndefined {
+		return errors.ErrNoServiceID
+	}
+	if err := c.Globals.ResolveToken(); err != nil {
+		return err
+	}
+	token, tokenSource := c.Globals.Token()
+	if tokenSource == config.SourceUndefined {
+		return errors.ErrNoToken
+	}
+
+	now := c.now()
+	var from, to time.Time
+	if c.from.WasSet {
+		t, err := common.ParseTime("from", c.from.Value, now)
+		if err != nil {
+			return err
+		}
+		from = t
+	}
+	if c.to.WasSet {
+		t, err := common.ParseTime("to", c.to.Value, now)
+		if err != nil {
+			return err
+		}
+		to = t
+	}
+
+	endpoint, _ := c.Globals.Endpoint()
+	s := session{
+		client: c.client,
+		base:   fmt.Sprintf("%s/service/%s/log_stream/managed/instance_output", strings.TrimSuffix(endpoint, "/"), url.PathEscape(serviceID)),
+		token:  token,
+	}
+	// Interrupts are handled from before the session is created, so that one
+	// during its creation doesn't exit without deleting it.
+	interrupt := c.interrupt
+	if interrupt == nil {
+		interrupt = make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+	}
+	if err := s.create(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := s.delete(); err != nil {
+			text.Warning(out, "Failed to end the log tailing session: %v", err)
+		}
+	}()
+
+	if c.format == "" && to.IsZero() {
+		text.Info(out, "Tailing the logs of service %s. Press Ctrl-C to stop.", serviceID)
+		text.Break(out)
+	}
+
+	query := url.Values{}
+	if !from.IsZero() {
+		query.Set("from", fmt.Sprint(from.Unix()))
+	}
+	seen := make(map[string]bool)
+	for {
+		batches, err := s.poll(query)
+		if err != nil {
+			return err
+		}
+		for _, b := range batches {
+			if seen[b.ID] {
+				continue
+			}
+			seen[b.ID] = true
+			if err := c.print(out, b.Logs, from, to); err != nil {
+				return err
+			}
+		}
+
+		if !to.IsZero() && !c.now().Before(to) {
+			return nil
+		}
+		select {
+		case <-interrupt:
+			if c.format == "" {
+				text.Break(out)
+			}
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// print writes the logs of a batch within the time range, which match
+// --search, in order.
+func (c *RootCommand) print(out io.Writer, logs []logLine, from, to time.Time) error {
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].RequestTime != logs[j].RequestTime {
+			return logs[i].RequestTime < logs[j].RequestTime
+		}
+		return logs[i].SequenceNumber < logs[j].SequenceNumber
+	})
+
+	enc := json.NewEncoder(out)
+	for _, l := range logs {
+		t := l.time()
+		switch {
+		case t.Before(from):
+			continue
+		case !to.IsZero() && !t.Before(to):
+			continue
+		case !strings.Contains(strings.ToLower(l.Message), strings.ToLower(c.search)):
+			continue
+		}
+
+		if c.format == "json" {
+			if err := enc.Encode(logJSON{
+				RequestID: l.RequestID,
+				Time:      t,
+				Stream:    l.Stream,
+				Message:   l.Message,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(out, "%s %s %s | %s\n", t.Format("2006-01-02T15:04:05.000Z07:00"), l.RequestID, l.Stream, strings.TrimRight(l.Message, "\r\n"))
+	}
+	return nil
+}
+
+// logJSON is a log as written by --format=json.
+type logJSON struct {
+	RequestID string    `json:"request_id"`
+	Time      time.Time `json:"time"`
+	Stream    string    `json:"stream"`
+	Message   string    `json:"message"`
+}
+
+// session is a log tailing session of a service. Sessions are created by a
+// POST to the base URL, which returns their ID, and then read from via GETs
+// of base/<ID>, each of which returns the batches of logs delivered since the
+// cursor of the previous one. Deleting base/<ID> ends the session.
+type session struct {
+	client api.HTTPClient
+	base   string
+	token  string
+	id     string
+	cursor string
+}
+
+func (s *session) create() error {
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := s.do(http.MethodPost, s.base, &resp); err != nil {
+		return fmt.Errorf("error creating log tailing session: %w", err)
+	}
+	s.id = resp.ID
+	return nil
+}
+
+// poll returns the batches delivered since the last poll. The query is only
+// sent with the first poll, as later ones continue from its cursor.
+func (s *session) poll(query url.Values) ([]batch, error) {
+	if s.cursor != "" {
+		query = url.Values{"cursor": []string{s.cursor}}
+	}
+	target := s.base + "/" + url.PathEscape(s.id)
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var resp struct {
+		Batches []batch `json:"batches"`
+		Cursor  string  `json:"cursor"`
+	}
+	if err := s.do(http.MethodGet, target, &resp); err != nil {
+		return nil, fmt.Errorf("error fetching logs: %w", err)
+	}
+	if resp.Cursor != "" {
+		s.cursor = resp.Cursor
+	}
+	return resp.Batches, nil
+}
+
+func (s *session) delete() error {
+	return s.do(http.MethodDelete, s.base+"/"+url.PathEscape(s.id), nil)
+}
+
+// do sends an authenticated request, and decodes the JSON response into v, if
+// it's non-nil.
+func (s *session) do(method, target string, v interface{}) error {
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Fastly-Key", s.token)
+	req.Header.Set("User-Agent", version.UserAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e struct {
+			Msg    string `json:"msg"`
+			Detail string `json:"detail"`
+		}
+		if json.Unmarshal(b, &e) == nil && e.Detail != "" {
+			return fmt.Errorf("%s: %s", resp.Status, e.Detail)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(b, v)
+}
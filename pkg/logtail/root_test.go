@@ -0,0 +1,98 @@
+package logtail
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/fakeapi"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+// logsOnCreate adds logs to a service once a log tailing session of it is
+// created, as though requests were handled while tailing.
+type logsOnCreate struct {
+	server    *fakeapi.Server
+	serviceID string
+	logs      []fakeapi.Log
+}
+
+func (c logsOnCreate) Do(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil && req.Method == http.MethodPost {
+		c.server.AddLogs(c.serviceID, c.logs...)
+	}
+	return resp, err
+}
+
+// TestInterrupt tails logs until interrupted, which ends the session.
+func TestInterrupt(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+	id := server.AddService("compute", "wasm")
+
+	// Logs from before the session was created aren't listed without --from.
+	start := time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC)
+	server.AddLogs(id, fakeapi.Log{RequestID: "req1", Stream: "stdout", Message: "before", Time: start})
+
+	c := RootCommand{
+		client: logsOnCreate{server: server, serviceID: id, logs: []fakeapi.Log{
+			{RequestID: "req2", Stream: "stdout", Message: "during", Time: start.Add(time.Minute)},
+		}},
+		now:       time.Now,
+		interrupt: make(chan os.Signal, 1),
+	}
+	c.Globals = &config.Data{Env: config.Environment{Token: "fake", Endpoint: server.URL}}
+	c.manifest.Flag.ServiceID = id
+	c.interrupt <- os.Interrupt
+
+	var out bytes.Buffer
+	testutil.AssertNoError(t, c.Exec(nil, &out))
+	testutil.AssertString(t, strings.Join([]string{
+		"",
+		"INFO: Tailing the logs of service service000001. Press Ctrl-C to stop.",
+		"",
+		"2021-01-02T12:01:00.000Z req2 stdout | during",
+		"",
+		"",
+	}, "\n"), out.String())
+	testutil.AssertEqual(t, 0, server.LogTails())
+}
+
+// interruptOnCreate interrupts the process once a log tailing session is
+// created, as though Ctrl-C were pressed while creating it.
+type interruptOnCreate struct{}
+
+func (interruptOnCreate) Do(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil && req.Method == http.MethodPost {
+		if p, err := os.FindProcess(os.Getpid()); err == nil {
+			p.Signal(os.Interrupt) // #nosec G104
+		}
+	}
+	return resp, err
+}
+
+// TestInterruptWhileCreating interrupts the process while the session is being
+// created, which must still end the session rather than exit.
+func TestInterruptWhileCreating(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("interrupts are sent as POSIX signals")
+	}
+	server := fakeapi.New()
+	defer server.Close()
+	id := server.AddService("compute", "wasm")
+
+	c := RootCommand{client: interruptOnCreate{}, now: time.Now}
+	c.Globals = &config.Data{Env: config.Environment{Token: "fake", Endpoint: server.URL}}
+	c.manifest.Flag.ServiceID = id
+
+	var out bytes.Buffer
+	testutil.AssertNoError(t, c.Exec(nil, &out))
+	testutil.AssertEqual(t, 0, server.LogTails())
+}
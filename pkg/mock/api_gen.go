@@ -1,3 +1,5 @@
+// Code generated by apigen. DO NOT EDIT.
+
 package mock
 
 import (
@@ -18,6 +20,7 @@ type API struct {
 	DeleteServiceFn     func(*fastly.DeleteServiceInput) error
 	SearchServiceFn     func(*fastly.SearchServiceInput) (*fastly.Service, error)
 
+	CreateVersionFn     func(*fastly.CreateVersionInput) (*fastly.Version, error)
 	CloneVersionFn      func(*fastly.CloneVersionInput) (*fastly.Version, error)
 	ListVersionsFn      func(*fastly.ListVersionsInput) ([]*fastly.Version, error)
 	UpdateVersionFn     func(*fastly.UpdateVersionInput) (*fastly.Version, error)
@@ -62,6 +65,30 @@ type API struct {
 
 	GetDictionaryInfoFn func(*fastly.GetDictionaryInfoInput) (*fastly.DictionaryInfo, error)
 
+	CreateConditionFn func(*fastly.CreateConditionInput) (*fastly.Condition, error)
+	ListConditionsFn  func(*fastly.ListConditionsInput) ([]*fastly.Condition, error)
+
+	CreateHeaderFn func(*fastly.CreateHeaderInput) (*fastly.Header, error)
+	ListHeadersFn  func(*fastly.ListHeadersInput) ([]*fastly.Header, error)
+
+	CreateVCLFn func(*fastly.CreateVCLInput) (*fastly.VCL, error)
+	ListVCLsFn  func(*fastly.ListVCLsInput) ([]*fastly.VCL, error)
+
+	CreateSnippetFn func(*fastly.CreateSnippetInput) (*fastly.Snippet, error)
+	ListSnippetsFn  func(*fastly.ListSnippetsInput) ([]*fastly.Snippet, error)
+
+	CreateGzipFn func(*fastly.CreateGzipInput) (*fastly.Gzip, error)
+	ListGzipsFn  func(*fastly.ListGzipsInput) ([]*fastly.Gzip, error)
+
+	CreateCacheSettingFn func(*fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error)
+	ListCacheSettingsFn  func(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error)
+
+	CreateRequestSettingFn func(*fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error)
+	ListRequestSettingsFn  func(*fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error)
+
+	CreateResponseObjectFn func(*fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error)
+	ListResponseObjectsFn  func(*fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error)
+
 	CreateBigQueryFn func(*fastly.CreateBigQueryInput) (*fastly.BigQuery, error)
 	ListBigQueriesFn func(*fastly.ListBigQueriesInput) ([]*fastly.BigQuery, error)
 	GetBigQueryFn    func(*fastly.GetBigQueryInput) (*fastly.BigQuery, error)
@@ -214,8 +241,28 @@ type API struct {
 
 	GetUserFn func(*fastly.GetUserInput) (*fastly.User, error)
 
-	GetRegionsFn   func() (*fastly.RegionsResponse, error)
-	GetStatsJSONFn func(i *fastly.GetStatsInput, dst interface{}) error
+	GetAPIEventsFn func(*fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error)
+
+	ListWAFsFn                        func(*fastly.ListWAFsInput) (*fastly.WAFResponse, error)
+	GetWAFFn                          func(*fastly.GetWAFInput) (*fastly.WAF, error)
+	UpdateWAFFn                       func(*fastly.UpdateWAFInput) (*fastly.WAF, error)
+	ListAllWAFVersionsFn              func(*fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error)
+	CloneWAFVersionFn                 func(*fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error)
+	DeployWAFVersionFn                func(*fastly.DeployWAFVersionInput) error
+	ListAllWAFActiveRulesFn           func(*fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error)
+	BatchModificationWAFActiveRulesFn func(*fastly.BatchModificationWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error)
+	ListAllWAFRulesFn                 func(*fastly.ListAllWAFRulesInput) (*fastly.WAFRuleResponse, error)
+
+	GetRegionsFn        func() (*fastly.RegionsResponse, error)
+	GetStatsJSONFn      func(*fastly.GetStatsInput, interface{}) error
+	GetUsageFn          func(*fastly.GetUsageInput) (*fastly.UsageResponse, error)
+	GetUsageByServiceFn func(*fastly.GetUsageInput) (*fastly.UsageByServiceResponse, error)
+
+	GetBillingFn func(*fastly.GetBillingInput) (*fastly.Billing, error)
+
+	AllDatacentersFn func() ([]fastly.Datacenter, error)
+	IPsFn            func() (fastly.IPAddrs, error)
+	IPsV6Fn          func() (fastly.IPAddrs, error)
 }
 
 // GetTokenSelf implements Interface.
@@ -243,11 +290,6 @@ func (m API) GetServiceDetails(i *fastly.GetServiceInput) (*fastly.ServiceDetail
 	return m.GetServiceDetailsFn(i)
 }
 
-// SearchService implements Interface.
-func (m API) SearchService(i *fastly.SearchServiceInput) (*fastly.Service, error) {
-	return m.SearchServiceFn(i)
-}
-
 // UpdateService implements Interface.
 func (m API) UpdateService(i *fastly.UpdateServiceInput) (*fastly.Service, error) {
 	return m.UpdateServiceFn(i)
@@ -258,6 +300,16 @@ func (m API) DeleteService(i *fastly.DeleteServiceInput) error {
 	return m.DeleteServiceFn(i)
 }
 
+// SearchService implements Interface.
+func (m API) SearchService(i *fastly.SearchServiceInput) (*fastly.Service, error) {
+	return m.SearchServiceFn(i)
+}
+
+// CreateVersion implements Interface.
+func (m API) CreateVersion(i *fastly.CreateVersionInput) (*fastly.Version, error) {
+	return m.CreateVersionFn(i)
+}
+
 // CloneVersion implements Interface.
 func (m API) CloneVersion(i *fastly.CloneVersionInput) (*fastly.Version, error) {
 	return m.CloneVersionFn(i)
@@ -438,6 +490,86 @@ func (m API) GetDictionaryInfo(i *fastly.GetDictionaryInfoInput) (*fastly.Dictio
 	return m.GetDictionaryInfoFn(i)
 }
 
+// CreateCondition implements Interface.
+func (m API) CreateCondition(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	return m.CreateConditionFn(i)
+}
+
+// ListConditions implements Interface.
+func (m API) ListConditions(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+	return m.ListConditionsFn(i)
+}
+
+// CreateHeader implements Interface.
+func (m API) CreateHeader(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+	return m.CreateHeaderFn(i)
+}
+
+// ListHeaders implements Interface.
+func (m API) ListHeaders(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+	return m.ListHeadersFn(i)
+}
+
+// CreateVCL implements Interface.
+func (m API) CreateVCL(i *fastly.CreateVCLInput) (*fastly.VCL, error) {
+	return m.CreateVCLFn(i)
+}
+
+// ListVCLs implements Interface.
+func (m API) ListVCLs(i *fastly.ListVCLsInput) ([]*fastly.VCL, error) {
+	return m.ListVCLsFn(i)
+}
+
+// CreateSnippet implements Interface.
+func (m API) CreateSnippet(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+	return m.CreateSnippetFn(i)
+}
+
+// ListSnippets implements Interface.
+func (m API) ListSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+	return m.ListSnippetsFn(i)
+}
+
+// CreateGzip implements Interface.
+func (m API) CreateGzip(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+	return m.CreateGzipFn(i)
+}
+
+// ListGzips implements Interface.
+func (m API) ListGzips(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+	return m.ListGzipsFn(i)
+}
+
+// CreateCacheSetting implements Interface.
+func (m API) CreateCacheSetting(i *fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error) {
+	return m.CreateCacheSettingFn(i)
+}
+
+// ListCacheSettings implements Interface.
+func (m API) ListCacheSettings(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	return m.ListCacheSettingsFn(i)
+}
+
+// CreateRequestSetting implements Interface.
+func (m API) CreateRequestSetting(i *fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error) {
+	return m.CreateRequestSettingFn(i)
+}
+
+// ListRequestSettings implements Interface.
+func (m API) ListRequestSettings(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+	return m.ListRequestSettingsFn(i)
+}
+
+// CreateResponseObject implements Interface.
+func (m API) CreateResponseObject(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+	return m.CreateResponseObjectFn(i)
+}
+
+// ListResponseObjects implements Interface.
+func (m API) ListResponseObjects(i *fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error) {
+	return m.ListResponseObjectsFn(i)
+}
+
 // CreateBigQuery implements Interface.
 func (m API) CreateBigQuery(i *fastly.CreateBigQueryInput) (*fastly.BigQuery, error) {
 	return m.CreateBigQueryFn(i)
@@ -1068,6 +1200,56 @@ func (m API) GetUser(i *fastly.GetUserInput) (*fastly.User, error) {
 	return m.GetUserFn(i)
 }
 
+// GetAPIEvents implements Interface.
+func (m API) GetAPIEvents(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	return m.GetAPIEventsFn(i)
+}
+
+// ListWAFs implements Interface.
+func (m API) ListWAFs(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+	return m.ListWAFsFn(i)
+}
+
+// GetWAF implements Interface.
+func (m API) GetWAF(i *fastly.GetWAFInput) (*fastly.WAF, error) {
+	return m.GetWAFFn(i)
+}
+
+// UpdateWAF implements Interface.
+func (m API) UpdateWAF(i *fastly.UpdateWAFInput) (*fastly.WAF, error) {
+	return m.UpdateWAFFn(i)
+}
+
+// ListAllWAFVersions implements Interface.
+func (m API) ListAllWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	return m.ListAllWAFVersionsFn(i)
+}
+
+// CloneWAFVersion implements Interface.
+func (m API) CloneWAFVersion(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error) {
+	return m.CloneWAFVersionFn(i)
+}
+
+// DeployWAFVersion implements Interface.
+func (m API) DeployWAFVersion(i *fastly.DeployWAFVersionInput) error {
+	return m.DeployWAFVersionFn(i)
+}
+
+// ListAllWAFActiveRules implements Interface.
+func (m API) ListAllWAFActiveRules(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error) {
+	return m.ListAllWAFActiveRulesFn(i)
+}
+
+// BatchModificationWAFActiveRules implements Interface.
+func (m API) BatchModificationWAFActiveRules(i *fastly.BatchModificationWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error) {
+	return m.BatchModificationWAFActiveRulesFn(i)
+}
+
+// ListAllWAFRules implements Interface.
+func (m API) ListAllWAFRules(i *fastly.ListAllWAFRulesInput) (*fastly.WAFRuleResponse, error) {
+	return m.ListAllWAFRulesFn(i)
+}
+
 // GetRegions implements Interface.
 func (m API) GetRegions() (*fastly.RegionsResponse, error) {
 	return m.GetRegionsFn()
@@ -1077,3 +1259,33 @@ func (m API) GetRegions() (*fastly.RegionsResponse, error) {
 func (m API) GetStatsJSON(i *fastly.GetStatsInput, dst interface{}) error {
 	return m.GetStatsJSONFn(i, dst)
 }
+
+// GetUsage implements Interface.
+func (m API) GetUsage(i *fastly.GetUsageInput) (*fastly.UsageResponse, error) {
+	return m.GetUsageFn(i)
+}
+
+// GetUsageByService implements Interface.
+func (m API) GetUsageByService(i *fastly.GetUsageInput) (*fastly.UsageByServiceResponse, error) {
+	return m.GetUsageByServiceFn(i)
+}
+
+// GetBilling implements Interface.
+func (m API) GetBilling(i *fastly.GetBillingInput) (*fastly.Billing, error) {
+	return m.GetBillingFn(i)
+}
+
+// AllDatacenters implements Interface.
+func (m API) AllDatacenters() ([]fastly.Datacenter, error) {
+	return m.AllDatacentersFn()
+}
+
+// IPs implements Interface.
+func (m API) IPs() (fastly.IPAddrs, error) {
+	return m.IPsFn()
+}
+
+// IPsV6 implements Interface.
+func (m API) IPsV6() (fastly.IPAddrs, error) {
+	return m.IPsV6Fn()
+}
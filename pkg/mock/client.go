@@ -11,3 +11,11 @@ func APIClient(a API) func(string, string) (api.Interface, error) {
 		return a, nil
 	}
 }
+
+// SpyClient takes a Spy and returns an app.ClientFactory that uses it, ignoring
+// the token and endpoint. It should only be used for tests.
+func SpyClient(s *Spy) func(string, string) (api.Interface, error) {
+	return func(token, endpoint string) (api.Interface, error) {
+		return s, nil
+	}
+}
@@ -0,0 +1,58 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// Call is a single call made through a Spy. Input and Output follow the same
+// conventions as api.Hook.
+type Call struct {
+	Method string
+	Input  interface{}
+	Output interface{}
+	Err    error
+}
+
+// Spy is an implementation of api.Interface which records every call before
+// returning the result of the wrapped client, typically an API. Its methods
+// are generated by cmd/apigen.
+type Spy struct {
+	API api.Interface
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Ensure that Spy satisfies api.Interface.
+var _ api.Interface = (*Spy)(nil)
+
+// NewSpy returns a Spy which records calls to a.
+func NewSpy(a api.Interface) *Spy {
+	return &Spy{API: a}
+}
+
+// Calls returns the calls made so far, in order.
+func (s *Spy) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// Methods returns the names of the methods called so far, in order.
+func (s *Spy) Methods() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	methods := make([]string, len(s.calls))
+	for i, c := range s.calls {
+		methods[i] = c.Method
+	}
+	return methods
+}
+
+func (s *Spy) record(method string, input, output interface{}, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, Call{Method: method, Input: input, Output: output, Err: err})
+}
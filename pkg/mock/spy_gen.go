@@ -0,0 +1,1442 @@
+// Code generated by apigen. DO NOT EDIT.
+
+package mock
+
+import (
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// GetTokenSelf implements Interface.
+func (s *Spy) GetTokenSelf() (*fastly.Token, error) {
+	o, err := s.API.GetTokenSelf()
+	s.record("GetTokenSelf", nil, o, err)
+	return o, err
+}
+
+// CreateService implements Interface.
+func (s *Spy) CreateService(i *fastly.CreateServiceInput) (*fastly.Service, error) {
+	o, err := s.API.CreateService(i)
+	s.record("CreateService", i, o, err)
+	return o, err
+}
+
+// ListServices implements Interface.
+func (s *Spy) ListServices(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+	o, err := s.API.ListServices(i)
+	s.record("ListServices", i, o, err)
+	return o, err
+}
+
+// GetService implements Interface.
+func (s *Spy) GetService(i *fastly.GetServiceInput) (*fastly.Service, error) {
+	o, err := s.API.GetService(i)
+	s.record("GetService", i, o, err)
+	return o, err
+}
+
+// GetServiceDetails implements Interface.
+func (s *Spy) GetServiceDetails(i *fastly.GetServiceInput) (*fastly.ServiceDetail, error) {
+	o, err := s.API.GetServiceDetails(i)
+	s.record("GetServiceDetails", i, o, err)
+	return o, err
+}
+
+// UpdateService implements Interface.
+func (s *Spy) UpdateService(i *fastly.UpdateServiceInput) (*fastly.Service, error) {
+	o, err := s.API.UpdateService(i)
+	s.record("UpdateService", i, o, err)
+	return o, err
+}
+
+// DeleteService implements Interface.
+func (s *Spy) DeleteService(i *fastly.DeleteServiceInput) error {
+	err := s.API.DeleteService(i)
+	s.record("DeleteService", i, nil, err)
+	return err
+}
+
+// SearchService implements Interface.
+func (s *Spy) SearchService(i *fastly.SearchServiceInput) (*fastly.Service, error) {
+	o, err := s.API.SearchService(i)
+	s.record("SearchService", i, o, err)
+	return o, err
+}
+
+// CreateVersion implements Interface.
+func (s *Spy) CreateVersion(i *fastly.CreateVersionInput) (*fastly.Version, error) {
+	o, err := s.API.CreateVersion(i)
+	s.record("CreateVersion", i, o, err)
+	return o, err
+}
+
+// CloneVersion implements Interface.
+func (s *Spy) CloneVersion(i *fastly.CloneVersionInput) (*fastly.Version, error) {
+	o, err := s.API.CloneVersion(i)
+	s.record("CloneVersion", i, o, err)
+	return o, err
+}
+
+// ListVersions implements Interface.
+func (s *Spy) ListVersions(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+	o, err := s.API.ListVersions(i)
+	s.record("ListVersions", i, o, err)
+	return o, err
+}
+
+// UpdateVersion implements Interface.
+func (s *Spy) UpdateVersion(i *fastly.UpdateVersionInput) (*fastly.Version, error) {
+	o, err := s.API.UpdateVersion(i)
+	s.record("UpdateVersion", i, o, err)
+	return o, err
+}
+
+// ActivateVersion implements Interface.
+func (s *Spy) ActivateVersion(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+	o, err := s.API.ActivateVersion(i)
+	s.record("ActivateVersion", i, o, err)
+	return o, err
+}
+
+// DeactivateVersion implements Interface.
+func (s *Spy) DeactivateVersion(i *fastly.DeactivateVersionInput) (*fastly.Version, error) {
+	o, err := s.API.DeactivateVersion(i)
+	s.record("DeactivateVersion", i, o, err)
+	return o, err
+}
+
+// LockVersion implements Interface.
+func (s *Spy) LockVersion(i *fastly.LockVersionInput) (*fastly.Version, error) {
+	o, err := s.API.LockVersion(i)
+	s.record("LockVersion", i, o, err)
+	return o, err
+}
+
+// LatestVersion implements Interface.
+func (s *Spy) LatestVersion(i *fastly.LatestVersionInput) (*fastly.Version, error) {
+	o, err := s.API.LatestVersion(i)
+	s.record("LatestVersion", i, o, err)
+	return o, err
+}
+
+// CreateDomain implements Interface.
+func (s *Spy) CreateDomain(i *fastly.CreateDomainInput) (*fastly.Domain, error) {
+	o, err := s.API.CreateDomain(i)
+	s.record("CreateDomain", i, o, err)
+	return o, err
+}
+
+// ListDomains implements Interface.
+func (s *Spy) ListDomains(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+	o, err := s.API.ListDomains(i)
+	s.record("ListDomains", i, o, err)
+	return o, err
+}
+
+// GetDomain implements Interface.
+func (s *Spy) GetDomain(i *fastly.GetDomainInput) (*fastly.Domain, error) {
+	o, err := s.API.GetDomain(i)
+	s.record("GetDomain", i, o, err)
+	return o, err
+}
+
+// UpdateDomain implements Interface.
+func (s *Spy) UpdateDomain(i *fastly.UpdateDomainInput) (*fastly.Domain, error) {
+	o, err := s.API.UpdateDomain(i)
+	s.record("UpdateDomain", i, o, err)
+	return o, err
+}
+
+// DeleteDomain implements Interface.
+func (s *Spy) DeleteDomain(i *fastly.DeleteDomainInput) error {
+	err := s.API.DeleteDomain(i)
+	s.record("DeleteDomain", i, nil, err)
+	return err
+}
+
+// CreateBackend implements Interface.
+func (s *Spy) CreateBackend(i *fastly.CreateBackendInput) (*fastly.Backend, error) {
+	o, err := s.API.CreateBackend(i)
+	s.record("CreateBackend", i, o, err)
+	return o, err
+}
+
+// ListBackends implements Interface.
+func (s *Spy) ListBackends(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+	o, err := s.API.ListBackends(i)
+	s.record("ListBackends", i, o, err)
+	return o, err
+}
+
+// GetBackend implements Interface.
+func (s *Spy) GetBackend(i *fastly.GetBackendInput) (*fastly.Backend, error) {
+	o, err := s.API.GetBackend(i)
+	s.record("GetBackend", i, o, err)
+	return o, err
+}
+
+// UpdateBackend implements Interface.
+func (s *Spy) UpdateBackend(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+	o, err := s.API.UpdateBackend(i)
+	s.record("UpdateBackend", i, o, err)
+	return o, err
+}
+
+// DeleteBackend implements Interface.
+func (s *Spy) DeleteBackend(i *fastly.DeleteBackendInput) error {
+	err := s.API.DeleteBackend(i)
+	s.record("DeleteBackend", i, nil, err)
+	return err
+}
+
+// CreateHealthCheck implements Interface.
+func (s *Spy) CreateHealthCheck(i *fastly.CreateHealthCheckInput) (*fastly.HealthCheck, error) {
+	o, err := s.API.CreateHealthCheck(i)
+	s.record("CreateHealthCheck", i, o, err)
+	return o, err
+}
+
+// ListHealthChecks implements Interface.
+func (s *Spy) ListHealthChecks(i *fastly.ListHealthChecksInput) ([]*fastly.HealthCheck, error) {
+	o, err := s.API.ListHealthChecks(i)
+	s.record("ListHealthChecks", i, o, err)
+	return o, err
+}
+
+// GetHealthCheck implements Interface.
+func (s *Spy) GetHealthCheck(i *fastly.GetHealthCheckInput) (*fastly.HealthCheck, error) {
+	o, err := s.API.GetHealthCheck(i)
+	s.record("GetHealthCheck", i, o, err)
+	return o, err
+}
+
+// UpdateHealthCheck implements Interface.
+func (s *Spy) UpdateHealthCheck(i *fastly.UpdateHealthCheckInput) (*fastly.HealthCheck, error) {
+	o, err := s.API.UpdateHealthCheck(i)
+	s.record("UpdateHealthCheck", i, o, err)
+	return o, err
+}
+
+// DeleteHealthCheck implements Interface.
+func (s *Spy) DeleteHealthCheck(i *fastly.DeleteHealthCheckInput) error {
+	err := s.API.DeleteHealthCheck(i)
+	s.record("DeleteHealthCheck", i, nil, err)
+	return err
+}
+
+// GetPackage implements Interface.
+func (s *Spy) GetPackage(i *fastly.GetPackageInput) (*fastly.Package, error) {
+	o, err := s.API.GetPackage(i)
+	s.record("GetPackage", i, o, err)
+	return o, err
+}
+
+// UpdatePackage implements Interface.
+func (s *Spy) UpdatePackage(i *fastly.UpdatePackageInput) (*fastly.Package, error) {
+	o, err := s.API.UpdatePackage(i)
+	s.record("UpdatePackage", i, o, err)
+	return o, err
+}
+
+// CreateDictionary implements Interface.
+func (s *Spy) CreateDictionary(i *fastly.CreateDictionaryInput) (*fastly.Dictionary, error) {
+	o, err := s.API.CreateDictionary(i)
+	s.record("CreateDictionary", i, o, err)
+	return o, err
+}
+
+// GetDictionary implements Interface.
+func (s *Spy) GetDictionary(i *fastly.GetDictionaryInput) (*fastly.Dictionary, error) {
+	o, err := s.API.GetDictionary(i)
+	s.record("GetDictionary", i, o, err)
+	return o, err
+}
+
+// DeleteDictionary implements Interface.
+func (s *Spy) DeleteDictionary(i *fastly.DeleteDictionaryInput) error {
+	err := s.API.DeleteDictionary(i)
+	s.record("DeleteDictionary", i, nil, err)
+	return err
+}
+
+// ListDictionaries implements Interface.
+func (s *Spy) ListDictionaries(i *fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) {
+	o, err := s.API.ListDictionaries(i)
+	s.record("ListDictionaries", i, o, err)
+	return o, err
+}
+
+// UpdateDictionary implements Interface.
+func (s *Spy) UpdateDictionary(i *fastly.UpdateDictionaryInput) (*fastly.Dictionary, error) {
+	o, err := s.API.UpdateDictionary(i)
+	s.record("UpdateDictionary", i, o, err)
+	return o, err
+}
+
+// ListDictionaryItems implements Interface.
+func (s *Spy) ListDictionaryItems(i *fastly.ListDictionaryItemsInput) ([]*fastly.DictionaryItem, error) {
+	o, err := s.API.ListDictionaryItems(i)
+	s.record("ListDictionaryItems", i, o, err)
+	return o, err
+}
+
+// GetDictionaryItem implements Interface.
+func (s *Spy) GetDictionaryItem(i *fastly.GetDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	o, err := s.API.GetDictionaryItem(i)
+	s.record("GetDictionaryItem", i, o, err)
+	return o, err
+}
+
+// CreateDictionaryItem implements Interface.
+func (s *Spy) CreateDictionaryItem(i *fastly.CreateDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	o, err := s.API.CreateDictionaryItem(i)
+	s.record("CreateDictionaryItem", i, o, err)
+	return o, err
+}
+
+// UpdateDictionaryItem implements Interface.
+func (s *Spy) UpdateDictionaryItem(i *fastly.UpdateDictionaryItemInput) (*fastly.DictionaryItem, error) {
+	o, err := s.API.UpdateDictionaryItem(i)
+	s.record("UpdateDictionaryItem", i, o, err)
+	return o, err
+}
+
+// DeleteDictionaryItem implements Interface.
+func (s *Spy) DeleteDictionaryItem(i *fastly.DeleteDictionaryItemInput) error {
+	err := s.API.DeleteDictionaryItem(i)
+	s.record("DeleteDictionaryItem", i, nil, err)
+	return err
+}
+
+// BatchModifyDictionaryItems implements Interface.
+func (s *Spy) BatchModifyDictionaryItems(i *fastly.BatchModifyDictionaryItemsInput) error {
+	err := s.API.BatchModifyDictionaryItems(i)
+	s.record("BatchModifyDictionaryItems", i, nil, err)
+	return err
+}
+
+// GetDictionaryInfo implements Interface.
+func (s *Spy) GetDictionaryInfo(i *fastly.GetDictionaryInfoInput) (*fastly.DictionaryInfo, error) {
+	o, err := s.API.GetDictionaryInfo(i)
+	s.record("GetDictionaryInfo", i, o, err)
+	return o, err
+}
+
+// CreateCondition implements Interface.
+func (s *Spy) CreateCondition(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	o, err := s.API.CreateCondition(i)
+	s.record("CreateCondition", i, o, err)
+	return o, err
+}
+
+// ListConditions implements Interface.
+func (s *Spy) ListConditions(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+	o, err := s.API.ListConditions(i)
+	s.record("ListConditions", i, o, err)
+	return o, err
+}
+
+// CreateHeader implements Interface.
+func (s *Spy) CreateHeader(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+	o, err := s.API.CreateHeader(i)
+	s.record("CreateHeader", i, o, err)
+	return o, err
+}
+
+// ListHeaders implements Interface.
+func (s *Spy) ListHeaders(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+	o, err := s.API.ListHeaders(i)
+	s.record("ListHeaders", i, o, err)
+	return o, err
+}
+
+// CreateVCL implements Interface.
+func (s *Spy) CreateVCL(i *fastly.CreateVCLInput) (*fastly.VCL, error) {
+	o, err := s.API.CreateVCL(i)
+	s.record("CreateVCL", i, o, err)
+	return o, err
+}
+
+// ListVCLs implements Interface.
+func (s *Spy) ListVCLs(i *fastly.ListVCLsInput) ([]*fastly.VCL, error) {
+	o, err := s.API.ListVCLs(i)
+	s.record("ListVCLs", i, o, err)
+	return o, err
+}
+
+// CreateSnippet implements Interface.
+func (s *Spy) CreateSnippet(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
+	o, err := s.API.CreateSnippet(i)
+	s.record("CreateSnippet", i, o, err)
+	return o, err
+}
+
+// ListSnippets implements Interface.
+func (s *Spy) ListSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error) {
+	o, err := s.API.ListSnippets(i)
+	s.record("ListSnippets", i, o, err)
+	return o, err
+}
+
+// CreateGzip implements Interface.
+func (s *Spy) CreateGzip(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+	o, err := s.API.CreateGzip(i)
+	s.record("CreateGzip", i, o, err)
+	return o, err
+}
+
+// ListGzips implements Interface.
+func (s *Spy) ListGzips(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+	o, err := s.API.ListGzips(i)
+	s.record("ListGzips", i, o, err)
+	return o, err
+}
+
+// CreateCacheSetting implements Interface.
+func (s *Spy) CreateCacheSetting(i *fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error) {
+	o, err := s.API.CreateCacheSetting(i)
+	s.record("CreateCacheSetting", i, o, err)
+	return o, err
+}
+
+// ListCacheSettings implements Interface.
+func (s *Spy) ListCacheSettings(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	o, err := s.API.ListCacheSettings(i)
+	s.record("ListCacheSettings", i, o, err)
+	return o, err
+}
+
+// CreateRequestSetting implements Interface.
+func (s *Spy) CreateRequestSetting(i *fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error) {
+	o, err := s.API.CreateRequestSetting(i)
+	s.record("CreateRequestSetting", i, o, err)
+	return o, err
+}
+
+// ListRequestSettings implements Interface.
+func (s *Spy) ListRequestSettings(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+	o, err := s.API.ListRequestSettings(i)
+	s.record("ListRequestSettings", i, o, err)
+	return o, err
+}
+
+// CreateResponseObject implements Interface.
+func (s *Spy) CreateResponseObject(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+	o, err := s.API.CreateResponseObject(i)
+	s.record("CreateResponseObject", i, o, err)
+	return o, err
+}
+
+// ListResponseObjects implements Interface.
+func (s *Spy) ListResponseObjects(i *fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error) {
+	o, err := s.API.ListResponseObjects(i)
+	s.record("ListResponseObjects", i, o, err)
+	return o, err
+}
+
+// CreateBigQuery implements Interface.
+func (s *Spy) CreateBigQuery(i *fastly.CreateBigQueryInput) (*fastly.BigQuery, error) {
+	o, err := s.API.CreateBigQuery(i)
+	s.record("CreateBigQuery", i, o, err)
+	return o, err
+}
+
+// ListBigQueries implements Interface.
+func (s *Spy) ListBigQueries(i *fastly.ListBigQueriesInput) ([]*fastly.BigQuery, error) {
+	o, err := s.API.ListBigQueries(i)
+	s.record("ListBigQueries", i, o, err)
+	return o, err
+}
+
+// GetBigQuery implements Interface.
+func (s *Spy) GetBigQuery(i *fastly.GetBigQueryInput) (*fastly.BigQuery, error) {
+	o, err := s.API.GetBigQuery(i)
+	s.record("GetBigQuery", i, o, err)
+	return o, err
+}
+
+// UpdateBigQuery implements Interface.
+func (s *Spy) UpdateBigQuery(i *fastly.UpdateBigQueryInput) (*fastly.BigQuery, error) {
+	o, err := s.API.UpdateBigQuery(i)
+	s.record("UpdateBigQuery", i, o, err)
+	return o, err
+}
+
+// DeleteBigQuery implements Interface.
+func (s *Spy) DeleteBigQuery(i *fastly.DeleteBigQueryInput) error {
+	err := s.API.DeleteBigQuery(i)
+	s.record("DeleteBigQuery", i, nil, err)
+	return err
+}
+
+// CreateS3 implements Interface.
+func (s *Spy) CreateS3(i *fastly.CreateS3Input) (*fastly.S3, error) {
+	o, err := s.API.CreateS3(i)
+	s.record("CreateS3", i, o, err)
+	return o, err
+}
+
+// ListS3s implements Interface.
+func (s *Spy) ListS3s(i *fastly.ListS3sInput) ([]*fastly.S3, error) {
+	o, err := s.API.ListS3s(i)
+	s.record("ListS3s", i, o, err)
+	return o, err
+}
+
+// GetS3 implements Interface.
+func (s *Spy) GetS3(i *fastly.GetS3Input) (*fastly.S3, error) {
+	o, err := s.API.GetS3(i)
+	s.record("GetS3", i, o, err)
+	return o, err
+}
+
+// UpdateS3 implements Interface.
+func (s *Spy) UpdateS3(i *fastly.UpdateS3Input) (*fastly.S3, error) {
+	o, err := s.API.UpdateS3(i)
+	s.record("UpdateS3", i, o, err)
+	return o, err
+}
+
+// DeleteS3 implements Interface.
+func (s *Spy) DeleteS3(i *fastly.DeleteS3Input) error {
+	err := s.API.DeleteS3(i)
+	s.record("DeleteS3", i, nil, err)
+	return err
+}
+
+// CreateKinesis implements Interface.
+func (s *Spy) CreateKinesis(i *fastly.CreateKinesisInput) (*fastly.Kinesis, error) {
+	o, err := s.API.CreateKinesis(i)
+	s.record("CreateKinesis", i, o, err)
+	return o, err
+}
+
+// ListKineses implements Interface.
+func (s *Spy) ListKineses(i *fastly.ListKinesesInput) ([]*fastly.Kinesis, error) {
+	o, err := s.API.ListKineses(i)
+	s.record("ListKineses", i, o, err)
+	return o, err
+}
+
+// GetKinesis implements Interface.
+func (s *Spy) GetKinesis(i *fastly.GetKinesisInput) (*fastly.Kinesis, error) {
+	o, err := s.API.GetKinesis(i)
+	s.record("GetKinesis", i, o, err)
+	return o, err
+}
+
+// UpdateKinesis implements Interface.
+func (s *Spy) UpdateKinesis(i *fastly.UpdateKinesisInput) (*fastly.Kinesis, error) {
+	o, err := s.API.UpdateKinesis(i)
+	s.record("UpdateKinesis", i, o, err)
+	return o, err
+}
+
+// DeleteKinesis implements Interface.
+func (s *Spy) DeleteKinesis(i *fastly.DeleteKinesisInput) error {
+	err := s.API.DeleteKinesis(i)
+	s.record("DeleteKinesis", i, nil, err)
+	return err
+}
+
+// CreateSyslog implements Interface.
+func (s *Spy) CreateSyslog(i *fastly.CreateSyslogInput) (*fastly.Syslog, error) {
+	o, err := s.API.CreateSyslog(i)
+	s.record("CreateSyslog", i, o, err)
+	return o, err
+}
+
+// ListSyslogs implements Interface.
+func (s *Spy) ListSyslogs(i *fastly.ListSyslogsInput) ([]*fastly.Syslog, error) {
+	o, err := s.API.ListSyslogs(i)
+	s.record("ListSyslogs", i, o, err)
+	return o, err
+}
+
+// GetSyslog implements Interface.
+func (s *Spy) GetSyslog(i *fastly.GetSyslogInput) (*fastly.Syslog, error) {
+	o, err := s.API.GetSyslog(i)
+	s.record("GetSyslog", i, o, err)
+	return o, err
+}
+
+// UpdateSyslog implements Interface.
+func (s *Spy) UpdateSyslog(i *fastly.UpdateSyslogInput) (*fastly.Syslog, error) {
+	o, err := s.API.UpdateSyslog(i)
+	s.record("UpdateSyslog", i, o, err)
+	return o, err
+}
+
+// DeleteSyslog implements Interface.
+func (s *Spy) DeleteSyslog(i *fastly.DeleteSyslogInput) error {
+	err := s.API.DeleteSyslog(i)
+	s.record("DeleteSyslog", i, nil, err)
+	return err
+}
+
+// CreateLogentries implements Interface.
+func (s *Spy) CreateLogentries(i *fastly.CreateLogentriesInput) (*fastly.Logentries, error) {
+	o, err := s.API.CreateLogentries(i)
+	s.record("CreateLogentries", i, o, err)
+	return o, err
+}
+
+// ListLogentries implements Interface.
+func (s *Spy) ListLogentries(i *fastly.ListLogentriesInput) ([]*fastly.Logentries, error) {
+	o, err := s.API.ListLogentries(i)
+	s.record("ListLogentries", i, o, err)
+	return o, err
+}
+
+// GetLogentries implements Interface.
+func (s *Spy) GetLogentries(i *fastly.GetLogentriesInput) (*fastly.Logentries, error) {
+	o, err := s.API.GetLogentries(i)
+	s.record("GetLogentries", i, o, err)
+	return o, err
+}
+
+// UpdateLogentries implements Interface.
+func (s *Spy) UpdateLogentries(i *fastly.UpdateLogentriesInput) (*fastly.Logentries, error) {
+	o, err := s.API.UpdateLogentries(i)
+	s.record("UpdateLogentries", i, o, err)
+	return o, err
+}
+
+// DeleteLogentries implements Interface.
+func (s *Spy) DeleteLogentries(i *fastly.DeleteLogentriesInput) error {
+	err := s.API.DeleteLogentries(i)
+	s.record("DeleteLogentries", i, nil, err)
+	return err
+}
+
+// CreatePapertrail implements Interface.
+func (s *Spy) CreatePapertrail(i *fastly.CreatePapertrailInput) (*fastly.Papertrail, error) {
+	o, err := s.API.CreatePapertrail(i)
+	s.record("CreatePapertrail", i, o, err)
+	return o, err
+}
+
+// ListPapertrails implements Interface.
+func (s *Spy) ListPapertrails(i *fastly.ListPapertrailsInput) ([]*fastly.Papertrail, error) {
+	o, err := s.API.ListPapertrails(i)
+	s.record("ListPapertrails", i, o, err)
+	return o, err
+}
+
+// GetPapertrail implements Interface.
+func (s *Spy) GetPapertrail(i *fastly.GetPapertrailInput) (*fastly.Papertrail, error) {
+	o, err := s.API.GetPapertrail(i)
+	s.record("GetPapertrail", i, o, err)
+	return o, err
+}
+
+// UpdatePapertrail implements Interface.
+func (s *Spy) UpdatePapertrail(i *fastly.UpdatePapertrailInput) (*fastly.Papertrail, error) {
+	o, err := s.API.UpdatePapertrail(i)
+	s.record("UpdatePapertrail", i, o, err)
+	return o, err
+}
+
+// DeletePapertrail implements Interface.
+func (s *Spy) DeletePapertrail(i *fastly.DeletePapertrailInput) error {
+	err := s.API.DeletePapertrail(i)
+	s.record("DeletePapertrail", i, nil, err)
+	return err
+}
+
+// CreateSumologic implements Interface.
+func (s *Spy) CreateSumologic(i *fastly.CreateSumologicInput) (*fastly.Sumologic, error) {
+	o, err := s.API.CreateSumologic(i)
+	s.record("CreateSumologic", i, o, err)
+	return o, err
+}
+
+// ListSumologics implements Interface.
+func (s *Spy) ListSumologics(i *fastly.ListSumologicsInput) ([]*fastly.Sumologic, error) {
+	o, err := s.API.ListSumologics(i)
+	s.record("ListSumologics", i, o, err)
+	return o, err
+}
+
+// GetSumologic implements Interface.
+func (s *Spy) GetSumologic(i *fastly.GetSumologicInput) (*fastly.Sumologic, error) {
+	o, err := s.API.GetSumologic(i)
+	s.record("GetSumologic", i, o, err)
+	return o, err
+}
+
+// UpdateSumologic implements Interface.
+func (s *Spy) UpdateSumologic(i *fastly.UpdateSumologicInput) (*fastly.Sumologic, error) {
+	o, err := s.API.UpdateSumologic(i)
+	s.record("UpdateSumologic", i, o, err)
+	return o, err
+}
+
+// DeleteSumologic implements Interface.
+func (s *Spy) DeleteSumologic(i *fastly.DeleteSumologicInput) error {
+	err := s.API.DeleteSumologic(i)
+	s.record("DeleteSumologic", i, nil, err)
+	return err
+}
+
+// CreateGCS implements Interface.
+func (s *Spy) CreateGCS(i *fastly.CreateGCSInput) (*fastly.GCS, error) {
+	o, err := s.API.CreateGCS(i)
+	s.record("CreateGCS", i, o, err)
+	return o, err
+}
+
+// ListGCSs implements Interface.
+func (s *Spy) ListGCSs(i *fastly.ListGCSsInput) ([]*fastly.GCS, error) {
+	o, err := s.API.ListGCSs(i)
+	s.record("ListGCSs", i, o, err)
+	return o, err
+}
+
+// GetGCS implements Interface.
+func (s *Spy) GetGCS(i *fastly.GetGCSInput) (*fastly.GCS, error) {
+	o, err := s.API.GetGCS(i)
+	s.record("GetGCS", i, o, err)
+	return o, err
+}
+
+// UpdateGCS implements Interface.
+func (s *Spy) UpdateGCS(i *fastly.UpdateGCSInput) (*fastly.GCS, error) {
+	o, err := s.API.UpdateGCS(i)
+	s.record("UpdateGCS", i, o, err)
+	return o, err
+}
+
+// DeleteGCS implements Interface.
+func (s *Spy) DeleteGCS(i *fastly.DeleteGCSInput) error {
+	err := s.API.DeleteGCS(i)
+	s.record("DeleteGCS", i, nil, err)
+	return err
+}
+
+// CreateFTP implements Interface.
+func (s *Spy) CreateFTP(i *fastly.CreateFTPInput) (*fastly.FTP, error) {
+	o, err := s.API.CreateFTP(i)
+	s.record("CreateFTP", i, o, err)
+	return o, err
+}
+
+// ListFTPs implements Interface.
+func (s *Spy) ListFTPs(i *fastly.ListFTPsInput) ([]*fastly.FTP, error) {
+	o, err := s.API.ListFTPs(i)
+	s.record("ListFTPs", i, o, err)
+	return o, err
+}
+
+// GetFTP implements Interface.
+func (s *Spy) GetFTP(i *fastly.GetFTPInput) (*fastly.FTP, error) {
+	o, err := s.API.GetFTP(i)
+	s.record("GetFTP", i, o, err)
+	return o, err
+}
+
+// UpdateFTP implements Interface.
+func (s *Spy) UpdateFTP(i *fastly.UpdateFTPInput) (*fastly.FTP, error) {
+	o, err := s.API.UpdateFTP(i)
+	s.record("UpdateFTP", i, o, err)
+	return o, err
+}
+
+// DeleteFTP implements Interface.
+func (s *Spy) DeleteFTP(i *fastly.DeleteFTPInput) error {
+	err := s.API.DeleteFTP(i)
+	s.record("DeleteFTP", i, nil, err)
+	return err
+}
+
+// CreateSplunk implements Interface.
+func (s *Spy) CreateSplunk(i *fastly.CreateSplunkInput) (*fastly.Splunk, error) {
+	o, err := s.API.CreateSplunk(i)
+	s.record("CreateSplunk", i, o, err)
+	return o, err
+}
+
+// ListSplunks implements Interface.
+func (s *Spy) ListSplunks(i *fastly.ListSplunksInput) ([]*fastly.Splunk, error) {
+	o, err := s.API.ListSplunks(i)
+	s.record("ListSplunks", i, o, err)
+	return o, err
+}
+
+// GetSplunk implements Interface.
+func (s *Spy) GetSplunk(i *fastly.GetSplunkInput) (*fastly.Splunk, error) {
+	o, err := s.API.GetSplunk(i)
+	s.record("GetSplunk", i, o, err)
+	return o, err
+}
+
+// UpdateSplunk implements Interface.
+func (s *Spy) UpdateSplunk(i *fastly.UpdateSplunkInput) (*fastly.Splunk, error) {
+	o, err := s.API.UpdateSplunk(i)
+	s.record("UpdateSplunk", i, o, err)
+	return o, err
+}
+
+// DeleteSplunk implements Interface.
+func (s *Spy) DeleteSplunk(i *fastly.DeleteSplunkInput) error {
+	err := s.API.DeleteSplunk(i)
+	s.record("DeleteSplunk", i, nil, err)
+	return err
+}
+
+// CreateScalyr implements Interface.
+func (s *Spy) CreateScalyr(i *fastly.CreateScalyrInput) (*fastly.Scalyr, error) {
+	o, err := s.API.CreateScalyr(i)
+	s.record("CreateScalyr", i, o, err)
+	return o, err
+}
+
+// ListScalyrs implements Interface.
+func (s *Spy) ListScalyrs(i *fastly.ListScalyrsInput) ([]*fastly.Scalyr, error) {
+	o, err := s.API.ListScalyrs(i)
+	s.record("ListScalyrs", i, o, err)
+	return o, err
+}
+
+// GetScalyr implements Interface.
+func (s *Spy) GetScalyr(i *fastly.GetScalyrInput) (*fastly.Scalyr, error) {
+	o, err := s.API.GetScalyr(i)
+	s.record("GetScalyr", i, o, err)
+	return o, err
+}
+
+// UpdateScalyr implements Interface.
+func (s *Spy) UpdateScalyr(i *fastly.UpdateScalyrInput) (*fastly.Scalyr, error) {
+	o, err := s.API.UpdateScalyr(i)
+	s.record("UpdateScalyr", i, o, err)
+	return o, err
+}
+
+// DeleteScalyr implements Interface.
+func (s *Spy) DeleteScalyr(i *fastly.DeleteScalyrInput) error {
+	err := s.API.DeleteScalyr(i)
+	s.record("DeleteScalyr", i, nil, err)
+	return err
+}
+
+// CreateLoggly implements Interface.
+func (s *Spy) CreateLoggly(i *fastly.CreateLogglyInput) (*fastly.Loggly, error) {
+	o, err := s.API.CreateLoggly(i)
+	s.record("CreateLoggly", i, o, err)
+	return o, err
+}
+
+// ListLoggly implements Interface.
+func (s *Spy) ListLoggly(i *fastly.ListLogglyInput) ([]*fastly.Loggly, error) {
+	o, err := s.API.ListLoggly(i)
+	s.record("ListLoggly", i, o, err)
+	return o, err
+}
+
+// GetLoggly implements Interface.
+func (s *Spy) GetLoggly(i *fastly.GetLogglyInput) (*fastly.Loggly, error) {
+	o, err := s.API.GetLoggly(i)
+	s.record("GetLoggly", i, o, err)
+	return o, err
+}
+
+// UpdateLoggly implements Interface.
+func (s *Spy) UpdateLoggly(i *fastly.UpdateLogglyInput) (*fastly.Loggly, error) {
+	o, err := s.API.UpdateLoggly(i)
+	s.record("UpdateLoggly", i, o, err)
+	return o, err
+}
+
+// DeleteLoggly implements Interface.
+func (s *Spy) DeleteLoggly(i *fastly.DeleteLogglyInput) error {
+	err := s.API.DeleteLoggly(i)
+	s.record("DeleteLoggly", i, nil, err)
+	return err
+}
+
+// CreateHoneycomb implements Interface.
+func (s *Spy) CreateHoneycomb(i *fastly.CreateHoneycombInput) (*fastly.Honeycomb, error) {
+	o, err := s.API.CreateHoneycomb(i)
+	s.record("CreateHoneycomb", i, o, err)
+	return o, err
+}
+
+// ListHoneycombs implements Interface.
+func (s *Spy) ListHoneycombs(i *fastly.ListHoneycombsInput) ([]*fastly.Honeycomb, error) {
+	o, err := s.API.ListHoneycombs(i)
+	s.record("ListHoneycombs", i, o, err)
+	return o, err
+}
+
+// GetHoneycomb implements Interface.
+func (s *Spy) GetHoneycomb(i *fastly.GetHoneycombInput) (*fastly.Honeycomb, error) {
+	o, err := s.API.GetHoneycomb(i)
+	s.record("GetHoneycomb", i, o, err)
+	return o, err
+}
+
+// UpdateHoneycomb implements Interface.
+func (s *Spy) UpdateHoneycomb(i *fastly.UpdateHoneycombInput) (*fastly.Honeycomb, error) {
+	o, err := s.API.UpdateHoneycomb(i)
+	s.record("UpdateHoneycomb", i, o, err)
+	return o, err
+}
+
+// DeleteHoneycomb implements Interface.
+func (s *Spy) DeleteHoneycomb(i *fastly.DeleteHoneycombInput) error {
+	err := s.API.DeleteHoneycomb(i)
+	s.record("DeleteHoneycomb", i, nil, err)
+	return err
+}
+
+// CreateHeroku implements Interface.
+func (s *Spy) CreateHeroku(i *fastly.CreateHerokuInput) (*fastly.Heroku, error) {
+	o, err := s.API.CreateHeroku(i)
+	s.record("CreateHeroku", i, o, err)
+	return o, err
+}
+
+// ListHerokus implements Interface.
+func (s *Spy) ListHerokus(i *fastly.ListHerokusInput) ([]*fastly.Heroku, error) {
+	o, err := s.API.ListHerokus(i)
+	s.record("ListHerokus", i, o, err)
+	return o, err
+}
+
+// GetHeroku implements Interface.
+func (s *Spy) GetHeroku(i *fastly.GetHerokuInput) (*fastly.Heroku, error) {
+	o, err := s.API.GetHeroku(i)
+	s.record("GetHeroku", i, o, err)
+	return o, err
+}
+
+// UpdateHeroku implements Interface.
+func (s *Spy) UpdateHeroku(i *fastly.UpdateHerokuInput) (*fastly.Heroku, error) {
+	o, err := s.API.UpdateHeroku(i)
+	s.record("UpdateHeroku", i, o, err)
+	return o, err
+}
+
+// DeleteHeroku implements Interface.
+func (s *Spy) DeleteHeroku(i *fastly.DeleteHerokuInput) error {
+	err := s.API.DeleteHeroku(i)
+	s.record("DeleteHeroku", i, nil, err)
+	return err
+}
+
+// CreateSFTP implements Interface.
+func (s *Spy) CreateSFTP(i *fastly.CreateSFTPInput) (*fastly.SFTP, error) {
+	o, err := s.API.CreateSFTP(i)
+	s.record("CreateSFTP", i, o, err)
+	return o, err
+}
+
+// ListSFTPs implements Interface.
+func (s *Spy) ListSFTPs(i *fastly.ListSFTPsInput) ([]*fastly.SFTP, error) {
+	o, err := s.API.ListSFTPs(i)
+	s.record("ListSFTPs", i, o, err)
+	return o, err
+}
+
+// GetSFTP implements Interface.
+func (s *Spy) GetSFTP(i *fastly.GetSFTPInput) (*fastly.SFTP, error) {
+	o, err := s.API.GetSFTP(i)
+	s.record("GetSFTP", i, o, err)
+	return o, err
+}
+
+// UpdateSFTP implements Interface.
+func (s *Spy) UpdateSFTP(i *fastly.UpdateSFTPInput) (*fastly.SFTP, error) {
+	o, err := s.API.UpdateSFTP(i)
+	s.record("UpdateSFTP", i, o, err)
+	return o, err
+}
+
+// DeleteSFTP implements Interface.
+func (s *Spy) DeleteSFTP(i *fastly.DeleteSFTPInput) error {
+	err := s.API.DeleteSFTP(i)
+	s.record("DeleteSFTP", i, nil, err)
+	return err
+}
+
+// CreateLogshuttle implements Interface.
+func (s *Spy) CreateLogshuttle(i *fastly.CreateLogshuttleInput) (*fastly.Logshuttle, error) {
+	o, err := s.API.CreateLogshuttle(i)
+	s.record("CreateLogshuttle", i, o, err)
+	return o, err
+}
+
+// ListLogshuttles implements Interface.
+func (s *Spy) ListLogshuttles(i *fastly.ListLogshuttlesInput) ([]*fastly.Logshuttle, error) {
+	o, err := s.API.ListLogshuttles(i)
+	s.record("ListLogshuttles", i, o, err)
+	return o, err
+}
+
+// GetLogshuttle implements Interface.
+func (s *Spy) GetLogshuttle(i *fastly.GetLogshuttleInput) (*fastly.Logshuttle, error) {
+	o, err := s.API.GetLogshuttle(i)
+	s.record("GetLogshuttle", i, o, err)
+	return o, err
+}
+
+// UpdateLogshuttle implements Interface.
+func (s *Spy) UpdateLogshuttle(i *fastly.UpdateLogshuttleInput) (*fastly.Logshuttle, error) {
+	o, err := s.API.UpdateLogshuttle(i)
+	s.record("UpdateLogshuttle", i, o, err)
+	return o, err
+}
+
+// DeleteLogshuttle implements Interface.
+func (s *Spy) DeleteLogshuttle(i *fastly.DeleteLogshuttleInput) error {
+	err := s.API.DeleteLogshuttle(i)
+	s.record("DeleteLogshuttle", i, nil, err)
+	return err
+}
+
+// CreateCloudfiles implements Interface.
+func (s *Spy) CreateCloudfiles(i *fastly.CreateCloudfilesInput) (*fastly.Cloudfiles, error) {
+	o, err := s.API.CreateCloudfiles(i)
+	s.record("CreateCloudfiles", i, o, err)
+	return o, err
+}
+
+// ListCloudfiles implements Interface.
+func (s *Spy) ListCloudfiles(i *fastly.ListCloudfilesInput) ([]*fastly.Cloudfiles, error) {
+	o, err := s.API.ListCloudfiles(i)
+	s.record("ListCloudfiles", i, o, err)
+	return o, err
+}
+
+// GetCloudfiles implements Interface.
+func (s *Spy) GetCloudfiles(i *fastly.GetCloudfilesInput) (*fastly.Cloudfiles, error) {
+	o, err := s.API.GetCloudfiles(i)
+	s.record("GetCloudfiles", i, o, err)
+	return o, err
+}
+
+// UpdateCloudfiles implements Interface.
+func (s *Spy) UpdateCloudfiles(i *fastly.UpdateCloudfilesInput) (*fastly.Cloudfiles, error) {
+	o, err := s.API.UpdateCloudfiles(i)
+	s.record("UpdateCloudfiles", i, o, err)
+	return o, err
+}
+
+// DeleteCloudfiles implements Interface.
+func (s *Spy) DeleteCloudfiles(i *fastly.DeleteCloudfilesInput) error {
+	err := s.API.DeleteCloudfiles(i)
+	s.record("DeleteCloudfiles", i, nil, err)
+	return err
+}
+
+// CreateDigitalOcean implements Interface.
+func (s *Spy) CreateDigitalOcean(i *fastly.CreateDigitalOceanInput) (*fastly.DigitalOcean, error) {
+	o, err := s.API.CreateDigitalOcean(i)
+	s.record("CreateDigitalOcean", i, o, err)
+	return o, err
+}
+
+// ListDigitalOceans implements Interface.
+func (s *Spy) ListDigitalOceans(i *fastly.ListDigitalOceansInput) ([]*fastly.DigitalOcean, error) {
+	o, err := s.API.ListDigitalOceans(i)
+	s.record("ListDigitalOceans", i, o, err)
+	return o, err
+}
+
+// GetDigitalOcean implements Interface.
+func (s *Spy) GetDigitalOcean(i *fastly.GetDigitalOceanInput) (*fastly.DigitalOcean, error) {
+	o, err := s.API.GetDigitalOcean(i)
+	s.record("GetDigitalOcean", i, o, err)
+	return o, err
+}
+
+// UpdateDigitalOcean implements Interface.
+func (s *Spy) UpdateDigitalOcean(i *fastly.UpdateDigitalOceanInput) (*fastly.DigitalOcean, error) {
+	o, err := s.API.UpdateDigitalOcean(i)
+	s.record("UpdateDigitalOcean", i, o, err)
+	return o, err
+}
+
+// DeleteDigitalOcean implements Interface.
+func (s *Spy) DeleteDigitalOcean(i *fastly.DeleteDigitalOceanInput) error {
+	err := s.API.DeleteDigitalOcean(i)
+	s.record("DeleteDigitalOcean", i, nil, err)
+	return err
+}
+
+// CreateElasticsearch implements Interface.
+func (s *Spy) CreateElasticsearch(i *fastly.CreateElasticsearchInput) (*fastly.Elasticsearch, error) {
+	o, err := s.API.CreateElasticsearch(i)
+	s.record("CreateElasticsearch", i, o, err)
+	return o, err
+}
+
+// ListElasticsearch implements Interface.
+func (s *Spy) ListElasticsearch(i *fastly.ListElasticsearchInput) ([]*fastly.Elasticsearch, error) {
+	o, err := s.API.ListElasticsearch(i)
+	s.record("ListElasticsearch", i, o, err)
+	return o, err
+}
+
+// GetElasticsearch implements Interface.
+func (s *Spy) GetElasticsearch(i *fastly.GetElasticsearchInput) (*fastly.Elasticsearch, error) {
+	o, err := s.API.GetElasticsearch(i)
+	s.record("GetElasticsearch", i, o, err)
+	return o, err
+}
+
+// UpdateElasticsearch implements Interface.
+func (s *Spy) UpdateElasticsearch(i *fastly.UpdateElasticsearchInput) (*fastly.Elasticsearch, error) {
+	o, err := s.API.UpdateElasticsearch(i)
+	s.record("UpdateElasticsearch", i, o, err)
+	return o, err
+}
+
+// DeleteElasticsearch implements Interface.
+func (s *Spy) DeleteElasticsearch(i *fastly.DeleteElasticsearchInput) error {
+	err := s.API.DeleteElasticsearch(i)
+	s.record("DeleteElasticsearch", i, nil, err)
+	return err
+}
+
+// CreateBlobStorage implements Interface.
+func (s *Spy) CreateBlobStorage(i *fastly.CreateBlobStorageInput) (*fastly.BlobStorage, error) {
+	o, err := s.API.CreateBlobStorage(i)
+	s.record("CreateBlobStorage", i, o, err)
+	return o, err
+}
+
+// ListBlobStorages implements Interface.
+func (s *Spy) ListBlobStorages(i *fastly.ListBlobStoragesInput) ([]*fastly.BlobStorage, error) {
+	o, err := s.API.ListBlobStorages(i)
+	s.record("ListBlobStorages", i, o, err)
+	return o, err
+}
+
+// GetBlobStorage implements Interface.
+func (s *Spy) GetBlobStorage(i *fastly.GetBlobStorageInput) (*fastly.BlobStorage, error) {
+	o, err := s.API.GetBlobStorage(i)
+	s.record("GetBlobStorage", i, o, err)
+	return o, err
+}
+
+// UpdateBlobStorage implements Interface.
+func (s *Spy) UpdateBlobStorage(i *fastly.UpdateBlobStorageInput) (*fastly.BlobStorage, error) {
+	o, err := s.API.UpdateBlobStorage(i)
+	s.record("UpdateBlobStorage", i, o, err)
+	return o, err
+}
+
+// DeleteBlobStorage implements Interface.
+func (s *Spy) DeleteBlobStorage(i *fastly.DeleteBlobStorageInput) error {
+	err := s.API.DeleteBlobStorage(i)
+	s.record("DeleteBlobStorage", i, nil, err)
+	return err
+}
+
+// CreateDatadog implements Interface.
+func (s *Spy) CreateDatadog(i *fastly.CreateDatadogInput) (*fastly.Datadog, error) {
+	o, err := s.API.CreateDatadog(i)
+	s.record("CreateDatadog", i, o, err)
+	return o, err
+}
+
+// ListDatadog implements Interface.
+func (s *Spy) ListDatadog(i *fastly.ListDatadogInput) ([]*fastly.Datadog, error) {
+	o, err := s.API.ListDatadog(i)
+	s.record("ListDatadog", i, o, err)
+	return o, err
+}
+
+// GetDatadog implements Interface.
+func (s *Spy) GetDatadog(i *fastly.GetDatadogInput) (*fastly.Datadog, error) {
+	o, err := s.API.GetDatadog(i)
+	s.record("GetDatadog", i, o, err)
+	return o, err
+}
+
+// UpdateDatadog implements Interface.
+func (s *Spy) UpdateDatadog(i *fastly.UpdateDatadogInput) (*fastly.Datadog, error) {
+	o, err := s.API.UpdateDatadog(i)
+	s.record("UpdateDatadog", i, o, err)
+	return o, err
+}
+
+// DeleteDatadog implements Interface.
+func (s *Spy) DeleteDatadog(i *fastly.DeleteDatadogInput) error {
+	err := s.API.DeleteDatadog(i)
+	s.record("DeleteDatadog", i, nil, err)
+	return err
+}
+
+// CreateHTTPS implements Interface.
+func (s *Spy) CreateHTTPS(i *fastly.CreateHTTPSInput) (*fastly.HTTPS, error) {
+	o, err := s.API.CreateHTTPS(i)
+	s.record("CreateHTTPS", i, o, err)
+	return o, err
+}
+
+// ListHTTPS implements Interface.
+func (s *Spy) ListHTTPS(i *fastly.ListHTTPSInput) ([]*fastly.HTTPS, error) {
+	o, err := s.API.ListHTTPS(i)
+	s.record("ListHTTPS", i, o, err)
+	return o, err
+}
+
+// GetHTTPS implements Interface.
+func (s *Spy) GetHTTPS(i *fastly.GetHTTPSInput) (*fastly.HTTPS, error) {
+	o, err := s.API.GetHTTPS(i)
+	s.record("GetHTTPS", i, o, err)
+	return o, err
+}
+
+// UpdateHTTPS implements Interface.
+func (s *Spy) UpdateHTTPS(i *fastly.UpdateHTTPSInput) (*fastly.HTTPS, error) {
+	o, err := s.API.UpdateHTTPS(i)
+	s.record("UpdateHTTPS", i, o, err)
+	return o, err
+}
+
+// DeleteHTTPS implements Interface.
+func (s *Spy) DeleteHTTPS(i *fastly.DeleteHTTPSInput) error {
+	err := s.API.DeleteHTTPS(i)
+	s.record("DeleteHTTPS", i, nil, err)
+	return err
+}
+
+// CreateKafka implements Interface.
+func (s *Spy) CreateKafka(i *fastly.CreateKafkaInput) (*fastly.Kafka, error) {
+	o, err := s.API.CreateKafka(i)
+	s.record("CreateKafka", i, o, err)
+	return o, err
+}
+
+// ListKafkas implements Interface.
+func (s *Spy) ListKafkas(i *fastly.ListKafkasInput) ([]*fastly.Kafka, error) {
+	o, err := s.API.ListKafkas(i)
+	s.record("ListKafkas", i, o, err)
+	return o, err
+}
+
+// GetKafka implements Interface.
+func (s *Spy) GetKafka(i *fastly.GetKafkaInput) (*fastly.Kafka, error) {
+	o, err := s.API.GetKafka(i)
+	s.record("GetKafka", i, o, err)
+	return o, err
+}
+
+// UpdateKafka implements Interface.
+func (s *Spy) UpdateKafka(i *fastly.UpdateKafkaInput) (*fastly.Kafka, error) {
+	o, err := s.API.UpdateKafka(i)
+	s.record("UpdateKafka", i, o, err)
+	return o, err
+}
+
+// DeleteKafka implements Interface.
+func (s *Spy) DeleteKafka(i *fastly.DeleteKafkaInput) error {
+	err := s.API.DeleteKafka(i)
+	s.record("DeleteKafka", i, nil, err)
+	return err
+}
+
+// CreatePubsub implements Interface.
+func (s *Spy) CreatePubsub(i *fastly.CreatePubsubInput) (*fastly.Pubsub, error) {
+	o, err := s.API.CreatePubsub(i)
+	s.record("CreatePubsub", i, o, err)
+	return o, err
+}
+
+// ListPubsubs implements Interface.
+func (s *Spy) ListPubsubs(i *fastly.ListPubsubsInput) ([]*fastly.Pubsub, error) {
+	o, err := s.API.ListPubsubs(i)
+	s.record("ListPubsubs", i, o, err)
+	return o, err
+}
+
+// GetPubsub implements Interface.
+func (s *Spy) GetPubsub(i *fastly.GetPubsubInput) (*fastly.Pubsub, error) {
+	o, err := s.API.GetPubsub(i)
+	s.record("GetPubsub", i, o, err)
+	return o, err
+}
+
+// UpdatePubsub implements Interface.
+func (s *Spy) UpdatePubsub(i *fastly.UpdatePubsubInput) (*fastly.Pubsub, error) {
+	o, err := s.API.UpdatePubsub(i)
+	s.record("UpdatePubsub", i, o, err)
+	return o, err
+}
+
+// DeletePubsub implements Interface.
+func (s *Spy) DeletePubsub(i *fastly.DeletePubsubInput) error {
+	err := s.API.DeletePubsub(i)
+	s.record("DeletePubsub", i, nil, err)
+	return err
+}
+
+// CreateOpenstack implements Interface.
+func (s *Spy) CreateOpenstack(i *fastly.CreateOpenstackInput) (*fastly.Openstack, error) {
+	o, err := s.API.CreateOpenstack(i)
+	s.record("CreateOpenstack", i, o, err)
+	return o, err
+}
+
+// ListOpenstack implements Interface.
+func (s *Spy) ListOpenstack(i *fastly.ListOpenstackInput) ([]*fastly.Openstack, error) {
+	o, err := s.API.ListOpenstack(i)
+	s.record("ListOpenstack", i, o, err)
+	return o, err
+}
+
+// GetOpenstack implements Interface.
+func (s *Spy) GetOpenstack(i *fastly.GetOpenstackInput) (*fastly.Openstack, error) {
+	o, err := s.API.GetOpenstack(i)
+	s.record("GetOpenstack", i, o, err)
+	return o, err
+}
+
+// UpdateOpenstack implements Interface.
+func (s *Spy) UpdateOpenstack(i *fastly.UpdateOpenstackInput) (*fastly.Openstack, error) {
+	o, err := s.API.UpdateOpenstack(i)
+	s.record("UpdateOpenstack", i, o, err)
+	return o, err
+}
+
+// DeleteOpenstack implements Interface.
+func (s *Spy) DeleteOpenstack(i *fastly.DeleteOpenstackInput) error {
+	err := s.API.DeleteOpenstack(i)
+	s.record("DeleteOpenstack", i, nil, err)
+	return err
+}
+
+// GetUser implements Interface.
+func (s *Spy) GetUser(i *fastly.GetUserInput) (*fastly.User, error) {
+	o, err := s.API.GetUser(i)
+	s.record("GetUser", i, o, err)
+	return o, err
+}
+
+// GetAPIEvents implements Interface.
+func (s *Spy) GetAPIEvents(i *fastly.GetAPIEventsFilterInput) (fastly.GetAPIEventsResponse, error) {
+	o, err := s.API.GetAPIEvents(i)
+	s.record("GetAPIEvents", i, o, err)
+	return o, err
+}
+
+// ListWAFs implements Interface.
+func (s *Spy) ListWAFs(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+	o, err := s.API.ListWAFs(i)
+	s.record("ListWAFs", i, o, err)
+	return o, err
+}
+
+// GetWAF implements Interface.
+func (s *Spy) GetWAF(i *fastly.GetWAFInput) (*fastly.WAF, error) {
+	o, err := s.API.GetWAF(i)
+	s.record("GetWAF", i, o, err)
+	return o, err
+}
+
+// UpdateWAF implements Interface.
+func (s *Spy) UpdateWAF(i *fastly.UpdateWAFInput) (*fastly.WAF, error) {
+	o, err := s.API.UpdateWAF(i)
+	s.record("UpdateWAF", i, o, err)
+	return o, err
+}
+
+// ListAllWAFVersions implements Interface.
+func (s *Spy) ListAllWAFVersions(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	o, err := s.API.ListAllWAFVersions(i)
+	s.record("ListAllWAFVersions", i, o, err)
+	return o, err
+}
+
+// CloneWAFVersion implements Interface.
+func (s *Spy) CloneWAFVersion(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error) {
+	o, err := s.API.CloneWAFVersion(i)
+	s.record("CloneWAFVersion", i, o, err)
+	return o, err
+}
+
+// DeployWAFVersion implements Interface.
+func (s *Spy) DeployWAFVersion(i *fastly.DeployWAFVersionInput) error {
+	err := s.API.DeployWAFVersion(i)
+	s.record("DeployWAFVersion", i, nil, err)
+	return err
+}
+
+// ListAllWAFActiveRules implements Interface.
+func (s *Spy) ListAllWAFActiveRules(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error) {
+	o, err := s.API.ListAllWAFActiveRules(i)
+	s.record("ListAllWAFActiveRules", i, o, err)
+	return o, err
+}
+
+// BatchModificationWAFActiveRules implements Interface.
+func (s *Spy) BatchModificationWAFActiveRules(i *fastly.BatchModificationWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error) {
+	o, err := s.API.BatchModificationWAFActiveRules(i)
+	s.record("BatchModificationWAFActiveRules", i, o, err)
+	return o, err
+}
+
+// ListAllWAFRules implements Interface.
+func (s *Spy) ListAllWAFRules(i *fastly.ListAllWAFRulesInput) (*fastly.WAFRuleResponse, error) {
+	o, err := s.API.ListAllWAFRules(i)
+	s.record("ListAllWAFRules", i, o, err)
+	return o, err
+}
+
+// GetRegions implements Interface.
+func (s *Spy) GetRegions() (*fastly.RegionsResponse, error) {
+	o, err := s.API.GetRegions()
+	s.record("GetRegions", nil, o, err)
+	return o, err
+}
+
+// GetStatsJSON implements Interface.
+func (s *Spy) GetStatsJSON(i *fastly.GetStatsInput, dst interface{}) error {
+	err := s.API.GetStatsJSON(i, dst)
+	s.record("GetStatsJSON", []interface{}{i, dst}, nil, err)
+	return err
+}
+
+// GetUsage implements Interface.
+func (s *Spy) GetUsage(i *fastly.GetUsageInput) (*fastly.UsageResponse, error) {
+	o, err := s.API.GetUsage(i)
+	s.record("GetUsage", i, o, err)
+	return o, err
+}
+
+// GetUsageByService implements Interface.
+func (s *Spy) GetUsageByService(i *fastly.GetUsageInput) (*fastly.UsageByServiceResponse, error) {
+	o, err := s.API.GetUsageByService(i)
+	s.record("GetUsageByService", i, o, err)
+	return o, err
+}
+
+// GetBilling implements Interface.
+func (s *Spy) GetBilling(i *fastly.GetBillingInput) (*fastly.Billing, error) {
+	o, err := s.API.GetBilling(i)
+	s.record("GetBilling", i, o, err)
+	return o, err
+}
+
+// AllDatacenters implements Interface.
+func (s *Spy) AllDatacenters() ([]fastly.Datacenter, error) {
+	o, err := s.API.AllDatacenters()
+	s.record("AllDatacenters", nil, o, err)
+	return o, err
+}
+
+// IPs implements Interface.
+func (s *Spy) IPs() (fastly.IPAddrs, error) {
+	o, err := s.API.IPs()
+	s.record("IPs", nil, o, err)
+	return o, err
+}
+
+// IPsV6 implements Interface.
+func (s *Spy) IPsV6() (fastly.IPAddrs, error) {
+	o, err := s.API.IPsV6()
+	s.record("IPsV6", nil, o, err)
+	return o, err
+}
@@ -0,0 +1,34 @@
+package mock_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestSpy(t *testing.T) {
+	errTest := errors.New("fixture error")
+	spy := mock.NewSpy(mock.API{
+		GetServiceFn: func(i *fastly.GetServiceInput) (*fastly.Service, error) {
+			return &fastly.Service{ID: i.ID}, nil
+		},
+		DeleteServiceFn: func(i *fastly.DeleteServiceInput) error {
+			return errTest
+		},
+	})
+
+	s, err := spy.GetService(&fastly.GetServiceInput{ID: "123"})
+	testutil.AssertNoError(t, err)
+	err = spy.DeleteService(&fastly.DeleteServiceInput{ID: "123"})
+	testutil.AssertErrorContains(t, err, "fixture error")
+
+	testutil.AssertEqual(t, []string{"GetService", "DeleteService"}, spy.Methods())
+	calls := spy.Calls()
+	testutil.AssertEqual(t, &fastly.GetServiceInput{ID: "123"}, calls[0].Input)
+	testutil.AssertEqual(t, s, calls[0].Output)
+	testutil.AssertBool(t, true, calls[1].Output == nil)
+	testutil.AssertBool(t, true, calls[1].Err == errTest)
+}
@@ -11,18 +11,23 @@ import (
 
 // Versioner mocks the update.Versioner interface.
 type Versioner struct {
-	Version string
-	Error   error
+	Version           string
+	PrereleaseVersion string
+	Error             error
 }
 
 // Make sure mock.Versioner implements update.Versioner.
 var _ update.Versioner = (*Versioner)(nil)
 
-// LatestVersion returns the parsed version field, or error if it's non-nil.
-func (v Versioner) LatestVersion(context.Context) (semver.Version, error) {
+// LatestVersion returns the parsed version field, or error if it's non-nil. For
+// the prerelease channel, PrereleaseVersion is returned instead, if non-empty.
+func (v Versioner) LatestVersion(_ context.Context, channel string) (semver.Version, error) {
 	if v.Error != nil {
 		return semver.Version{}, v.Error
 	}
+	if channel == update.ChannelPrerelease && v.PrereleaseVersion != "" {
+		return semver.Parse(strings.TrimPrefix(v.PrereleaseVersion, "v"))
+	}
 	return semver.Parse(strings.TrimPrefix(v.Version, "v"))
 }
 
@@ -0,0 +1,3 @@
+// Package plugin contains support for external `fastly-<name>` commands
+// discovered on the PATH.
+package plugin
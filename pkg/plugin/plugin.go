@@ -0,0 +1,160 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+)
+
+// Prefix is the executable name prefix that identifies a plugin. An executable
+// named fastly-foo on the PATH is exposed as the `fastly foo` command.
+const Prefix = "fastly-"
+
+// EnvVarServiceID is the env var a plugin can read the resolved service ID
+// from, if one was provided via the package manifest or project config file.
+const EnvVarServiceID = "FASTLY_SERVICE_ID"
+
+// EnvVarProfile is the env var a plugin can read the selected profile from, if
+// one was provided via the project config file.
+const EnvVarProfile = "FASTLY_PROFILE"
+
+// Plugin describes an external executable discovered on the PATH.
+type Plugin struct {
+	Name string
+	Path string
+}
+
+// Discover walks each directory in path, which should be formatted like the
+// PATH env var, and returns every executable plugin found, sorted by name. If
+// the same plugin name appears in multiple directories, the first one wins,
+// mirroring how a shell would resolve it.
+func Discover(path string) []Plugin {
+	var (
+		plugins []Plugin
+		seen    = map[string]bool{}
+	)
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue
+		}
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue // missing or unreadable PATH entries are common
+		}
+		for _, fi := range files {
+			name, ok := pluginName(fi)
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: filepath.Join(dir, fi.Name())})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// pluginName returns the command name for a plugin executable, and whether the
+// file is a valid plugin at all.
+func pluginName(fi os.FileInfo) (string, bool) {
+	filename := fi.Name()
+	if !strings.HasPrefix(filename, Prefix) || fi.IsDir() {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(filename), ".exe") {
+			return "", false
+		}
+		filename = strings.TrimSuffix(filename, filepath.Ext(filename))
+	} else if fi.Mode().Perm()&0111 == 0 {
+		return "", false
+	}
+	name := strings.TrimPrefix(filename, Prefix)
+	if name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// Command executes a plugin, forwarding any trailing arguments and exposing
+// the resolved global configuration via environment variables.
+type Command struct {
+	common.Base
+	manifest manifest.Data
+	plugin   Plugin
+	args     []string
+	errOut   io.Writer
+}
+
+// NewCommand returns a usable command registered under the parent. The args
+// are passed verbatim to the plugin executable if the command is selected.
+func NewCommand(parent common.Registerer, p Plugin, args []string, globals *config.Data) *Command {
+	var c Command
+	c.Globals = globals
+	c.plugin = p
+	c.args = args
+	c.errOut = os.Stderr
+	c.manifest.File.Read(manifest.Filename)
+	c.CmdClause = parent.Command(p.Name, fmt.Sprintf("Run the %s%s plugin", Prefix, p.Name))
+	return &c
+}
+
+// Exec invokes the plugin executable, connecting it to the provided input and
+// output, and to stderr. If it exits unsuccessfully, the returned error carries
+// its exit code, with which the CLI exits in turn.
+func (c *Command) Exec(in io.Reader, out io.Writer) error {
+	if err := c.Globals.ResolveToken(); err != nil {
+		return err
+	}
+	env := os.Environ()
+	if token, _ := c.Globals.Token(); token != "" {
+		env = append(env, fmt.Sprintf("%s=%s", config.EnvVarToken, token))
+	}
+	endpoint, _ := c.Globals.Endpoint()
+	env = append(env, fmt.Sprintf("%s=%s", config.EnvVarEndpoint, endpoint))
+	if serviceID := c.serviceID(); serviceID != "" {
+		env = append(env, fmt.Sprintf("%s=%s", EnvVarServiceID, serviceID))
+	}
+	if profile, _ := c.Globals.Profile(); profile != "" {
+		env = append(env, fmt.Sprintf("%s=%s", EnvVarProfile, profile))
+	}
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the user explicitly installed the executable on their PATH.
+	/* #nosec */
+	cmd := exec.Command(c.plugin.Path, c.args...)
+	cmd.Env = env
+	cmd.Stdin = in
+	cmd.Stdout = out
+	cmd.Stderr = c.errOut
+
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return errors.ExitError{Code: exitErr.ExitCode(), Inner: fmt.Errorf("error running plugin %s: %w", c.plugin.Path, err)}
+	}
+	if err != nil {
+		return fmt.Errorf("error running plugin %s: %w", c.plugin.Path, err)
+	}
+	return nil
+}
+
+// serviceID yields the service ID of the package manifest, or else the project
+// config file, as plugins have no --service-id flag for it to resolve to.
+func (c *Command) serviceID() string {
+	if serviceID, source := c.manifest.ServiceID(); source != manifest.SourceUndefined {
+		return serviceID
+	}
+	return c.Globals.Project.ServiceID
+}
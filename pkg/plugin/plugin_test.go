@@ -0,0 +1,155 @@
+package plugin_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/plugin"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
+)
+
+func TestPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+
+	dir := makePluginDir(t, map[string]string{
+		"fastly-hello":   "#!/bin/sh\necho \"hello $* token=$FASTLY_API_TOKEN endpoint=$FASTLY_API_ENDPOINT\"\n",
+		"fastly-fail":    "#!/bin/sh\necho failing >&2\nexit 3\n",
+		"fastly-version": "#!/bin/sh\necho shadowed\n",
+	})
+	defer os.RemoveAll(dir)
+
+	for _, testcase := range []struct {
+		name       string
+		args       []string
+		wantError  string
+		wantCode   int
+		wantOutput string
+	}{
+		{
+			name:       "plugin receives args and environment",
+			args:       []string{"--token", "123", "hello", "--name", "world"},
+			wantOutput: "hello --name world token=123 endpoint=https://api.fastly.com",
+		},
+		{
+			name:      "plugin failure",
+			args:      []string{"fail"},
+			wantError: "exit status 3",
+			wantCode:  3,
+		},
+		{
+			name:       "built-in command takes precedence",
+			args:       []string{"version"},
+			wantOutput: "Fastly CLI version",
+		},
+		{
+			name:       "plugin listed in usage JSON",
+			args:       []string{"help", "--format", "json"},
+			wantOutput: `{"name":"hello","description":"Run the fastly-hello plugin"`,
+		},
+		{
+			name:      "unknown command",
+			args:      []string{"unknown"},
+			wantError: "expected command but got unknown",
+			wantCode:  errors.ExitUsage,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			out, err := golden.Exec(golden.Scenario{
+				Args: testcase.args,
+				Env:  config.Environment{Path: dir},
+			})
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertEqual(t, testcase.wantCode, errors.ExitCode(err))
+			testutil.AssertStringContains(t, out, testcase.wantOutput)
+			if strings.Contains(out, "failing") {
+				t.Errorf("plugin stderr was written to stdout: %q", out)
+			}
+		})
+	}
+}
+
+func TestPluginProject(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+
+	dir := makePluginDir(t, map[string]string{
+		"fastly-env": "#!/bin/sh\necho \"service=$FASTLY_SERVICE_ID profile=$FASTLY_PROFILE token=$FASTLY_API_TOKEN\"\n",
+	})
+	defer os.RemoveAll(dir)
+
+	rootdir, err := ioutil.TempDir("", "fastly-project-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootdir)
+	if err := os.MkdirAll(filepath.Join(rootdir, ".fastly"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	project := "service_id = \"123\"\nprofile = \"staging\"\n"
+	if err := ioutil.WriteFile(filepath.Join(rootdir, ".fastly", "config.toml"), []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	out, err := golden.Exec(golden.Scenario{
+		Args: []string{"env"},
+		Env:  config.Environment{Path: dir},
+		File: config.File{Token: "123", Profiles: map[string]config.Profile{"staging": {Token: "456"}}},
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, "service=123 profile=staging token=456\n", out)
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+
+	first := makePluginDir(t, map[string]string{"fastly-a": "#!/bin/sh\n"})
+	defer os.RemoveAll(first)
+	second := makePluginDir(t, map[string]string{"fastly-a": "#!/bin/sh\n", "fastly-b": "#!/bin/sh\n"})
+	defer os.RemoveAll(second)
+
+	// Non-executable files are not plugins.
+	if err := ioutil.WriteFile(filepath.Join(second, "fastly-c"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := first + string(os.PathListSeparator) + "/does/not/exist" + string(os.PathListSeparator) + second
+	testutil.AssertEqual(t, []plugin.Plugin{
+		{Name: "a", Path: filepath.Join(first, "fastly-a")},
+		{Name: "b", Path: filepath.Join(second, "fastly-b")},
+	}, plugin.Discover(path))
+}
+
+func makePluginDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "fastly-plugins-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
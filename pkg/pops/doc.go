@@ -0,0 +1,3 @@
+// Package pops contains commands to inspect the points of presence (POPs) of
+// the Fastly network.
+package pops
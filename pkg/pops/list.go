@@ -0,0 +1,50 @@
+package pops
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ListCommand calls the Fastly API to list the POPs of the Fastly network.
+type ListCommand struct {
+	common.Base
+	shields bool
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent common.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("list", "List the POPs of the Fastly network, with their locations")
+	c.CmdClause.Flag("shields", "Only list POPs which can be used as shields").BoolVar(&c.shields)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	pops, err := c.Globals.Client.AllDatacenters()
+	if err != nil {
+		return fmt.Errorf("error listing POPs: %w", err)
+	}
+	sort.Slice(pops, func(i, j int) bool {
+		return pops[i].Code < pops[j].Code
+	})
+
+	// A POP's shield is the name by which services refer to it as a shield,
+	// e.g. in a backend's --shield, and is empty if it can't be one.
+	tw := text.NewTable(out)
+	tw.AddHeader("CODE", "NAME", "REGION", "LATITUDE", "LONGITUDE", "SHIELD")
+	for _, p := range pops {
+		if c.shields && p.Shield == "" {
+			continue
+		}
+		tw.AddLine(p.Code, p.Name, p.Group, p.Coordinates.Latitude, p.Coordinates.Longtitude, p.Shield)
+	}
+	tw.Print()
+	return nil
+}
@@ -0,0 +1,63 @@
+package pops_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil/golden"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestPopsList(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"pops", "list"},
+			API:  mock.API{AllDatacentersFn: allDatacentersOK},
+		},
+		{
+			Args: []string{"pops", "list", "--shields"},
+			API:  mock.API{AllDatacentersFn: allDatacentersOK},
+		},
+		{
+			Args: []string{"pops", "list", "--verbose"},
+			API:  mock.API{AllDatacentersFn: allDatacentersError},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func allDatacentersOK() ([]fastly.Datacenter, error) {
+	return []fastly.Datacenter{
+		{
+			Code:        "LHR",
+			Name:        "London",
+			Group:       "Europe",
+			Shield:      "london-uk",
+			Coordinates: fastly.Coordinates{Latitude: 51.4775, Longtitude: -0.4614},
+		},
+		{
+			Code:        "AMS",
+			Name:        "Amsterdam",
+			Group:       "Europe",
+			Shield:      "amsterdam-nl",
+			Coordinates: fastly.Coordinates{Latitude: 52.308, Longtitude: 4.7642},
+		},
+		{
+			Code:        "BOG",
+			Name:        "Bogota",
+			Group:       "South America",
+			Coordinates: fastly.Coordinates{Latitude: 4.7016, Longtitude: -74.1469},
+		},
+	}, nil
+}
+
+func allDatacentersError() ([]fastly.Datacenter, error) {
+	return nil, errTest
+}
+
+var errTest = errors.New("fixture error")
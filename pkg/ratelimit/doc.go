@@ -0,0 +1,3 @@
+// Package ratelimit backs off from, and retries, Fastly API requests which
+// exceed the API's rate limit.
+package ratelimit
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/fastly/cli/pkg/errors"
+)
+
+// MaxRetries is how many times a rate limited request is retried, and
+// DefaultBackoff how long to wait before retrying when the time at which the
+// rate limit resets is unknown.
+const (
+	MaxRetries     = 3
+	DefaultBackoff = 10 * time.Second
+)
+
+// Limiter makes callers sharing it back off together: once any request is rate
+// limited, no caller makes another until the limit resets.
+type Limiter struct {
+	mu    sync.Mutex
+	until time.Time
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// New returns a Limiter using the system clock.
+func New() *Limiter {
+	return &Limiter{now: time.Now, sleep: time.Sleep}
+}
+
+// Do calls call, retrying it if it's rate limited.
+func (l *Limiter) Do(call func() error) error {
+	for attempt := 0; ; attempt++ {
+		l.mu.Lock()
+		wait := l.until.Sub(l.now())
+		l.mu.Unlock()
+		if wait > 0 {
+			l.sleep(wait)
+		}
+
+		err := call()
+		var rateLimitError errors.RateLimitError
+		if !stderrors.As(err, &rateLimitError) || attempt == MaxRetries {
+			return err
+		}
+
+		reset := rateLimitError.Reset
+		if reset.IsZero() {
+			reset = l.now().Add(DefaultBackoff)
+		}
+		l.mu.Lock()
+		if reset.After(l.until) {
+			l.until = reset
+		}
+		l.mu.Unlock()
+	}
+}
+
+// Hook is an api.Hook which makes every call through an api.Middleware via Do.
+func (l *Limiter) Hook(method string, input interface{}, call func() (interface{}, error)) (interface{}, error) {
+	var output interface{}
+	err := l.Do(func() (err error) {
+		output, err = call()
+		return err
+	})
+	return output, err
+}
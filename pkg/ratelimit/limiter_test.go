@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// TestLimiter makes rate limited calls against a fake clock, which advances
+// whenever the Limiter sleeps.
+func TestLimiter(t *testing.T) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	rateLimited := func(reset time.Time) error {
+		return fmt.Errorf("error listing backends: %w", errors.RateLimitError{Reset: reset, HTTPError: &fastly.HTTPError{StatusCode: 429}})
+	}
+
+	for _, testcase := range []struct {
+		name      string
+		errs      []error // returned by each call in turn
+		wantCalls int
+		wantSlept []time.Duration
+		wantLimit bool
+	}{
+		{
+			name:      "not rate limited",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "reset time",
+			errs:      []error{rateLimited(start.Add(time.Minute)), nil},
+			wantCalls: 2,
+			wantSlept: []time.Duration{time.Minute},
+		},
+		{
+			name:      "unknown reset time",
+			errs:      []error{rateLimited(time.Time{}), rateLimited(time.Time{}), nil},
+			wantCalls: 3,
+			wantSlept: []time.Duration{DefaultBackoff, DefaultBackoff},
+		},
+		{
+			name:      "too many retries",
+			errs:      []error{rateLimited(time.Time{}), rateLimited(time.Time{}), rateLimited(time.Time{}), rateLimited(time.Time{}), nil},
+			wantCalls: MaxRetries + 1,
+			wantSlept: []time.Duration{DefaultBackoff, DefaultBackoff, DefaultBackoff},
+			wantLimit: true,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			now := start
+			var slept []time.Duration
+			l := &Limiter{
+				now:   func() time.Time { return now },
+				sleep: func(d time.Duration) { slept = append(slept, d); now = now.Add(d) },
+			}
+
+			var calls int
+			err := l.Do(func() error {
+				calls++
+				return testcase.errs[calls-1]
+			})
+			testutil.AssertEqual(t, testcase.wantCalls, calls)
+			testutil.AssertEqual(t, testcase.wantSlept, slept)
+			testutil.AssertBool(t, testcase.wantLimit, err != nil)
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// Package rawapi contains the `api` command, which makes authenticated
+// requests directly against the Fastly API.
+package rawapi
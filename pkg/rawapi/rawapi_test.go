@@ -0,0 +1,233 @@
+package rawapi_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
+	"github.com/fastly/cli/pkg/version"
+)
+
+func TestAPI(t *testing.T) {
+	dataFile := testutil.MakeTempFile(t, `{"name":"from-file"}`)
+
+	for _, testcase := range []struct {
+		name        string
+		args        []string
+		stdin       string
+		client      *recordingClient
+		wantError   string
+		wantOutput  string
+		wantMethod  string
+		wantURLs    []string
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:      "no token",
+			args:      []string{"api", "GET", "/service"},
+			client:    newRecordingClient(page{body: `[]`}),
+			wantError: "no token provided",
+		},
+		{
+			name:       "pretty prints JSON",
+			args:       []string{"--token=123", "api", "get", "/service"},
+			client:     newRecordingClient(page{body: `[{"id":"abc"}]`}),
+			wantOutput: "[\n  {\n    \"id\": \"abc\"\n  }\n]\n",
+			wantMethod: "GET",
+			wantURLs:   []string{"https://api.fastly.com/service"},
+			wantHeaders: map[string]string{
+				"Fastly-Key": "123",
+				"User-Agent": version.UserAgent,
+			},
+		},
+		{
+			name:       "alternative endpoint and custom headers",
+			args:       []string{"--token=123", "api", "GET", "/service?page=2", "--endpoint=https://staging.fastly.com", "-H", "Fastly-Foo: bar"},
+			client:     newRecordingClient(page{body: `plain text`}),
+			wantOutput: "plain text\n",
+			wantMethod: "GET",
+			wantURLs:   []string{"https://staging.fastly.com/service?page=2"},
+			wantHeaders: map[string]string{
+				"Fastly-Foo": "bar",
+			},
+		},
+		{
+			name:      "invalid header",
+			args:      []string{"--token=123", "api", "GET", "/service", "--header", "nope"},
+			client:    newRecordingClient(),
+			wantError: `error parsing header "nope"`,
+		},
+		{
+			name:       "data from file",
+			args:       []string{"--token=123", "api", "POST", "/service", "--data", "@" + dataFile},
+			client:     newRecordingClient(page{body: `{}`}),
+			wantOutput: "{}\n",
+			wantMethod: "POST",
+			wantURLs:   []string{"https://api.fastly.com/service"},
+			wantBody:   `{"name":"from-file"}`,
+			wantHeaders: map[string]string{
+				"Content-Type": "application/json",
+			},
+		},
+		{
+			name:       "data from stdin",
+			args:       []string{"--token=123", "api", "PUT", "/service/abc", "--data", "@-"},
+			stdin:      "name=from-stdin",
+			client:     newRecordingClient(page{body: `{}`}),
+			wantOutput: "{}\n",
+			wantMethod: "PUT",
+			wantURLs:   []string{"https://api.fastly.com/service/abc"},
+			wantBody:   "name=from-stdin",
+			wantHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+		},
+		{
+			name: "paginate via Link header",
+			args: []string{"--token=123", "api", "GET", "/service", "--paginate"},
+			client: newRecordingClient(
+				page{body: `[{"id":"a"}]`, link: `<https://api.fastly.com/service?page=2>; rel="next"`},
+				page{body: `[{"id":"b"}]`},
+			),
+			wantOutput: "[\n  {\n    \"id\": \"a\"\n  },\n  {\n    \"id\": \"b\"\n  }\n]\n",
+			wantMethod: "GET",
+			wantURLs:   []string{"https://api.fastly.com/service", "https://api.fastly.com/service?page=2"},
+		},
+		{
+			name: "paginate via JSON:API links",
+			args: []string{"--token=123", "api", "GET", "/tls/domains", "--paginate"},
+			client: newRecordingClient(
+				page{body: `{"data":[{"id":"a"}],"links":{"next":"https://api.fastly.com/tls/domains?page[number]=2"}}`},
+				page{body: `{"data":[{"id":"b"}],"links":{}}`},
+			),
+			wantOutput: "{\n  \"data\": [\n    {\n      \"id\": \"a\"\n    },\n    {\n      \"id\": \"b\"\n    }\n  ]\n}\n",
+			wantMethod: "GET",
+			wantURLs:   []string{"https://api.fastly.com/tls/domains", "https://api.fastly.com/tls/domains?page[number]=2"},
+		},
+		{
+			name: "paginate empty pages",
+			args: []string{"--token=123", "api", "GET", "/service", "--paginate"},
+			client: newRecordingClient(
+				page{body: `[]`, link: `<https://api.fastly.com/service?page=2>; rel="next"`},
+				page{body: `[]`},
+			),
+			wantOutput: "[]\n",
+			wantMethod: "GET",
+			wantURLs:   []string{"https://api.fastly.com/service", "https://api.fastly.com/service?page=2"},
+		},
+		{
+			name: "paginate empty JSON:API pages",
+			args: []string{"--token=123", "api", "GET", "/tls/domains", "--paginate"},
+			client: newRecordingClient(
+				page{body: `{"data":[],"links":{"next":"https://api.fastly.com/tls/domains?page[number]=2"}}`},
+				page{body: `{"data":[],"links":{}}`},
+			),
+			wantOutput: "{\n  \"data\": []\n}\n",
+			wantMethod: "GET",
+			wantURLs:   []string{"https://api.fastly.com/tls/domains", "https://api.fastly.com/tls/domains?page[number]=2"},
+		},
+		{
+			name: "refuses to follow links to fetched pages",
+			args: []string{"--token=123", "api", "GET", "/service", "--paginate"},
+			client: newRecordingClient(
+				page{body: `[{"id":"a"}]`, link: `<https://api.fastly.com/service?page=2>; rel="next"`},
+				page{body: `[{"id":"b"}]`, link: `<https://api.fastly.com/service>; rel="next"`},
+			),
+			wantError: "the next page, https://api.fastly.com/service, was already fetched",
+			wantURLs:  []string{"https://api.fastly.com/service", "https://api.fastly.com/service?page=2"},
+		},
+		{
+			name: "pagination ignored without flag",
+			args: []string{"--token=123", "api", "GET", "/service"},
+			client: newRecordingClient(
+				page{body: `[]`, link: `<https://api.fastly.com/service?page=2>; rel="next"`},
+			),
+			wantOutput: "[]\n",
+			wantMethod: "GET",
+			wantURLs:   []string{"https://api.fastly.com/service"},
+		},
+		{
+			name: "refuses to follow links to other hosts",
+			args: []string{"--token=123", "api", "GET", "/service", "--paginate"},
+			client: newRecordingClient(
+				page{body: `[]`, link: `<https://evil.example.com/service?page=2>; rel="next"`},
+			),
+			wantError: "refusing to send token to evil.example.com",
+		},
+		{
+			name:      "error from API",
+			args:      []string{"--token=123", "api", "DELETE", "/service/abc"},
+			client:    newRecordingClient(page{code: http.StatusNotFound, body: `{"msg":"Record not found"}`}),
+			wantError: `error from API: 404 Not Found: {"msg":"Record not found"}`,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			out, err := golden.Exec(golden.Scenario{
+				Args:       testcase.args,
+				Stdin:      testcase.stdin,
+				HTTPClient: testcase.client,
+			})
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertString(t, testcase.wantOutput, out)
+			if testcase.wantMethod != "" {
+				testutil.AssertString(t, testcase.wantMethod, testcase.client.requests[0].Method)
+			}
+			if testcase.wantURLs != nil {
+				var urls []string
+				for _, req := range testcase.client.requests {
+					urls = append(urls, req.URL.String())
+				}
+				testutil.AssertEqual(t, testcase.wantURLs, urls)
+			}
+			if testcase.wantBody != "" {
+				testutil.AssertString(t, testcase.wantBody, testcase.client.bodies[0])
+			}
+			for k, v := range testcase.wantHeaders {
+				testutil.AssertString(t, v, testcase.client.requests[0].Header.Get(k))
+			}
+		})
+	}
+}
+
+type page struct {
+	code int
+	body string
+	link string
+}
+
+type recordingClient struct {
+	pages    []page
+	requests []*http.Request
+	bodies   []string
+}
+
+func newRecordingClient(pages ...page) *recordingClient {
+	return &recordingClient{pages: pages}
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	var body string
+	if req.Body != nil {
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+	}
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, body)
+
+	p := c.pages[0]
+	c.pages = c.pages[1:]
+
+	rec := httptest.NewRecorder()
+	if p.link != "" {
+		rec.Header().Set("Link", p.link)
+	}
+	if p.code != 0 {
+		rec.WriteHeader(p.code)
+	}
+	rec.WriteString(p.body)
+	return rec.Result(), nil
+}
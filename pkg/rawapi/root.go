@@ -0,0 +1,293 @@
+package rawapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/version"
+)
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	common.Base
+	client api.HTTPClient
+
+	method   string
+	path     string
+	data     string
+	headers  []string
+	paginate bool
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent common.Registerer, client api.HTTPClient, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.client = client
+	c.CmdClause = parent.Command("api", "Make an authenticated request to the Fastly API")
+	c.CmdClause.Arg("method", "HTTP method, e.g. GET or POST").Required().StringVar(&c.method)
+	c.CmdClause.Arg("path", "API path, e.g. /service").Required().StringVar(&c.path)
+	c.CmdClause.Flag("data", "Request body, or @file to read it from a file (@- for stdin)").Short('d').StringVar(&c.data)
+	c.CmdClause.Flag("header", "Additional request header in key:value form (repeatable)").Short('H').StringsVar(&c.headers)
+	c.CmdClause.Flag("paginate", "Follow pagination links and combine every page of results").BoolVar(&c.paginate)
+	return &c
+}
+
+// Name implements the Command interface. It's overridden because kingpin
+// includes argument placeholders in FullCommand, which would never match the
+// command name returned by Parse.
+func (c *RootCommand) Name() string {
+	return "api"
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	if err := c.Globals.ResolveToken(); err != nil {
+		return err
+	}
+	token, source := c.Globals.Token()
+	if source == config.SourceUndefined {
+		return errors.ErrNoToken
+	}
+
+	endpoint, _ := c.Globals.Endpoint()
+	base, err := url.Parse(strings.TrimSuffix(endpoint, "/") + "/")
+	if err != nil {
+		return fmt.Errorf("error parsing API endpoint: %w", err)
+	}
+
+	target, err := resolve(base, c.path)
+	if err != nil {
+		return err
+	}
+
+	header, err := parseHeaders(c.headers)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.readData(in)
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(c.method)
+	var pages [][]byte
+	fetched := make(map[string]bool)
+	for target != nil {
+		page, next, err := c.do(method, target, token, header, body)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, page)
+		fetched[target.String()] = true
+
+		target = nil
+		if c.paginate && next != "" {
+			if target, err = resolve(base, next); err != nil {
+				return err
+			}
+			// A link back to an earlier page would otherwise be followed
+			// forever.
+			if fetched[target.String()] {
+				return fmt.Errorf("error following pagination: the next page, %s, was already fetched", target)
+			}
+		}
+	}
+
+	return write(out, combine(pages))
+}
+
+// readData returns the request body, honouring the @file and @- forms.
+func (c *RootCommand) readData(in io.Reader) ([]byte, error) {
+	switch {
+	case c.data == "":
+		return nil, nil
+	case c.data == "@-":
+		b, err := ioutil.ReadAll(in)
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body from stdin: %w", err)
+		}
+		return b, nil
+	case strings.HasPrefix(c.data, "@"):
+		path, err := filepath.Abs(strings.TrimPrefix(c.data, "@"))
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		b, err := ioutil.ReadFile(path) // #nosec G304
+		if err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+		return b, nil
+	default:
+		return []byte(c.data), nil
+	}
+}
+
+// do executes a single request, returning the response body and the URL of
+// the next page of results, if any.
+func (c *RootCommand) do(method string, target *url.URL, token string, header http.Header, body []byte) ([]byte, string, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, target.String(), r)
+	if err != nil {
+		return nil, "", fmt.Errorf("error constructing API request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType(body))
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Fastly-Key", token)
+	req.Header.Set("User-Agent", version.UserAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("error executing API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading API response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(b))
+		if msg == "" {
+			return nil, "", fmt.Errorf("error from API: %s", resp.Status)
+		}
+		return nil, "", fmt.Errorf("error from API: %s: %s", resp.Status, msg)
+	}
+
+	return b, nextLink(resp.Header, b), nil
+}
+
+// resolve turns a path, or a full URL as found in pagination links, into a URL
+// relative to the API endpoint. Requests to any other host are refused to avoid
+// leaking the API token.
+func resolve(base *url.URL, ref string) (*url.URL, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing API path: %w", err)
+	}
+	if u.IsAbs() && u.Host != base.Host {
+		return nil, fmt.Errorf("error parsing API path: refusing to send token to %s", u.Host)
+	}
+	return base.ResolveReference(&url.URL{
+		Path:     strings.TrimPrefix(u.Path, "/"),
+		RawQuery: u.RawQuery,
+	}), nil
+}
+
+// parseHeaders converts key:value pairs into an http.Header.
+func parseHeaders(headers []string) (http.Header, error) {
+	h := http.Header{}
+	for _, kv := range headers {
+		toks := strings.SplitN(kv, ":", 2)
+		if len(toks) != 2 || strings.TrimSpace(toks[0]) == "" {
+			return nil, fmt.Errorf("error parsing header %q: must be in key:value form", kv)
+		}
+		h.Add(strings.TrimSpace(toks[0]), strings.TrimSpace(toks[1]))
+	}
+	return h, nil
+}
+
+// contentType guesses the request body content type. The Fastly API accepts
+// either JSON or form encoded bodies.
+func contentType(body []byte) string {
+	if json.Valid(body) {
+		return "application/json"
+	}
+	return "application/x-www-form-urlencoded"
+}
+
+var linkNextRegExp = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// nextLink returns the URL of the next page of results, which the Fastly API
+// provides either via a Link header or a JSON:API links object.
+func nextLink(header http.Header, body []byte) string {
+	for _, link := range header["Link"] {
+		if m := linkNextRegExp.FindStringSubmatch(link); m != nil {
+			return m[1]
+		}
+	}
+
+	var doc struct {
+		Links struct {
+			Next string `json:"next"`
+		} `json:"links"`
+	}
+	if err := json.Unmarshal(body, &doc); err == nil {
+		return doc.Links.Next
+	}
+	return ""
+}
+
+// combine merges multiple pages of results into a single document. Plain JSON
+// arrays are concatenated, as are the data members of JSON:API documents. Any
+// other content is returned unmodified, one page after another.
+func combine(pages [][]byte) []byte {
+	if len(pages) == 1 {
+		return pages[0]
+	}
+
+	items := []json.RawMessage{}
+	for _, page := range pages {
+		var arr []json.RawMessage
+		if err := json.Unmarshal(page, &arr); err != nil {
+			items = nil
+			break
+		}
+		items = append(items, arr...)
+	}
+	if items != nil {
+		b, _ := json.Marshal(items)
+		return b
+	}
+
+	data := []json.RawMessage{}
+	for _, page := range pages {
+		var doc struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(page, &doc); err != nil || doc.Data == nil {
+			return bytes.Join(pages, []byte("\n"))
+		}
+		data = append(data, doc.Data...)
+	}
+	b, _ := json.Marshal(map[string][]json.RawMessage{"data": data})
+	return b
+}
+
+// write pretty-prints JSON content, or writes any other content verbatim.
+func write(out io.Writer, b []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		buf.Reset()
+		buf.Write(b)
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteString("\n")
+	}
+	_, err := out.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,6 @@
+// Package recording records HTTP interactions with the Fastly API to fixture
+// files, with tokens and other secrets scrubbed, and replays them. Recording is
+// enabled via the FASTLY_RECORD environment variable, so a session can be
+// attached to a bug report, and replayed by maintainers via FASTLY_REPLAY or
+// in tests.
+package recording
@@ -0,0 +1,64 @@
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/fastly/cli/pkg/config"
+)
+
+// Fixture is a recorded sequence of HTTP interactions.
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded HTTP request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Read the Fixture from the file at path.
+func (f *Fixture) Read(path string) error {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as the path is provided by the user via the environment.
+	/* #nosec */
+	p, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading fixture: %w", err)
+	}
+	if err := json.Unmarshal(p, f); err != nil {
+		return fmt.Errorf("error parsing fixture %s: %w", path, err)
+	}
+	return nil
+}
+
+// Write the Fixture to the file at path.
+func (f *Fixture) Write(path string) error {
+	p, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding fixture: %w", err)
+	}
+	if err := ioutil.WriteFile(path, append(p, '\n'), config.FilePermissions); err != nil {
+		return fmt.Errorf("error writing fixture: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// tokenHeader is the request header carrying the Fastly API token.
+const tokenHeader = "Fastly-Key"
+
+// Redacted replaces secrets in recorded interactions.
+const Redacted = "REDACTED"
+
+// sensitiveHeaders are removed from recorded requests and responses.
+var sensitiveHeaders = []string{
+	tokenHeader,
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Scrub returns the interaction with the API token and other secrets redacted
+// from its URL, headers and bodies.
+func Scrub(i Interaction) Interaction {
+	token := i.Request.Header.Get(tokenHeader)
+
+	i.Request.URL = scrubURL(i.Request.URL, token)
+	i.Request.Body = scrubBody(i.Request.Body, i.Request.Header.Get("Content-Type"), token)
+	i.Request.Header = scrubHeader(i.Request.Header)
+
+	i.Response.Body = scrubBody(i.Response.Body, i.Response.Header.Get("Content-Type"), token)
+	i.Response.Header = scrubHeader(i.Response.Header)
+
+	return i
+}
+
+func scrubHeader(h http.Header) http.Header {
+	for _, name := range sensitiveHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, Redacted)
+		}
+	}
+	return h
+}
+
+func scrubURL(raw, token string) string {
+	u, err := url.Parse(replaceToken(raw, token))
+	if err != nil {
+		return raw
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = scrubValues(u.Query()).Encode()
+	}
+	return u.String()
+}
+
+func scrubBody(body, contentType, token string) string {
+	if body == "" {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(body); err == nil {
+			body = scrubValues(values).Encode()
+		}
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		dec := json.NewDecoder(strings.NewReader(body))
+		dec.UseNumber()
+		var v interface{}
+		if err := dec.Decode(&v); err == nil {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			if err := enc.Encode(scrubJSON(v)); err == nil {
+				body = strings.TrimSuffix(buf.String(), "\n")
+			}
+		}
+	case strings.HasPrefix(mediaType, "multipart/"):
+		// File uploads, such as Compute@Edge packages, aren't useful to
+		// replay, and would bloat the fixture.
+		return fmt.Sprintf("(%d byte %s body omitted)", len(body), mediaType)
+	}
+
+	return replaceToken(body, token)
+}
+
+func scrubValues(values url.Values) url.Values {
+	for k := range values {
+		if api.IsSensitive(k) {
+			values[k] = []string{Redacted}
+		}
+	}
+	return values
+}
+
+func scrubJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if _, ok := child.(string); ok && api.IsSensitive(k) {
+				v[k] = Redacted
+				continue
+			}
+			v[k] = scrubJSON(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = scrubJSON(child)
+		}
+	}
+	return v
+}
+
+func replaceToken(s, token string) string {
+	if token == "" || token == Redacted {
+		return s
+	}
+	return strings.ReplaceAll(s, token, Redacted)
+}
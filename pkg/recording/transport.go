@@ -0,0 +1,188 @@
+package recording
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/fastly/cli/pkg/config"
+)
+
+// NewTransport returns a transport which records to, or replays from, the
+// fixture file named by the environment, or nil if neither is enabled.
+// Recorded requests are made via next.
+func NewTransport(env config.Environment, next http.RoundTripper) (http.RoundTripper, error) {
+	switch {
+	case env.Record != "" && env.Replay != "":
+		return nil, fmt.Errorf("only one of %s and %s may be set", config.EnvVarRecord, config.EnvVarReplay)
+	case env.Record != "":
+		return NewRecorder(env.Record, next), nil
+	case env.Replay != "":
+		r, err := NewReplayer(env.Replay)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	return nil, nil
+}
+
+// Recorder is an http.RoundTripper which records each interaction, scrubbed of
+// secrets, to a fixture file. The file is rewritten after every interaction,
+// so it's complete however the program exits.
+type Recorder struct {
+	path string
+	next http.RoundTripper
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder returns a Recorder which writes to the fixture file at path,
+// making requests via next, or http.DefaultTransport if next is nil.
+func NewRecorder(path string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+
+	interaction := Scrub(Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   string(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fixture.Interactions = append(r.fixture.Interactions, interaction)
+	if err := r.fixture.Write(r.path); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Do implements api.HTTPClient.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	return (&http.Client{Transport: r}).Do(req)
+}
+
+// Replayer is an http.RoundTripper which responds to requests from a fixture
+// file rather than the network. Each recorded interaction is used once, and
+// requests are matched to the first unused interaction with the same method
+// and URL, so repeated requests are answered in the order they were recorded.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer for the fixture file at path.
+func NewReplayer(path string) (*Replayer, error) {
+	var f Fixture
+	if err := f.Read(path); err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		interactions: f.Interactions,
+		used:         make([]bool, len(f.Interactions)),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// URLs are scrubbed when recorded, so the request is too before matching.
+	url := scrubURL(req.URL.String(), req.Header.Get(tokenHeader))
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != url {
+			continue
+		}
+		r.used[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, url)
+}
+
+// Do implements api.HTTPClient.
+func (r *Replayer) Do(req *http.Request) (*http.Response, error) {
+	return (&http.Client{Transport: r}).Do(req)
+}
+
+// Unused returns the number of recorded interactions which haven't been
+// replayed, which tests can use to check every expected request was made.
+func (r *Replayer) Unused() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var n int
+	for _, used := range r.used {
+		if !used {
+			n++
+		}
+	}
+	return n
+}
+
+// readBody reads the body, and replaces it with an identical unread copy.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	p, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	*body = ioutil.NopCloser(bytes.NewReader(p))
+	return p, err
+}
@@ -0,0 +1,3 @@
+// Package search contains the `search` command, which finds resources across
+// the active versions of every service, such as the services using a backend.
+package search
@@ -0,0 +1,195 @@
+package search
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/logging"
+	"github.com/fastly/cli/pkg/ratelimit"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// Entry is the indexed configuration of the active version of a service.
+type Entry struct {
+	ServiceID string     `json:"service_id"`
+	Name      string     `json:"name"`
+	Version   int        `json:"version"`
+	Resources []Resource `json:"resources"`
+}
+
+// Resource is an indexed resource: its kind, e.g. "backend" or "logging s3",
+// and the values of its string fields, by field name. Fields holding secrets
+// aren't indexed.
+type Resource struct {
+	Kind   string            `json:"kind"`
+	Name   string            `json:"name"`
+	Fields map[string]string `json:"fields"`
+}
+
+// indexKind is a kind of versioned resource, and the API call listing them.
+type indexKind struct {
+	name string
+	list logging.ListFunc
+}
+
+// indexKinds are the kinds of resource which are indexed. Logging endpoints
+// are added by init, from logging.Lists.
+var indexKinds = []indexKind{
+	{"domain", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDomains(&fastly.ListDomainsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"backend", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListBackends(&fastly.ListBackendsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"healthcheck", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListHealthChecks(&fastly.ListHealthChecksInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"dictionary", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: id, ServiceVersion: v})
+	}},
+}
+
+func init() {
+	providers := make([]string, 0, len(logging.Lists))
+	for provider := range logging.Lists {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		indexKinds = append(indexKinds, indexKind{"logging " + provider, logging.Lists[provider]})
+	}
+}
+
+// indexService returns the index entry of the active version of a service.
+func indexService(client api.Interface, l *ratelimit.Limiter, s *fastly.Service) (*Entry, error) {
+	e := &Entry{ServiceID: s.ID, Name: s.Name, Version: int(s.ActiveVersion)}
+	for _, kind := range indexKinds {
+		var resources interface{}
+		err := l.Do(func() (err error) {
+			resources, err = kind.list(client, s.ID, e.Version)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources of service %s: %w", kind.name, s.ID, err)
+		}
+		v := reflect.ValueOf(resources)
+		for i := 0; i < v.Len(); i++ {
+			e.Resources = append(e.Resources, indexResource(kind.name, v.Index(i).Interface()))
+		}
+	}
+	return e, nil
+}
+
+// indexResource indexes the string fields of a go-fastly resource, which is a
+// pointer to a struct.
+func indexResource(kind string, resource interface{}) Resource {
+	v := reflect.Indirect(reflect.ValueOf(resource))
+	r := Resource{Kind: kind, Fields: make(map[string]string)}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.Type.Kind() != reflect.String || f.Name == "ServiceID" || api.IsSensitive(f.Name) {
+			continue
+		}
+		if s := v.Field(i).String(); s != "" {
+			r.Fields[f.Name] = s
+		}
+	}
+	r.Name = r.Fields["Name"]
+	return r
+}
+
+// buildIndex indexes services concurrently, with a pool of workers.
+func buildIndex(client api.Interface, l *ratelimit.Limiter, services []*fastly.Service, workers int) ([]*Entry, error) {
+	type result struct {
+		entry *Entry
+		err   error
+	}
+	var (
+		jobs    = make(chan *fastly.Service)
+		results = make(chan result)
+		wg      sync.WaitGroup
+	)
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for s := range jobs {
+				e, err := indexService(client, l, s)
+				results <- result{e, err}
+			}
+		}()
+	}
+	go func() {
+		for _, s := range services {
+			jobs <- s
+		}
+		close(jobs)
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		entries []*Entry
+		err     error
+	)
+	for r := range results {
+		if r.err != nil && err == nil {
+			err = r.err
+		}
+		if r.entry != nil {
+			entries = append(entries, r.entry)
+		}
+	}
+	return entries, err
+}
+
+// cachePath returns the path of the index cache for the endpoint and token,
+// so that indexes of different accounts are kept apart.
+func cachePath(dir, endpoint, token string) string {
+	sum := sha256.Sum256([]byte(endpoint + "\x00" + token))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// readCache returns the cached index entries, by service ID. A missing or
+// unreadable cache is treated as empty.
+func readCache(path string) map[string]*Entry {
+	entries := make(map[string]*Entry)
+	b, err := ioutil.ReadFile(path) // #nosec G304
+	if err != nil {
+		return entries
+	}
+	var list []*Entry
+	if err := json.Unmarshal(b, &list); err != nil {
+		return entries
+	}
+	for _, e := range list {
+		entries[e.ServiceID] = e
+	}
+	return entries
+}
+
+func writeCache(path string, entries []*Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), config.DirectoryPermissions); err != nil {
+		return err
+	}
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, config.FilePermissions)
+}
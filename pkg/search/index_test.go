@@ -0,0 +1,24 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestIndexResource(t *testing.T) {
+	have := indexResource("logging syslog", &fastly.Syslog{
+		ServiceID: "123",
+		Name:      "logs",
+		Address:   "logs.example.com",
+		Port:      514,
+		Token:     "secret",
+	})
+	want := Resource{
+		Kind:   "logging syslog",
+		Name:   "logs",
+		Fields: map[string]string{"Name": "logs", "Address": "logs.example.com"},
+	}
+	testutil.AssertEqual(t, want, have)
+}
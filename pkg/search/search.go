@@ -0,0 +1,203 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/ratelimit"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// DefaultWorkers is how many services are indexed concurrently by default.
+const DefaultWorkers = 8
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	common.Base
+	text           string
+	backendAddress string
+	domain         string
+	loggingHost    string
+	workers        int
+	refresh        bool
+
+	// CacheDir is where the index is cached between searches, or "" to not
+	// cache it.
+	CacheDir string
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent common.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	if d, err := os.UserCacheDir(); err == nil {
+		c.CacheDir = filepath.Join(d, "fastly", "search")
+	}
+	c.CmdClause = parent.Command("search", "Search the active versions of every service for resources")
+	c.CmdClause.Arg("text", "Text to search for in any field of any resource").StringVar(&c.text)
+	c.CmdClause.Flag("backend-address", "Find backends with an address or hostname containing this").StringVar(&c.backendAddress)
+	c.CmdClause.Flag("domain", "Find domains containing this").StringVar(&c.domain)
+	c.CmdClause.Flag("logging-host", "Find logging endpoints with a host, URL or bucket containing this").StringVar(&c.loggingHost)
+	c.CmdClause.Flag("workers", "Number of services to index concurrently").Default(fmt.Sprint(DefaultWorkers)).IntVar(&c.workers)
+	c.CmdClause.Flag("refresh", "Rebuild the index of every service, rather than reusing the cached index").BoolVar(&c.refresh)
+	return &c
+}
+
+// Name implements the Command interface. It's overridden because kingpin
+// includes argument placeholders in FullCommand, which would never match the
+// command name returned by Parse.
+func (c *RootCommand) Name() string {
+	return "search"
+}
+
+// query matches the fields of resources of some kinds against a value.
+type query struct {
+	kind   func(kind string) bool
+	fields []string // every field if empty
+	value  string
+}
+
+func (q query) match(r Resource) (field string, ok bool) {
+	if !q.kind(r.Kind) {
+		return "", false
+	}
+	fields := q.fields
+	if len(fields) == 0 {
+		for f := range r.Fields {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+	}
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(r.Fields[f]), strings.ToLower(q.value)) {
+			return f, true
+		}
+	}
+	return "", false
+}
+
+func (c *RootCommand) queries() []query {
+	var queries []query
+	is := func(name string) func(string) bool {
+		return func(kind string) bool { return kind == name }
+	}
+	if c.text != "" {
+		queries = append(queries, query{kind: func(string) bool { return true }, value: c.text})
+	}
+	if c.backendAddress != "" {
+		queries = append(queries, query{
+			kind:   is("backend"),
+			fields: []string{"Address", "Hostname", "OverrideHost", "SSLCertHostname", "SSLSNIHostname"},
+			value:  c.backendAddress,
+		})
+	}
+	if c.domain != "" {
+		queries = append(queries, query{kind: is("domain"), fields: []string{"Name"}, value: c.domain})
+	}
+	if c.loggingHost != "" {
+		queries = append(queries, query{
+			kind:   func(kind string) bool { return strings.HasPrefix(kind, "logging ") },
+			fields: []string{"Address", "Hostname", "IPV4", "URL", "Brokers", "BucketName", "Bucket", "Domain", "Container", "TLSHostname"},
+			value:  c.loggingHost,
+		})
+	}
+	return queries
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	queries := c.queries()
+	if len(queries) == 0 {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: nothing to search for"),
+			Remediation: "Provide text to search for, or at least one of --backend-address, --domain or --logging-host.",
+		}
+	}
+
+	services, err := c.Globals.Client.ListServices(&fastly.ListServicesInput{})
+	if err != nil {
+		return err
+	}
+
+	var path string
+	cached := make(map[string]*Entry)
+	if c.CacheDir != "" {
+		token, _ := c.Globals.Token()
+		endpoint, _ := c.Globals.Endpoint()
+		path = cachePath(c.CacheDir, endpoint, token)
+		if !c.refresh {
+			cached = readCache(path)
+		}
+	}
+
+	// Active versions are locked, so the index of a service only needs to be
+	// rebuilt when its active version changes.
+	var (
+		entries []*Entry
+		stale   []*fastly.Service
+	)
+	for _, s := range services {
+		if s.ActiveVersion == 0 {
+			continue
+		}
+		if e, ok := cached[s.ID]; ok && e.Version == int(s.ActiveVersion) {
+			e.Name = s.Name
+			entries = append(entries, e)
+			continue
+		}
+		stale = append(stale, s)
+	}
+	indexed, err := buildIndex(c.Globals.Client, ratelimit.New(), stale, c.workers)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, indexed...)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ServiceID < entries[j].ServiceID
+	})
+	if c.Globals.Verbose() {
+		text.Info(out, "Indexed %d service(s), and reused the cached index of %d", len(indexed), len(entries)-len(indexed))
+	}
+	if path != "" {
+		writeCache(path, entries) // best effort
+	}
+
+	var matches int
+	tw := text.NewTable(out)
+	tw.AddHeader("SERVICE", "ID", "VERSION", "RESOURCE", "FIELD", "VALUE")
+	for _, e := range entries {
+		for _, r := range e.Resources {
+			for _, q := range queries {
+				if field, ok := q.match(r); ok {
+					tw.AddLine(e.Name, e.ServiceID, e.Version, r.Kind+" "+r.Name, field, abbreviate(r.Fields[field]))
+					matches++
+					break
+				}
+			}
+		}
+	}
+	if matches == 0 {
+		text.Info(out, "No matches in the active versions of %d service(s)", len(entries))
+		return nil
+	}
+	tw.Print()
+	return nil
+}
+
+// abbreviate shortens long values, such as log formats, for display.
+func abbreviate(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 60 {
+		return string(r[:59]) + "…"
+	}
+	return s
+}
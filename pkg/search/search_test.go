@@ -0,0 +1,116 @@
+package search_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/fakeapi"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/apptest"
+	"github.com/fastly/cli/pkg/testutil/golden"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestSearch(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"search"},
+		},
+		{
+			Args: []string{"search", "--domain", "example.com"},
+			API:  mock.API{ListServicesFn: listServicesError},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+// TestSearchServices searches services served by a fake of the Fastly API.
+func TestSearchServices(t *testing.T) {
+	cache, err := ioutil.TempDir("", "fastly-search")
+	testutil.AssertNoError(t, err)
+	defer os.RemoveAll(cache)
+	for _, name := range []string{"XDG_CACHE_HOME", "HOME"} {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Setenv(name, cache)
+	}
+
+	server := fakeapi.New()
+	defer server.Close()
+	client, err := fastly.NewClientForEndpoint("fake", server.URL)
+	testutil.AssertNoError(t, err)
+
+	shop := server.AddService("shop", "vcl")
+	_, err = client.CreateDomain(&fastly.CreateDomainInput{ServiceID: shop, ServiceVersion: 1, Name: "shop.example.com"})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: shop, ServiceVersion: 1, Name: "origin", Address: "origin.example.com", Port: 443})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateSyslog(&fastly.CreateSyslogInput{ServiceID: shop, ServiceVersion: 1, Name: "logs", Address: "logs.example.net", Port: 514, Token: "secret-token"})
+	testutil.AssertNoError(t, err)
+	_, err = client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: shop, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+
+	api := server.AddService("api", "vcl")
+	_, err = client.CreateDomain(&fastly.CreateDomainInput{ServiceID: api, ServiceVersion: 1, Name: "api.example.net"})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: api, ServiceVersion: 1, Name: "api", Address: "api.example.org", Port: 443})
+	testutil.AssertNoError(t, err)
+	_, err = client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: api, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+
+	// Services with no active version aren't searched.
+	draft := server.AddService("draft", "vcl")
+	_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: draft, ServiceVersion: 1, Name: "origin", Address: "origin.example.com", Port: 443})
+	testutil.AssertNoError(t, err)
+
+	for _, testcase := range []struct {
+		name string
+		args []string
+	}{
+		{"backend address", []string{"search", "--backend-address", "origin.example", "--verbose"}},
+		{"domain", []string{"search", "--domain", "EXAMPLE.NET", "--verbose"}},
+		{"logging host", []string{"search", "--logging-host", "logs"}},
+		{"text", []string{"search", "api"}},
+		{"secrets", []string{"search", "secret-token"}},
+		{"several queries", []string{"search", "--domain", "shop", "--backend-address", "api"}},
+		{"no matches", []string{"search", "nothing"}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			out, err := apptest.Run(t, server.URL, nil, testcase.args...)
+			golden.Assert(t, strings.ReplaceAll(golden.Format(out, err), server.URL, "http://fakeapi"))
+		})
+	}
+
+	// Activating a new version of a service invalidates its cached index.
+	_, err = client.CloneVersion(&fastly.CloneVersionInput{ServiceID: api, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: api, ServiceVersion: 2, Name: "api-v2", Address: "api-v2.example.org", Port: 443})
+	testutil.AssertNoError(t, err)
+	_, err = client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: api, ServiceVersion: 2})
+	testutil.AssertNoError(t, err)
+
+	for _, testcase := range []struct {
+		name string
+		args []string
+	}{
+		{"after activation", []string{"search", "--backend-address", "example.org", "--verbose"}},
+		{"refresh", []string{"search", "--backend-address", "example.org", "--verbose", "--refresh"}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			out, err := apptest.Run(t, server.URL, nil, testcase.args...)
+			golden.Assert(t, strings.ReplaceAll(golden.Format(out, err), server.URL, "http://fakeapi"))
+		})
+	}
+}
+
+func listServicesError(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
+	return nil, errTest
+}
+
+var errTest = errors.New("fixture error")
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/logging"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// Backup is the configuration of a service, as written by service backup and
+// read by service restore.
+type Backup struct {
+	Service   *fastly.ServiceDetail `json:"service"`
+	Version   int                   `json:"version"`
+	Resources []BackupResources     `json:"resources"`
+
+	// DictionaryItems are the items of each dictionary, by name. The items of
+	// write-only dictionaries can't be read, so aren't included.
+	DictionaryItems map[string]map[string]string `json:"dictionary_items,omitempty"`
+
+	// Package describes the Compute@Edge package of the version, if it has one.
+	// The package itself can't be downloaded from the API.
+	Package *fastly.PackageMetadata `json:"package,omitempty"`
+}
+
+// BackupResources are the resources of a kind in a backed up version.
+type BackupResources struct {
+	Kind  string          `json:"kind"`  // e.g. "backend" or "logging s3"
+	Type  string          `json:"type"`  // the go-fastly type, which names its API calls
+	Items json.RawMessage `json:"items"` // the go-fastly resources
+}
+
+// backupKind is a kind of versioned resource, and the API call listing them.
+type backupKind struct {
+	name string
+	list logging.ListFunc
+}
+
+// backupKinds are the kinds of resource in a backup, in the order they're
+// restored: conditions before the resources which refer to them by name, and
+// health checks before the backends which refer to them. Logging endpoints,
+// which may also refer to conditions, are added by init, from logging.Lists.
+var backupKinds = []backupKind{
+	{"condition", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListConditions(&fastly.ListConditionsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"domain", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDomains(&fastly.ListDomainsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"healthcheck", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListHealthChecks(&fastly.ListHealthChecksInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"backend", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListBackends(&fastly.ListBackendsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"dictionary", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"header", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListHeaders(&fastly.ListHeadersInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"gzip", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListGzips(&fastly.ListGzipsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"cache setting", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListCacheSettings(&fastly.ListCacheSettingsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"request setting", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListRequestSettings(&fastly.ListRequestSettingsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"response object", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListResponseObjects(&fastly.ListResponseObjectsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"vcl", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListVCLs(&fastly.ListVCLsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"snippet", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListSnippets(&fastly.ListSnippetsInput{ServiceID: id, ServiceVersion: v})
+	}},
+}
+
+func init() {
+	providers := make([]string, 0, len(logging.Lists))
+	for provider := range logging.Lists {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		backupKinds = append(backupKinds, backupKind{"logging " + provider, logging.Lists[provider]})
+	}
+}
+
+// BackupCommand calls the Fastly API to back up the configuration of services
+// to local files.
+type BackupCommand struct {
+	common.Base
+	manifest manifest.Data
+	all      bool
+	output   string
+}
+
+// NewBackupCommand returns a usable command registered under the parent.
+func NewBackupCommand(parent common.Registerer, globals *config.Data) *BackupCommand {
+	var c BackupCommand
+	c.Globals = globals
+	c.manifest.File.Read(manifest.Filename)
+	c.CmdClause = parent.Command("backup", "Back up the configuration of Fastly services to local JSON files")
+	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
+	c.CmdClause.Flag("all", "Back up every service").BoolVar(&c.all)
+	c.CmdClause.Flag("output", "Directory to write a <service ID>.json file to for each service").Short('o').Required().StringVar(&c.output)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *BackupCommand) Exec(in io.Reader, out io.Writer) error {
+	var serviceIDs []string
+	switch {
+	case c.all && c.manifest.Flag.ServiceID != "":
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: --all and --service-id can't be used together"),
+			Remediation: "Pass either --all, or --service-id, but not both.",
+		}
+	case c.all:
+		services, err := c.Globals.Client.ListServices(&fastly.ListServicesInput{})
+		if err != nil {
+			return err
+		}
+		for _, s := range services {
+			serviceIDs = append(serviceIDs, s.ID)
+		}
+	default:
+		serviceID, source := c.manifest.ServiceID()
+		if source == manifest.SourceUndefined {
+			return errors.ErrNoServiceID
+		}
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+
+	if err := os.MkdirAll(c.output, 0750); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+
+	for _, serviceID := range serviceIDs {
+		b, err := backupService(c.Globals.Client, serviceID)
+		if err != nil {
+			return fmt.Errorf("error backing up service %s: %w", serviceID, err)
+		}
+		data, err := json.MarshalIndent(b, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error backing up service %s: %w", serviceID, err)
+		}
+		// Backups include secrets, such as the credentials of logging endpoints,
+		// so are only readable by their owner.
+		path := filepath.Join(c.output, serviceID+".json")
+		if err := ioutil.WriteFile(path, append(data, '\n'), 0600); err != nil {
+			return fmt.Errorf("error writing backup: %w", err)
+		}
+		fmt.Fprintf(out, "Backed up service %s (%s) version %d to %s\n", serviceID, b.Service.Name, b.Version, path)
+	}
+
+	text.Success(out, "Backed up %d service(s) to %s", len(serviceIDs), c.output)
+	return nil
+}
+
+// backupService returns a backup of the active version of a service, or its
+// latest version if none is active.
+func backupService(client api.Interface, serviceID string) (*Backup, error) {
+	service, err := client.GetServiceDetails(&fastly.GetServiceInput{ID: serviceID})
+	if err != nil {
+		return nil, err
+	}
+	b := &Backup{Service: service, Version: service.ActiveVersion.Number}
+	if b.Version == 0 {
+		b.Version = service.Version.Number
+	}
+
+	for _, kind := range backupKinds {
+		resources, err := kind.list(client, serviceID, b.Version)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources: %w", kind.name, err)
+		}
+		v := reflect.ValueOf(resources)
+		if v.Len() == 0 {
+			continue
+		}
+		items, err := json.Marshal(resources)
+		if err != nil {
+			return nil, err
+		}
+		b.Resources = append(b.Resources, BackupResources{
+			Kind:  kind.name,
+			Type:  v.Type().Elem().Elem().Name(),
+			Items: items,
+		})
+
+		if kind.name != "dictionary" {
+			continue
+		}
+		for _, d := range resources.([]*fastly.Dictionary) {
+			if d.WriteOnly {
+				continue
+			}
+			items, err := client.ListDictionaryItems(&fastly.ListDictionaryItemsInput{ServiceID: serviceID, DictionaryID: d.ID})
+			if err != nil {
+				return nil, fmt.Errorf("error listing items of dictionary %s: %w", d.Name, err)
+			}
+			if b.DictionaryItems == nil {
+				b.DictionaryItems = make(map[string]map[string]string)
+			}
+			b.DictionaryItems[d.Name] = make(map[string]string)
+			for _, item := range items {
+				b.DictionaryItems[d.Name][item.ItemKey] = item.ItemValue
+			}
+		}
+	}
+
+	// Not every service has a package, so its absence isn't an error.
+	p, err := client.GetPackage(&fastly.GetPackageInput{ServiceID: serviceID, ServiceVersion: b.Version})
+	switch {
+	case err == nil && p.Metadata.HashSum != "":
+		b.Package = &p.Metadata
+	case err != nil && errors.HTTPStatus(err) != http.StatusNotFound:
+		return nil, fmt.Errorf("error getting package: %w", err)
+	}
+	return b, nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/common"
@@ -36,6 +37,10 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 	c.Input.ID = serviceID
 
+	if err := c.confirm(in, out); err != nil {
+		return err
+	}
+
 	if err := c.Globals.Client.DeleteService(&c.Input); err != nil {
 		return err
 	}
@@ -43,3 +48,19 @@ func (c *DeleteCommand) Exec(in io.Reader, out io.Writer) error {
 	text.Success(out, "Deleted service ID %s", c.Input.ID)
 	return nil
 }
+
+// confirm asks the user to type the name of the service before it's deleted.
+// The service is only looked up when a prompt will actually be shown.
+func (c *DeleteCommand) confirm(in io.Reader, out io.Writer) error {
+	if c.Globals.AutoYes() || !text.IsInteractive(in) {
+		return text.Confirm(out, in, c.Globals.AutoYes(), "", "")
+	}
+
+	s, err := c.Globals.Client.GetService(&fastly.GetServiceInput{ID: c.Input.ID})
+	if err != nil {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Delete service %s (%s)? This cannot be undone.", s.Name, c.Input.ID)
+	return text.Confirm(out, in, false, prompt, s.Name)
+}
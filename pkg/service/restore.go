@@ -0,0 +1,228 @@
+package service
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// RestoreCommand calls the Fastly API to recreate a service from a backup.
+type RestoreCommand struct {
+	common.Base
+	file        string
+	serviceID   string
+	name        common.OptionalString
+	packagePath string
+	activate    bool
+}
+
+// NewRestoreCommand returns a usable command registered under the parent.
+func NewRestoreCommand(parent common.Registerer, globals *config.Data) *RestoreCommand {
+	var c RestoreCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("restore", "Recreate a Fastly service from a backup, as a new service or a new version of an existing one")
+	c.CmdClause.Flag("file", "Path to a backup written by service backup").Short('f').Required().StringVar(&c.file)
+	c.CmdClause.Flag("service-id", "ID of an existing service to restore to, instead of a new service").Short('s').StringVar(&c.serviceID)
+	c.CmdClause.Flag("name", "Name of the new service, defaulting to the name of the backed up service").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("package", "Path to the Compute@Edge package of the backed up version, which must match its hash").StringVar(&c.packagePath)
+	c.CmdClause.Flag("activate", "Activate the restored version").BoolVar(&c.activate)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *RestoreCommand) Exec(in io.Reader, out io.Writer) error {
+	data, err := ioutil.ReadFile(c.file)
+	if err != nil {
+		return fmt.Errorf("error reading backup: %w", err)
+	}
+	var b Backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return fmt.Errorf("error reading backup: %w", err)
+	}
+	if b.Service == nil {
+		return fmt.Errorf("error reading backup: %s has no service", c.file)
+	}
+
+	// Check the package before changing anything.
+	if b.Package != nil && c.packagePath != "" {
+		hashSum, err := hashFile(c.packagePath)
+		if err != nil {
+			return fmt.Errorf("error reading package: %w", err)
+		}
+		if hashSum != b.Package.HashSum {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("error reading package: %s doesn't match the package of the backed up version", c.packagePath),
+				Remediation: fmt.Sprintf("Provide the package %s (%s) which was deployed to version %d.", b.Package.Name, b.Package.Language, b.Version),
+			}
+		}
+	}
+
+	client := c.Globals.Client
+
+	var (
+		serviceID = c.serviceID
+		version   int
+	)
+	if serviceID == "" {
+		name := b.Service.Name
+		if c.name.WasSet {
+			name = c.name.Value
+		}
+		s, err := client.CreateService(&fastly.CreateServiceInput{Name: name, Type: b.Service.Type, Comment: b.Service.Comment})
+		if err != nil {
+			return fmt.Errorf("error creating service: %w", err)
+		}
+		serviceID, version = s.ID, 1
+	} else {
+		v, err := client.CreateVersion(&fastly.CreateVersionInput{ServiceID: serviceID})
+		if err != nil {
+			return fmt.Errorf("error creating version: %w", err)
+		}
+		version = v.Number
+	}
+
+	if _, err := client.UpdateVersion(&fastly.UpdateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Comment:        fastly.String(fmt.Sprintf("Restored from a backup of service %s version %d", b.Service.ID, b.Version)),
+	}); err != nil {
+		return fmt.Errorf("error setting version comment: %w", err)
+	}
+
+	dictionaries := make(map[string]string) // IDs of created dictionaries, by name
+	for _, resources := range b.Resources {
+		created, err := restoreResources(client, serviceID, version, resources)
+		if err != nil {
+			return fmt.Errorf("error restoring %s resources: %w", resources.Kind, err)
+		}
+		for _, r := range created {
+			if d, ok := r.(*fastly.Dictionary); ok {
+				dictionaries[d.Name] = d.ID
+			}
+		}
+	}
+
+	names := make([]string, 0, len(b.DictionaryItems))
+	for name := range b.DictionaryItems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := restoreItems(client, serviceID, dictionaries[name], b.DictionaryItems[name]); err != nil {
+			return fmt.Errorf("error restoring items of dictionary %s: %w", name, err)
+		}
+	}
+
+	if b.Package != nil {
+		if c.packagePath == "" {
+			text.Warning(out, "The backed up version had the Compute@Edge package %s, which isn't included in backups. Restore it via --package, or deploy it to version %d.", b.Package.Name, version)
+		} else if _, err := client.UpdatePackage(&fastly.UpdatePackageInput{ServiceID: serviceID, ServiceVersion: version, PackagePath: c.packagePath}); err != nil {
+			return fmt.Errorf("error uploading package: %w", err)
+		}
+	}
+
+	if c.activate {
+		if _, err := client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: serviceID, ServiceVersion: version}); err != nil {
+			return err
+		}
+	}
+
+	text.Success(out, "Restored service %s version %d to service %s version %d", b.Service.ID, b.Version, serviceID, version)
+	if !c.activate {
+		text.Info(out, "Version %d isn't active; activate it via service-version activate", version)
+	}
+	return nil
+}
+
+// restoreResources creates backed up resources in a service version, via the
+// client's Create method for their go-fastly type, e.g. CreateBackend. It
+// returns the created resources.
+func restoreResources(client api.Interface, serviceID string, version int, resources BackupResources) ([]interface{}, error) {
+	create := reflect.ValueOf(client).MethodByName("Create" + resources.Type)
+	if !create.IsValid() {
+		return nil, fmt.Errorf("unsupported resource type %s", resources.Type)
+	}
+	inputType := create.Type().In(0).Elem()
+	resourceType := create.Type().Out(0)
+
+	items := reflect.New(reflect.SliceOf(resourceType))
+	if err := json.Unmarshal(resources.Items, items.Interface()); err != nil {
+		return nil, err
+	}
+
+	var created []interface{}
+	for i := 0; i < items.Elem().Len(); i++ {
+		input := reflect.New(inputType)
+		api.SetFields(input.Interface(), items.Elem().Index(i).Interface())
+		input.Elem().FieldByName("ServiceID").SetString(serviceID)
+		input.Elem().FieldByName("ServiceVersion").SetInt(int64(version))
+
+		out := create.Call([]reflect.Value{input})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, err
+		}
+		created = append(created, out[0].Interface())
+	}
+	return created, nil
+}
+
+// restoreItems creates items in a dictionary, in batches no larger than the
+// API allows.
+func restoreItems(client api.Interface, serviceID, dictionaryID string, items map[string]string) error {
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for len(keys) > 0 {
+		n := len(keys)
+		if n > fastly.BatchModifyMaximumOperations {
+			n = fastly.BatchModifyMaximumOperations
+		}
+		batch := make([]*fastly.BatchDictionaryItem, n)
+		for i, k := range keys[:n] {
+			batch[i] = &fastly.BatchDictionaryItem{Operation: fastly.CreateBatchOperation, ItemKey: k, ItemValue: items[k]}
+		}
+		if err := client.BatchModifyDictionaryItems(&fastly.BatchModifyDictionaryItemsInput{
+			ServiceID:    serviceID,
+			DictionaryID: dictionaryID,
+			Items:        batch,
+		}); err != nil {
+			return err
+		}
+		keys = keys[n:]
+	}
+	return nil
+}
+
+// hashFile returns the hash of a file in the form of a package's HashSum.
+func hashFile(path string) (string, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we trust the source of the filepath variable.
+	/* #nosec */
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close() // #nosec G307
+
+	h := sha512.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/fakeapi"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// TestBackupKindsRestorable checks that every kind of resource which is backed
+// up has a Create method, as used by restore, whose input can be populated
+// from the backed up resource.
+func TestBackupKindsRestorable(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+	serviceID := server.AddService("example", "vcl")
+	client, err := fastly.NewClientForEndpoint("fake", server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iface := reflect.TypeOf((*api.Interface)(nil)).Elem()
+	for _, kind := range backupKinds {
+		resources, err := kind.list(client, serviceID, 1)
+		if err != nil {
+			t.Fatalf("%s: %v", kind.name, err)
+		}
+		resourceType := reflect.TypeOf(resources).Elem()
+		name := "Create" + resourceType.Elem().Name()
+		method, ok := iface.MethodByName(name)
+		if !ok {
+			t.Errorf("%s: api.Interface has no method %s", kind.name, name)
+			continue
+		}
+		if have := method.Type.Out(0); have != resourceType {
+			t.Errorf("%s: %s returns %s, want %s", kind.name, name, have, resourceType)
+		}
+		for _, field := range []string{"ServiceID", "ServiceVersion", "Name"} {
+			if _, ok := method.Type.In(0).Elem().FieldByName(field); !ok {
+				t.Errorf("%s: %s input has no %s field", kind.name, name, field)
+			}
+		}
+	}
+}
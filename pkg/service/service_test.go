@@ -1,392 +1,414 @@
 package service_test
 
 import (
-	"bytes"
+	"encoding/json"
 	"errors"
-	"io"
-	"net/http"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/fakeapi"
 	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/service"
 	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/apptest"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestServiceCreate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"service", "create"},
-			api:       mock.API{CreateServiceFn: createServiceOK},
-			wantError: "error parsing arguments: required flag --name not provided",
+			Args: []string{"service", "create"},
+			API:  mock.API{CreateServiceFn: createServiceOK},
 		},
 		{
-			args:       []string{"service", "create", "--name", "Foo"},
-			api:        mock.API{CreateServiceFn: createServiceOK},
-			wantOutput: "Created service 12345",
+			Args: []string{"service", "create", "--name", "Foo"},
+			API:  mock.API{CreateServiceFn: createServiceOK},
 		},
 		{
-			args:       []string{"service", "create", "-n=Foo"},
-			api:        mock.API{CreateServiceFn: createServiceOK},
-			wantOutput: "Created service 12345",
+			Args: []string{"service", "create", "-n=Foo"},
+			API:  mock.API{CreateServiceFn: createServiceOK},
 		},
 		{
-			args:       []string{"service", "create", "--name", "Foo", "--type", "wasm"},
-			api:        mock.API{CreateServiceFn: createServiceOK},
-			wantOutput: "Created service 12345",
+			Args: []string{"service", "create", "--name", "Foo", "--type", "wasm"},
+			API:  mock.API{CreateServiceFn: createServiceOK},
 		},
 		{
-			args:       []string{"service", "create", "--name", "Foo", "--type", "wasm", "--comment", "Hello"},
-			api:        mock.API{CreateServiceFn: createServiceOK},
-			wantOutput: "Created service 12345",
+			Args: []string{"service", "create", "--name", "Foo", "--type", "wasm", "--comment", "Hello"},
+			API:  mock.API{CreateServiceFn: createServiceOK},
 		},
 		{
-			args:       []string{"service", "create", "-n", "Foo", "--comment", "Hello"},
-			api:        mock.API{CreateServiceFn: createServiceOK},
-			wantOutput: "Created service 12345",
+			Args: []string{"service", "create", "-n", "Foo", "--comment", "Hello"},
+			API:  mock.API{CreateServiceFn: createServiceOK},
 		},
 		{
-			args:      []string{"service", "create", "-n", "Foo"},
-			api:       mock.API{CreateServiceFn: createServiceError},
-			wantError: errTest.Error(),
+			Args: []string{"service", "create", "-n", "Foo"},
+			API:  mock.API{CreateServiceFn: createServiceError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                            = testcase.args
-				env                             = config.Environment{}
-				file                            = config.File{}
-				configFileName                  = "/dev/null"
-				clientFactory                   = mock.APIClient(testcase.api)
-				httpClient                      = http.DefaultClient
-				versioner      update.Versioner = nil
-				in             io.Reader        = nil
-				out            bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFileName, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestServiceList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"service", "list"},
-			api:        mock.API{ListServicesFn: listServicesOK},
-			wantOutput: listServicesShortOutput,
+			Args: []string{"service", "list"},
+			API:  mock.API{ListServicesFn: listServicesOK},
 		},
 		{
-			args:       []string{"service", "list", "--verbose"},
-			api:        mock.API{ListServicesFn: listServicesOK},
-			wantOutput: listServicesVerboseOutput,
+			Args: []string{"service", "list", "--verbose"},
+			API:  mock.API{ListServicesFn: listServicesOK},
 		},
 		{
-			args:       []string{"service", "list", "-v"},
-			api:        mock.API{ListServicesFn: listServicesOK},
-			wantOutput: listServicesVerboseOutput,
+			Args: []string{"service", "list", "-v"},
+			API:  mock.API{ListServicesFn: listServicesOK},
 		},
 		{
-			args:       []string{"service", "--verbose", "list"},
-			api:        mock.API{ListServicesFn: listServicesOK},
-			wantOutput: listServicesVerboseOutput,
+			Args: []string{"service", "--verbose", "list"},
+			API:  mock.API{ListServicesFn: listServicesOK},
 		},
 		{
-			args:       []string{"-v", "service", "list"},
-			api:        mock.API{ListServicesFn: listServicesOK},
-			wantOutput: listServicesVerboseOutput,
+			Args: []string{"-v", "service", "list"},
+			API:  mock.API{ListServicesFn: listServicesOK},
 		},
 		{
-			args:      []string{"service", "list"},
-			api:       mock.API{ListServicesFn: listServicesError},
-			wantError: errTest.Error(),
+			Args: []string{"service", "list"},
+			API:  mock.API{ListServicesFn: listServicesError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                            = testcase.args
-				env                             = config.Environment{}
-				file                            = config.File{}
-				configFileName                  = "/dev/null"
-				clientFactory                   = mock.APIClient(testcase.api)
-				httpClient                      = http.DefaultClient
-				versioner      update.Versioner = nil
-				in             io.Reader        = nil
-				out            bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFileName, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestServiceDescribe(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:      []string{"service", "describe"},
-			api:       mock.API{GetServiceDetailsFn: describeServiceOK},
-			wantError: "error reading service: no service ID found",
+			Args: []string{"service", "describe"},
+			API:  mock.API{GetServiceDetailsFn: describeServiceOK},
 		},
 		{
-			args:       []string{"service", "describe", "--service-id", "123"},
-			api:        mock.API{GetServiceDetailsFn: describeServiceOK},
-			wantOutput: describeServiceShortOutput,
+			Args: []string{"service", "describe", "--service-id", "123"},
+			API:  mock.API{GetServiceDetailsFn: describeServiceOK},
 		},
 		{
-			args:       []string{"service", "describe", "--service-id", "123", "--verbose"},
-			api:        mock.API{GetServiceDetailsFn: describeServiceOK},
-			wantOutput: describeServiceVerboseOutput,
+			Args: []string{"service", "describe", "--service-id", "123", "--verbose"},
+			API:  mock.API{GetServiceDetailsFn: describeServiceOK},
 		},
 		{
-			args:       []string{"service", "describe", "--service-id", "123", "-v"},
-			api:        mock.API{GetServiceDetailsFn: describeServiceOK},
-			wantOutput: describeServiceVerboseOutput,
+			Args: []string{"service", "describe", "--service-id", "123", "-v"},
+			API:  mock.API{GetServiceDetailsFn: describeServiceOK},
 		},
 		{
-			args:       []string{"service", "--verbose", "describe", "--service-id", "123"},
-			api:        mock.API{GetServiceDetailsFn: describeServiceOK},
-			wantOutput: describeServiceVerboseOutput,
+			Args: []string{"service", "--verbose", "describe", "--service-id", "123"},
+			API:  mock.API{GetServiceDetailsFn: describeServiceOK},
 		},
 		{
-			args:       []string{"-v", "service", "describe", "--service-id", "123"},
-			api:        mock.API{GetServiceDetailsFn: describeServiceOK},
-			wantOutput: describeServiceVerboseOutput,
+			Args: []string{"-v", "service", "describe", "--service-id", "123"},
+			API:  mock.API{GetServiceDetailsFn: describeServiceOK},
 		},
 		{
-			args:      []string{"service", "describe", "--service-id", "123"},
-			api:       mock.API{GetServiceDetailsFn: describeServiceError},
-			wantError: errTest.Error(),
+			Args: []string{"service", "describe", "--service-id", "123"},
+			API:  mock.API{GetServiceDetailsFn: describeServiceError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestServiceSearch(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"service", "search", "--name", "Foo"},
-			api:        mock.API{SearchServiceFn: searchServiceOK},
-			wantOutput: searchServiceShortOutput,
+			Args: []string{"service", "search", "--name", "Foo"},
+			API:  mock.API{SearchServiceFn: searchServiceOK},
 		},
 		{
-			args:       []string{"service", "search", "--name", "Foo", "-v"},
-			api:        mock.API{SearchServiceFn: searchServiceOK},
-			wantOutput: searchServiceVerboseOutput,
+			Args: []string{"service", "search", "--name", "Foo", "-v"},
+			API:  mock.API{SearchServiceFn: searchServiceOK},
 		},
 		{
-			args:      []string{"service", "search", "--name"},
-			api:       mock.API{SearchServiceFn: searchServiceOK},
-			wantError: "error parsing arguments: expected argument for flag '--name'",
+			Args: []string{"service", "search", "--name"},
+			API:  mock.API{SearchServiceFn: searchServiceOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestServiceUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args: []string{"service", "update"},
-			api: mock.API{
+			Args: []string{"service", "update"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantError: "error reading service: no service ID found",
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantError: "error parsing arguments: must provide either --name or --comment to update service",
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345", "--name", "Foo"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345", "--name", "Foo"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantOutput: "Updated service 12345",
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345", "-n=Foo"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345", "-n=Foo"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantOutput: "Updated service 12345",
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345", "--name", "Foo"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345", "--name", "Foo"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantOutput: "Updated service 12345",
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345", "--name", "Foo", "--comment", "Hello"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345", "--name", "Foo", "--comment", "Hello"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantOutput: "Updated service 12345",
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345", "-n", "Foo", "--comment", "Hello"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345", "-n", "Foo", "--comment", "Hello"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantOutput: "Updated service 12345",
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345", "-n", "Foo"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345", "-n", "Foo"},
+			API: mock.API{
 				GetServiceFn:    getServiceError,
 				UpdateServiceFn: updateServiceOK,
 			},
-			wantError: errTest.Error(),
 		},
 		{
-			args: []string{"service", "update", "--service-id", "12345", "-n", "Foo"},
-			api: mock.API{
+			Args: []string{"service", "update", "--service-id", "12345", "-n", "Foo"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateServiceFn: updateServiceError,
 			},
-			wantError: errTest.Error(),
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                            = testcase.args
-				env                             = config.Environment{}
-				file                            = config.File{}
-				configFileName                  = "/dev/null"
-				clientFactory                   = mock.APIClient(testcase.api)
-				httpClient                      = http.DefaultClient
-				versioner      update.Versioner = nil
-				in             io.Reader        = nil
-				out            bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFileName, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestServiceDelete(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service", "delete", "--auto-yes"},
+			API:  mock.API{DeleteServiceFn: deleteServiceOK},
+		},
+		{
+			Args: []string{"service", "delete", "--service-id=X", "--auto-yes"},
+			API:  mock.API{DeleteServiceFn: deleteServiceOK},
+		},
 		{
-			args:      []string{"service", "delete"},
-			api:       mock.API{DeleteServiceFn: deleteServiceOK},
-			wantError: "error reading service: no service ID found",
+			Args: []string{"service", "delete", "--service-id", "zzz", "--auto-yes"},
+			API:  mock.API{DeleteServiceFn: deleteServiceOK},
 		},
 		{
-			args:       []string{"service", "delete", "--service-id=X"},
-			api:        mock.API{DeleteServiceFn: deleteServiceOK},
-			wantOutput: "Deleted service ID X",
+			Args: []string{"service", "delete", "--service-id", "bonk", "--auto-yes"},
+			API:  mock.API{DeleteServiceFn: deleteServiceError},
 		},
 		{
-			args:       []string{"service", "delete", "--service-id", "zzz"},
-			api:        mock.API{DeleteServiceFn: deleteServiceOK},
-			wantOutput: "Deleted service ID zzz",
+			Args: []string{"service", "delete", "--service-id", "123"},
+			API:  mock.API{DeleteServiceFn: deleteServiceOK},
 		},
 		{
-			args:      []string{"service", "delete", "--service-id", "bonk"},
-			api:       mock.API{DeleteServiceFn: deleteServiceError},
-			wantError: errTest.Error(),
+			Args:  []string{"service", "delete", "--service-id", "123"},
+			API:   mock.API{GetServiceFn: getServiceOK, DeleteServiceFn: deleteServiceOK},
+			Stdin: "Foo\n",
+		},
+		{
+			Args:  []string{"service", "delete", "--service-id", "123"},
+			API:   mock.API{GetServiceFn: getServiceOK, DeleteServiceFn: deleteServiceError},
+			Stdin: "123\n",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                            = testcase.args
-				env                             = config.Environment{}
-				file                            = config.File{}
-				configFileName                  = "/dev/null"
-				clientFactory                   = mock.APIClient(testcase.api)
-				httpClient                      = http.DefaultClient
-				versioner      update.Versioner = nil
-				in             io.Reader        = nil
-				out            bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFileName, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 var errTest = errors.New("fixture error")
 
+func TestServiceBackup(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service", "backup", "--output", "backups"},
+		},
+		{
+			Args: []string{"service", "backup", "--all", "--service-id", "123", "--output", "backups"},
+		},
+		{
+			Args: []string{"service", "backup", "--all", "--output", "backups"},
+			API:  mock.API{ListServicesFn: listServicesError},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func TestServiceRestore(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service", "restore"},
+		},
+		{
+			Args: []string{"service", "restore", "--file", "missing.json"},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+// TestServiceBackupRestore backs up services served by a fake of the Fastly
+// API, restores them, and checks the restored configuration matches.
+func TestServiceBackupRestore(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+	client, err := fastly.NewClientForEndpoint("fake", server.URL)
+	testutil.AssertNoError(t, err)
+
+	original := server.AddService("example", "vcl")
+	empty := server.AddService("empty", "vcl")
+	_, err = client.CreateDomain(&fastly.CreateDomainInput{ServiceID: original, ServiceVersion: 1, Name: "www.example.com", Comment: "main"})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateHealthCheck(&fastly.CreateHealthCheckInput{ServiceID: original, ServiceVersion: 1, Name: "origin", Path: "/health", Threshold: 3})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateCondition(&fastly.CreateConditionInput{ServiceID: original, ServiceVersion: 1, Name: "api", Statement: `req.url ~ "^/api/"`, Type: "REQUEST", Priority: 10})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: original, ServiceVersion: 1, Name: "origin", Address: "origin.example.com", Port: 443, UseSSL: true, HealthCheck: "origin", RequestCondition: "api"})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateHeader(&fastly.CreateHeaderInput{ServiceID: original, ServiceVersion: 1, Name: "cors", Action: fastly.HeaderActionSet, Type: fastly.HeaderTypeResponse, Destination: "http.Access-Control-Allow-Origin", Source: `"*"`, RequestCondition: "api"})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateSnippet(&fastly.CreateSnippetInput{ServiceID: original, ServiceVersion: 1, Name: "recv", Type: fastly.SnippetTypeRecv, Priority: 100, Content: "set req.http.X-Example = \"1\";"})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateSyslog(&fastly.CreateSyslogInput{ServiceID: original, ServiceVersion: 1, Name: "logs", Address: "logs.example.com", Port: 514})
+	testutil.AssertNoError(t, err)
+	dictionary, err := client.CreateDictionary(&fastly.CreateDictionaryInput{ServiceID: original, ServiceVersion: 1, Name: "settings"})
+	testutil.AssertNoError(t, err)
+	for _, k := range []string{"a", "b"} {
+		_, err = client.CreateDictionaryItem(&fastly.CreateDictionaryItemInput{ServiceID: original, DictionaryID: dictionary.ID, ItemKey: k, ItemValue: strings.ToUpper(k)})
+		testutil.AssertNoError(t, err)
+	}
+	_, err = client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: original, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+
+	dir, err := ioutil.TempDir("", "fastly-backup")
+	testutil.AssertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	edge := server.AddService("edge", "wasm")
+	pkg := filepath.Join(dir, "edge.tar.gz")
+	testutil.AssertNoError(t, ioutil.WriteFile(pkg, []byte("package"), 0600))
+	_, err = client.UpdatePackage(&fastly.UpdatePackageInput{ServiceID: edge, ServiceVersion: 1, PackagePath: pkg})
+	testutil.AssertNoError(t, err)
+	other := testutil.MakeTempFile(t, "other package")
+	defer os.Remove(other)
+
+	backup := filepath.Join(dir, original+".json")
+
+	for _, testcase := range []struct {
+		name string
+		args []string
+	}{
+		{"backup", []string{"service", "backup", "--all", "--output", dir}},
+		{"restore to new service", []string{"service", "restore", "--file", backup, "--name", "copy", "--activate"}},
+		{"restore to existing service", []string{"service", "restore", "--file", backup, "--service-id", empty}},
+		{"restore without package", []string{"service", "restore", "--file", filepath.Join(dir, edge+".json"), "--name", "edge-copy"}},
+		{"restore with wrong package", []string{"service", "restore", "--file", filepath.Join(dir, edge+".json"), "--package", other, "--name", "edge-copy"}},
+		{"restore with package", []string{"service", "restore", "--file", filepath.Join(dir, edge+".json"), "--package", pkg, "--name", "edge-copy2"}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			out, err := apptest.Run(t, server.URL, nil, testcase.args...)
+			paths := strings.NewReplacer(dir, "backups", other, "other.tar.gz")
+			golden.Assert(t, paths.Replace(golden.Format(out, err)))
+		})
+	}
+
+	// The restored services are backed up in turn, for comparison.
+	want := readBackup(t, backup)
+	testutil.AssertEqual(t, 0, readBackup(t, filepath.Join(dir, empty+".json")).Version)
+	for _, serviceID := range []string{"service000004", empty} {
+		_, err := apptest.Run(t, server.URL, nil, "service", "backup", "--service-id", serviceID, "--output", dir)
+		testutil.AssertNoError(t, err)
+		have := readBackup(t, filepath.Join(dir, serviceID+".json"))
+		testutil.AssertEqual(t, want.Resources, have.Resources)
+		testutil.AssertEqual(t, want.DictionaryItems, have.DictionaryItems)
+	}
+
+	wantPackage, err := client.GetPackage(&fastly.GetPackageInput{ServiceID: edge, ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	havePackage, err := client.GetPackage(&fastly.GetPackageInput{ServiceID: "service000006", ServiceVersion: 1})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, wantPackage.Metadata.HashSum, havePackage.Metadata.HashSum)
+}
+
+// normalizedBackup is a backup without the fields which differ between
+// services, or versions.
+type normalizedBackup struct {
+	Version         int
+	Resources       map[string][]map[string]interface{}
+	DictionaryItems map[string]map[string]string
+}
+
+func readBackup(t *testing.T, path string) normalizedBackup {
+	t.Helper()
+	data, err := ioutil.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	var b service.Backup
+	testutil.AssertNoError(t, json.Unmarshal(data, &b))
+
+	n := normalizedBackup{
+		Version:         b.Service.ActiveVersion.Number,
+		Resources:       make(map[string][]map[string]interface{}),
+		DictionaryItems: b.DictionaryItems,
+	}
+	for _, resources := range b.Resources {
+		var items []map[string]interface{}
+		testutil.AssertNoError(t, json.Unmarshal(resources.Items, &items))
+		for _, item := range items {
+			for _, field := range []string{"ID", "ServiceID", "ServiceVersion", "CreatedAt", "UpdatedAt", "DeletedAt"} {
+				delete(item, field)
+			}
+		}
+		n.Resources[resources.Kind] = items
+	}
+	return n
+}
+
 func createServiceOK(i *fastly.CreateServiceInput) (*fastly.Service, error) {
 	return &fastly.Service{
 		ID:      "12345",
@@ -452,66 +474,6 @@ func listServicesError(i *fastly.ListServicesInput) ([]*fastly.Service, error) {
 	return nil, errTest
 }
 
-var listServicesShortOutput = strings.TrimSpace(`
-NAME  ID   TYPE  ACTIVE VERSION  LAST EDITED (UTC)
-Foo   123  wasm  2               2010-11-15 19:01
-Bar   456  wasm  1               2015-03-14 12:59
-Baz   789  vcl   1               n/a
-`) + "\n"
-
-var listServicesVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Service 1/3
-	ID: 123
-	Name: Foo
-	Type: wasm
-	Customer ID: mycustomerid
-	Last edited (UTC): 2010-11-15 19:01
-	Active version: 2
-	Versions: 2
-		Version 1/2
-			Number: 1
-			Comment: a
-			Service ID: b
-			Active: false
-			Locked: false
-			Deployed: false
-			Staging: false
-			Testing: false
-			Created (UTC): 2001-02-03 04:05
-			Last edited (UTC): 2001-02-04 04:05
-			Deleted (UTC): 2001-02-05 04:05
-		Version 2/2
-			Number: 2
-			Comment: c
-			Service ID: d
-			Active: true
-			Locked: false
-			Deployed: true
-			Staging: false
-			Testing: false
-			Created (UTC): 2001-03-03 04:05
-			Last edited (UTC): 2001-03-04 04:05
-
-Service 2/3
-	ID: 456
-	Name: Bar
-	Type: wasm
-	Customer ID: mycustomerid
-	Last edited (UTC): 2015-03-14 12:59
-	Active version: 1
-	Versions: 0
-
-Service 3/3
-	ID: 789
-	Name: Baz
-	Type: vcl
-	Customer ID: mycustomerid
-	Active version: 1
-	Versions: 0
-`) + "\n\n"
-
 func getServiceOK(i *fastly.GetServiceInput) (*fastly.Service, error) {
 	return &fastly.Service{
 		ID:      "12345",
@@ -566,94 +528,6 @@ func describeServiceError(i *fastly.GetServiceInput) (*fastly.ServiceDetail, err
 	return nil, errTest
 }
 
-var describeServiceShortOutput = strings.TrimSpace(`
-ID: 123
-Name: Foo
-Type: wasm
-Customer ID: mycustomerid
-Last edited (UTC): 2010-11-15 19:01
-Active version:
-	Number: 2
-	Comment: c
-	Service ID: d
-	Active: true
-	Locked: false
-	Deployed: true
-	Staging: false
-	Testing: false
-	Created (UTC): 2001-03-03 04:05
-	Last edited (UTC): 2001-03-04 04:05
-Versions: 2
-	Version 1/2
-		Number: 1
-		Comment: a
-		Service ID: b
-		Active: false
-		Locked: false
-		Deployed: false
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-02-03 04:05
-		Last edited (UTC): 2001-02-04 04:05
-		Deleted (UTC): 2001-02-05 04:05
-	Version 2/2
-		Number: 2
-		Comment: c
-		Service ID: d
-		Active: true
-		Locked: false
-		Deployed: true
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-03-03 04:05
-		Last edited (UTC): 2001-03-04 04:05
-`) + "\n"
-
-var describeServiceVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-ID: 123
-Name: Foo
-Type: wasm
-Customer ID: mycustomerid
-Last edited (UTC): 2010-11-15 19:01
-Active version:
-	Number: 2
-	Comment: c
-	Service ID: d
-	Active: true
-	Locked: false
-	Deployed: true
-	Staging: false
-	Testing: false
-	Created (UTC): 2001-03-03 04:05
-	Last edited (UTC): 2001-03-04 04:05
-Versions: 2
-	Version 1/2
-		Number: 1
-		Comment: a
-		Service ID: b
-		Active: false
-		Locked: false
-		Deployed: false
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-02-03 04:05
-		Last edited (UTC): 2001-02-04 04:05
-		Deleted (UTC): 2001-02-05 04:05
-	Version 2/2
-		Number: 2
-		Comment: c
-		Service ID: d
-		Active: true
-		Locked: false
-		Deployed: true
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-03-03 04:05
-		Last edited (UTC): 2001-03-04 04:05
-`) + "\n"
-
 func searchServiceOK(i *fastly.SearchServiceInput) (*fastly.Service, error) {
 	return &fastly.Service{
 		ID:         "123",
@@ -683,74 +557,6 @@ func searchServiceOK(i *fastly.SearchServiceInput) (*fastly.Service, error) {
 	}, nil
 }
 
-var searchServiceShortOutput = strings.TrimSpace(`
-ID: 123
-Name: Foo
-Type: wasm
-Customer ID: mycustomerid
-Last edited (UTC): 2010-11-15 19:01
-Active version: 0
-Versions: 2
-	Version 1/2
-		Number: 1
-		Comment: a
-		Service ID: b
-		Active: false
-		Locked: false
-		Deployed: false
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-02-03 04:05
-		Last edited (UTC): 2001-02-04 04:05
-		Deleted (UTC): 2001-02-05 04:05
-	Version 2/2
-		Number: 2
-		Comment: c
-		Service ID: d
-		Active: true
-		Locked: false
-		Deployed: true
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-03-03 04:05
-		Last edited (UTC): 2001-03-04 04:05
-`) + "\n"
-
-var searchServiceVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-ID: 123
-Name: Foo
-Type: wasm
-Customer ID: mycustomerid
-Last edited (UTC): 2010-11-15 19:01
-Active version: 0
-Versions: 2
-	Version 1/2
-		Number: 1
-		Comment: a
-		Service ID: b
-		Active: false
-		Locked: false
-		Deployed: false
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-02-03 04:05
-		Last edited (UTC): 2001-02-04 04:05
-		Deleted (UTC): 2001-02-05 04:05
-	Version 2/2
-		Number: 2
-		Comment: c
-		Service ID: d
-		Active: true
-		Locked: false
-		Deployed: true
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-03-03 04:05
-		Last edited (UTC): 2001-03-04 04:05
-`) + "\n"
-
 func updateServiceOK(i *fastly.UpdateServiceInput) (*fastly.Service, error) {
 	return &fastly.Service{
 		ID:      "12345",
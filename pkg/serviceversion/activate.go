@@ -1,7 +1,9 @@
 package serviceversion
 
 import (
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/fastly/cli/pkg/common"
 	"github.com/fastly/cli/pkg/compute/manifest"
@@ -16,32 +18,169 @@ type ActivateCommand struct {
 	common.Base
 	manifest manifest.Data
 	Input    fastly.ActivateVersionInput
+	comment  common.OptionalString
+	at       common.OptionalString
+
+	// now and sleep are the clock used to schedule activation, replaced in
+	// tests.
+	now   func() time.Time
+	sleep func(time.Duration)
 }
 
 // NewActivateCommand returns a usable command registered under the parent.
 func NewActivateCommand(parent common.Registerer, globals *config.Data) *ActivateCommand {
 	var c ActivateCommand
 	c.Globals = globals
+	c.now = time.Now
+	c.sleep = time.Sleep
 	c.manifest.File.Read(manifest.Filename)
 	c.CmdClause = parent.Command("activate", "Activate a Fastly service version")
 	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
 	c.CmdClause.Flag("version", "Number of version you wish to activate").Required().IntVar(&c.Input.ServiceVersion)
+	c.CmdClause.Flag("comment", "Human-readable comment, recording why the version was activated").Action(c.comment.Set).StringVar(&c.comment.Value)
+	c.CmdClause.Flag("at", "Wait until this time, e.g. 2026-10-20T02:00Z, or for this duration, e.g. 2h, to activate, refusing if the version or active version changes meanwhile").Action(c.at.Set).StringVar(&c.at.Value)
 	return &c
 }
 
 // Exec invokes the application logic for the command.
-func (c *ActivateCommand) Exec(in io.Reader, out io.Writer) error {
+func (c *ActivateCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	serviceID, source := c.manifest.ServiceID()
 	if source == manifest.SourceUndefined {
 		return errors.ErrNoServiceID
 	}
 	c.Input.ServiceID = serviceID
 
+	var at time.Time
+	if c.at.WasSet {
+		if at, err = parseAt(c.at.Value, c.now()); err != nil {
+			return err
+		}
+		if !at.After(c.now()) {
+			return fmt.Errorf("error scheduling activation: %s is in the past", at.UTC().Format(time.RFC3339))
+		}
+	}
+
+	var progress text.Progress = text.NewNullProgress()
+	if c.at.WasSet {
+		if c.Globals.Verbose() {
+			progress = text.NewVerboseProgress(out)
+		} else {
+			progress = text.NewQuietProgress(out)
+		}
+	}
+	defer func() {
+		if err != nil {
+			progress.Fail() // progress.Done is handled inline
+		}
+	}()
+
+	if c.at.WasSet {
+		if err := c.waitUntil(progress, at); err != nil {
+			return err
+		}
+	}
+
+	if c.comment.WasSet {
+		if _, err := c.Globals.Client.UpdateVersion(&fastly.UpdateVersionInput{
+			ServiceID:      serviceID,
+			ServiceVersion: c.Input.ServiceVersion,
+			Comment:        fastly.String(c.comment.Value),
+		}); err != nil {
+			return fmt.Errorf("error setting version comment: %w", err)
+		}
+	}
+
+	progress.Step(fmt.Sprintf("Activating version %d...", c.Input.ServiceVersion))
 	v, err := c.Globals.Client.ActivateVersion(&c.Input)
 	if err != nil {
 		return err
 	}
+	progress.Done()
 
 	text.Success(out, "Activated service %s version %d", v.ServiceID, c.Input.ServiceVersion)
 	return nil
 }
+
+// waitUntil counts down to at, and then checks that neither the version to be
+// activated nor the service's active version changed while waiting.
+func (c *ActivateCommand) waitUntil(progress text.Progress, at time.Time) error {
+	before, err := c.versionState()
+	if err != nil {
+		return err
+	}
+
+	progress.Step(fmt.Sprintf("Waiting until %s to activate version %d...", at.UTC().Format(common.TimeFormat), c.Input.ServiceVersion))
+	for remaining := at.Sub(c.now()); remaining > 0; remaining = at.Sub(c.now()) {
+		fmt.Fprintf(progress, "Activating version %d in %s\n", c.Input.ServiceVersion, remaining.Round(time.Second))
+		if remaining > time.Second {
+			remaining = time.Second
+		}
+		c.sleep(remaining)
+	}
+
+	progress.Step(fmt.Sprintf("Checking version %d is unchanged...", c.Input.ServiceVersion))
+	after, err := c.versionState()
+	if err != nil {
+		return err
+	}
+	switch {
+	case after.active != before.active:
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("refusing to activate version %d: the active version changed from %d to %d while waiting", c.Input.ServiceVersion, before.active, after.active),
+			Remediation: "Check the service's versions, and schedule the activation again if it's still wanted.",
+		}
+	case !after.updated.Equal(before.updated):
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("refusing to activate version %d: it was edited while waiting", c.Input.ServiceVersion),
+			Remediation: "Review the changes to the version, and schedule the activation again if it's still wanted.",
+		}
+	}
+	return nil
+}
+
+// versionState is what must stay the same while waiting to activate a version.
+type versionState struct {
+	active  int       // the service's active version, or 0 if there isn't one
+	updated time.Time // when the version to be activated was last edited
+}
+
+func (c *ActivateCommand) versionState() (versionState, error) {
+	var s versionState
+	versions, err := c.Globals.Client.ListVersions(&fastly.ListVersionsInput{ServiceID: c.Input.ServiceID})
+	if err != nil {
+		return s, err
+	}
+	found := false
+	for _, v := range versions {
+		if v.Active {
+			s.active = v.Number
+		}
+		if v.Number == c.Input.ServiceVersion {
+			found = true
+			if v.UpdatedAt != nil {
+				s.updated = *v.UpdatedAt
+			}
+		}
+	}
+	if !found {
+		return s, fmt.Errorf("error scheduling activation: version %d of service %s not found", c.Input.ServiceVersion, c.Input.ServiceID)
+	}
+	return s, nil
+}
+
+// parseAt parses the value of --at, which is either a time or a duration after
+// now.
+func parseAt(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(d), nil
+	}
+	for _, layout := range common.TimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.RemediationError{
+		Inner:       fmt.Errorf("error parsing --at: %q isn't a valid time", s),
+		Remediation: "Provide a time in RFC 3339 format, with an explicit time zone, e.g. 2026-10-20T02:00Z, or a duration from now, e.g. 2h.",
+	}
+}
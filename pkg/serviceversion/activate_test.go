@@ -0,0 +1,151 @@
+package serviceversion
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// TestActivateSchedule runs scheduled activations against a fake clock, which advances whenever the command sleeps.
+func TestActivateSchedule(t *testing.T) {
+	start := time.Date(2026, 10, 20, 1, 59, 57, 500000000, time.UTC)
+	edited := testutil.MustParseTimeRFC3339("2026-10-19T12:00:00Z")
+	reedited := testutil.MustParseTimeRFC3339("2026-10-20T01:59:59Z")
+
+	// versions returns a ListVersions function yielding each list in turn, and
+	// the last one thereafter.
+	versions := func(lists ...[]*fastly.Version) func(*fastly.ListVersionsInput) ([]*fastly.Version, error) {
+		return func(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+			list := lists[0]
+			if len(lists) > 1 {
+				lists = lists[1:]
+			}
+			return list, nil
+		}
+	}
+	stable := []*fastly.Version{
+		{Number: 2, Active: true, UpdatedAt: edited},
+		{Number: 3, UpdatedAt: edited},
+	}
+
+	for _, testcase := range []struct {
+		name        string
+		at          string
+		api         mock.API
+		wantError   string
+		wantOutput  []string
+		wantElapsed time.Duration
+	}{
+		{
+			name: "scheduled",
+			at:   "2026-10-20T02:00Z",
+			api: mock.API{
+				ListVersionsFn:    versions(stable),
+				ActivateVersionFn: activateVersionOK,
+			},
+			wantOutput: []string{
+				"Waiting until 2026-10-20 02:00 to activate version 3...",
+				"Activating version 3 in 3s",
+				"Activating version 3 in 2s",
+				"Activating version 3 in 1s",
+				"Checking version 3 is unchanged...",
+				"Activating version 3...",
+				"Activated service 123 version 3",
+			},
+			wantElapsed: 2500 * time.Millisecond,
+		},
+		{
+			name: "duration",
+			at:   "2s",
+			api: mock.API{
+				ListVersionsFn:    versions(stable),
+				ActivateVersionFn: activateVersionOK,
+			},
+			wantOutput: []string{
+				"Waiting until 2026-10-20 01:59 to activate version 3...",
+				"Activating version 3 in 2s",
+				"Activating version 3 in 1s",
+				"Activated service 123 version 3",
+			},
+			wantElapsed: 2 * time.Second,
+		},
+		{
+			name:      "in the past",
+			at:        "2026-10-20T01:00:00+00:00",
+			wantError: "error scheduling activation: 2026-10-20T01:00:00Z is in the past",
+		},
+		{
+			name:      "negative duration",
+			at:        "-1h",
+			wantError: "error scheduling activation: 2026-10-20T00:59:57Z is in the past",
+		},
+		{
+			name:      "invalid time",
+			at:        "tomorrow",
+			wantError: `error parsing --at: "tomorrow" isn't a valid time`,
+		},
+		{
+			name: "version not found",
+			at:   "2026-10-20T02:00Z",
+			api: mock.API{
+				ListVersionsFn: versions(stable[:1]),
+			},
+			wantError: "error scheduling activation: version 3 of service 123 not found",
+		},
+		{
+			name: "active version changed",
+			at:   "2026-10-20T02:00Z",
+			api: mock.API{
+				ListVersionsFn: versions(stable, []*fastly.Version{
+					{Number: 2, UpdatedAt: edited},
+					{Number: 3, UpdatedAt: edited},
+					{Number: 4, Active: true, UpdatedAt: edited},
+				}),
+			},
+			wantError:   "refusing to activate version 3: the active version changed from 2 to 4 while waiting",
+			wantElapsed: 2500 * time.Millisecond,
+		},
+		{
+			name: "version edited",
+			at:   "2026-10-20T02:00Z",
+			api: mock.API{
+				ListVersionsFn: versions(stable, []*fastly.Version{
+					{Number: 2, Active: true, UpdatedAt: edited},
+					{Number: 3, UpdatedAt: reedited},
+				}),
+			},
+			wantError:   "refusing to activate version 3: it was edited while waiting",
+			wantElapsed: 2500 * time.Millisecond,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			clock := start
+			c := &ActivateCommand{
+				now:   func() time.Time { return clock },
+				sleep: func(d time.Duration) { clock = clock.Add(d) },
+				at:    common.OptionalString{Optional: common.Optional{WasSet: testcase.at != ""}, Value: testcase.at},
+			}
+			c.Globals = &config.Data{Client: testcase.api, Flag: config.Flag{Verbose: true}}
+			c.manifest.Flag.ServiceID = "123"
+			c.Input.ServiceVersion = 3
+
+			var out bytes.Buffer
+			err := c.Exec(nil, &out)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, out.String(), s)
+			}
+			testutil.AssertEqual(t, testcase.wantElapsed, clock.Sub(start))
+		})
+	}
+}
+
+func activateVersionOK(i *fastly.ActivateVersionInput) (*fastly.Version, error) {
+	return &fastly.Version{ServiceID: i.ServiceID, Number: i.ServiceVersion, Active: true}, nil
+}
@@ -1,6 +1,7 @@
 package serviceversion
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/common"
@@ -16,6 +17,7 @@ type CloneCommand struct {
 	common.Base
 	manifest manifest.Data
 	Input    fastly.CloneVersionInput
+	comment  common.OptionalString
 }
 
 // NewCloneCommand returns a usable command registered under the parent.
@@ -26,6 +28,7 @@ func NewCloneCommand(parent common.Registerer, globals *config.Data) *CloneComma
 	c.CmdClause = parent.Command("clone", "Clone a Fastly service version")
 	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
 	c.CmdClause.Flag("version", "Number of version you wish to clone").Required().IntVar(&c.Input.ServiceVersion)
+	c.CmdClause.Flag("comment", "Human-readable comment for the new version").Action(c.comment.Set).StringVar(&c.comment.Value)
 	return &c
 }
 
@@ -42,6 +45,16 @@ func (c *CloneCommand) Exec(in io.Reader, out io.Writer) error {
 		return err
 	}
 
+	if c.comment.WasSet {
+		if _, err := c.Globals.Client.UpdateVersion(&fastly.UpdateVersionInput{
+			ServiceID:      v.ServiceID,
+			ServiceVersion: v.Number,
+			Comment:        fastly.String(c.comment.Value),
+		}); err != nil {
+			return fmt.Errorf("error setting version comment: %w", err)
+		}
+	}
+
 	text.Success(out, "Cloned service %s version %d to version %d", v.ServiceID, c.Input.ServiceVersion, v.Number)
 	return nil
 }
@@ -1,6 +1,7 @@
 package serviceversion
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/fastly/cli/pkg/common"
@@ -37,6 +38,11 @@ func (c *DeactivateCommand) Exec(in io.Reader, out io.Writer) error {
 	}
 	c.Input.ServiceID = serviceID
 
+	prompt := fmt.Sprintf("Deactivate service %s version %d?", c.Input.ServiceID, c.Input.ServiceVersion)
+	if err := text.Confirm(out, in, c.Globals.AutoYes(), prompt, ""); err != nil {
+		return err
+	}
+
 	v, err := c.Globals.Client.DeactivateVersion(&c.Input)
 	if err != nil {
 		return err
@@ -0,0 +1,241 @@
+package serviceversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/logging"
+	"github.com/fastly/cli/pkg/ratelimit"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// LogCommand calls the Fastly API to show the history of a service's versions.
+// The API can't list what changed in a version, so each version shown, and the
+// one before the oldest, costs a request per kind of resource in resourceKinds
+// and one for its package: about 30, or 330 for the default --limit of 10.
+// Requests which are rate limited are retried once the limit resets.
+type LogCommand struct {
+	common.Base
+	manifest manifest.Data
+	limit    int
+}
+
+// NewLogCommand returns a usable command registered under the parent.
+func NewLogCommand(parent common.Registerer, globals *config.Data) *LogCommand {
+	var c LogCommand
+	c.Globals = globals
+	c.manifest.File.Read(manifest.Filename)
+	c.CmdClause = parent.Command("log", "Show the history of a Fastly service's versions, and what changed in each")
+	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
+	c.CmdClause.Flag("limit", "Maximum number of versions to show, newest first (0 for all)").Default("10").IntVar(&c.limit)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *LogCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source := c.manifest.ServiceID()
+	if source == manifest.SourceUndefined {
+		return errors.ErrNoServiceID
+	}
+
+	versions, err := c.Globals.Client.ListVersions(&fastly.ListVersionsInput{ServiceID: serviceID})
+	if err != nil {
+		return err
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Number > versions[j].Number
+	})
+
+	shown := versions
+	if c.limit > 0 && len(shown) > c.limit {
+		shown = shown[:c.limit]
+	}
+
+	// Each version is compared against the one before it, so the oldest version
+	// shown needs the snapshot of the version preceding it, if there is one.
+	client := api.NewMiddleware(c.Globals.Client, ratelimit.New().Hook)
+	snapshots := make(map[int]snapshot)
+	for i := 0; i < len(versions) && i <= len(shown); i++ {
+		s, err := takeSnapshot(client, serviceID, versions[i].Number)
+		if err != nil {
+			return err
+		}
+		snapshots[versions[i].Number] = s
+	}
+
+	for i, v := range shown {
+		var previous snapshot
+		if i+1 < len(versions) {
+			previous = snapshots[versions[i+1].Number]
+		}
+		printLogEntry(out, v, diff(previous, snapshots[v.Number]))
+	}
+	return nil
+}
+
+func printLogEntry(out io.Writer, v *fastly.Version, changes []string) {
+	var markers []string
+	if v.Active {
+		markers = append(markers, "active")
+	}
+	if v.Locked {
+		markers = append(markers, "locked")
+	}
+	header := fmt.Sprintf("version %d", v.Number)
+	if len(markers) > 0 {
+		header += fmt.Sprintf(" (%s)", strings.Join(markers, ", "))
+	}
+	fmt.Fprintln(out, text.BoldYellow(header))
+	if v.CreatedAt != nil {
+		fmt.Fprintf(out, "Created (UTC): %s\n", v.CreatedAt.UTC().Format(common.TimeFormat))
+	}
+	if v.UpdatedAt != nil {
+		fmt.Fprintf(out, "Last edited (UTC): %s\n", v.UpdatedAt.UTC().Format(common.TimeFormat))
+	}
+	fmt.Fprintln(out)
+
+	if v.Comment != "" {
+		for _, line := range strings.Split(v.Comment, "\n") {
+			fmt.Fprintf(out, "    %s\n", line)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "    (no changes)\n\n")
+		return
+	}
+	for _, change := range changes {
+		fmt.Fprintf(out, "    %s\n", change)
+	}
+	fmt.Fprintln(out)
+}
+
+// snapshot maps each resource in a service version, identified by its kind
+// and name, to a fingerprint of its configuration.
+type snapshot map[string]string
+
+// resourceKind is a kind of versioned resource, and the API call listing them.
+type resourceKind struct {
+	name string
+	list logging.ListFunc
+}
+
+// resourceKinds are the kinds of resource compared between versions. Logging
+// endpoints are added by init, from logging.Lists.
+var resourceKinds = []resourceKind{
+	{"domain", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDomains(&fastly.ListDomainsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"backend", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListBackends(&fastly.ListBackendsInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"healthcheck", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListHealthChecks(&fastly.ListHealthChecksInput{ServiceID: id, ServiceVersion: v})
+	}},
+	{"dictionary", func(c api.Interface, id string, v int) (interface{}, error) {
+		return c.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: id, ServiceVersion: v})
+	}},
+}
+
+func init() {
+	providers := make([]string, 0, len(logging.Lists))
+	for provider := range logging.Lists {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		resourceKinds = append(resourceKinds, resourceKind{"logging " + provider, logging.Lists[provider]})
+	}
+}
+
+func takeSnapshot(client api.Interface, serviceID string, version int) (snapshot, error) {
+	s := make(snapshot)
+	for _, kind := range resourceKinds {
+		resources, err := kind.list(client, serviceID, version)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources of version %d: %w", kind.name, version, err)
+		}
+		v := reflect.ValueOf(resources)
+		for i := 0; i < v.Len(); i++ {
+			name, fp := fingerprint(v.Index(i).Interface())
+			s[kind.name+" "+name] = fp
+		}
+	}
+
+	// Compute@Edge packages are identified by the hash of their contents. Not
+	// every service has a package, so its absence isn't an error.
+	p, err := client.GetPackage(&fastly.GetPackageInput{ServiceID: serviceID, ServiceVersion: version})
+	switch {
+	case err == nil && p.Metadata.HashSum != "":
+		s["package"] = p.Metadata.HashSum
+	case err != nil && errors.HTTPStatus(err) != http.StatusNotFound:
+		return nil, fmt.Errorf("error getting package of version %d: %w", version, err)
+	}
+	return s, nil
+}
+
+// versionFields are excluded from fingerprints, as they differ between
+// versions without any change to the resource's configuration.
+var versionFields = map[string]bool{
+	"ServiceID":      true,
+	"ServiceVersion": true,
+	"Version":        true,
+	"CreatedAt":      true,
+	"UpdatedAt":      true,
+	"DeletedAt":      true,
+}
+
+// fingerprint returns the name of a go-fastly resource, which is a pointer to
+// a struct, and a string representing its configuration.
+func fingerprint(resource interface{}) (name, fp string) {
+	v := reflect.Indirect(reflect.ValueOf(resource))
+	fields := make(map[string]interface{})
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if versionFields[f.Name] || f.PkgPath != "" {
+			continue
+		}
+		fields[f.Name] = v.Field(i).Interface()
+	}
+	if n, ok := fields["Name"].(string); ok {
+		name = n
+	}
+	b, _ := json.Marshal(fields) // map keys are sorted
+	return name, string(b)
+}
+
+// diff describes the changes between two snapshots, one line per resource
+// prefixed with + if it was added, - if it was removed, or ~ if it changed.
+func diff(from, to snapshot) []string {
+	var changes []string
+	for key, fp := range to {
+		previous, ok := from[key]
+		switch {
+		case !ok:
+			changes = append(changes, "+ "+key)
+		case previous != fp:
+			changes = append(changes, "~ "+key)
+		}
+	}
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			changes = append(changes, "- "+key)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i][2:] < changes[j][2:]
+	})
+	return changes
+}
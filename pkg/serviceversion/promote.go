@@ -0,0 +1,597 @@
+package serviceversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/logging"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// PromoteCommand replays the configuration of a version of one service onto a
+// clone of the active version of another, e.g. from staging to production.
+type PromoteCommand struct {
+	common.Base
+	fromService string
+	fromVersion int
+	toService   string
+	mappingPath string
+}
+
+// NewPromoteCommand returns a usable command registered under the parent.
+func NewPromoteCommand(parent common.Registerer, globals *config.Data) *PromoteCommand {
+	var c PromoteCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("promote", "Promote the domains, health checks, backends, dictionaries and logging endpoints of a service version to another service")
+	c.CmdClause.Flag("from-service", "ID of the service to promote from").Required().StringVar(&c.fromService)
+	c.CmdClause.Flag("from-version", "Number of the version to promote").Required().IntVar(&c.fromVersion)
+	c.CmdClause.Flag("to-service", "ID of the service to promote to").Required().StringVar(&c.toService)
+	c.CmdClause.Flag("mapping", "Path to a TOML file rewriting environment-specific values").StringVar(&c.mappingPath)
+	return &c
+}
+
+// Mapping rewrites environment-specific values when promoting a version.
+// Domains and Backends map values in the source service to values in the
+// target service: Domains applies to domain names and health check hosts, and
+// Backends to backend addresses and hostnames. Dictionaries sets the values of
+// items, by dictionary name and item key.
+type Mapping struct {
+	Domains      map[string]string            `toml:"domains"`
+	Backends     map[string]string            `toml:"backends"`
+	Dictionaries map[string]map[string]string `toml:"dictionaries"`
+}
+
+// Exec invokes the application logic for the command.
+func (c *PromoteCommand) Exec(in io.Reader, out io.Writer) error {
+	// Fail before changing anything if the activation can't be confirmed.
+	if !c.Globals.AutoYes() && !text.IsInteractive(in) {
+		return text.ErrNonInteractive
+	}
+
+	var m Mapping
+	if c.mappingPath != "" {
+		if _, err := toml.DecodeFile(c.mappingPath, &m); err != nil {
+			return fmt.Errorf("error reading mapping file: %w", err)
+		}
+	}
+
+	client := c.Globals.Client
+
+	desired, err := loadState(client, c.fromService, c.fromVersion)
+	if err != nil {
+		return err
+	}
+	desired.rewrite(m)
+
+	versions, err := client.ListVersions(&fastly.ListVersionsInput{ServiceID: c.toService})
+	if err != nil {
+		return err
+	}
+	var active *fastly.Version
+	for _, v := range versions {
+		if v.Active {
+			active = v
+		}
+	}
+	if active == nil {
+		return fmt.Errorf("error promoting to service %s: it has no active version to clone", c.toService)
+	}
+
+	current, err := loadState(client, c.toService, active.Number)
+	if err != nil {
+		return err
+	}
+
+	changes := plan(current, desired)
+	if len(changes) == 0 {
+		text.Info(out, "Nothing to promote: service %s version %d already matches service %s version %d", c.toService, active.Number, c.fromService, c.fromVersion)
+		return nil
+	}
+
+	clone, err := client.CloneVersion(&fastly.CloneVersionInput{ServiceID: c.toService, ServiceVersion: active.Number})
+	if err != nil {
+		return fmt.Errorf("error cloning active version: %w", err)
+	}
+	if _, err := client.UpdateVersion(&fastly.UpdateVersionInput{
+		ServiceID:      c.toService,
+		ServiceVersion: clone.Number,
+		Comment:        fastly.String(fmt.Sprintf("Promoted from service %s version %d", c.fromService, c.fromVersion)),
+	}); err != nil {
+		return fmt.Errorf("error setting version comment: %w", err)
+	}
+
+	fmt.Fprintf(out, "Changes to service %s, cloned from version %d to version %d:\n\n", c.toService, active.Number, clone.Number)
+	for _, ch := range changes {
+		fmt.Fprintf(out, "%s\n", ch)
+	}
+
+	// Dictionary items aren't versioned, so the items of dictionaries shared
+	// with the active version are only changed once activation is confirmed.
+	deferred, err := apply(client, c.toService, clone.Number, changes, current.ids)
+	if err != nil {
+		return fmt.Errorf("error promoting to version %d: %w", clone.Number, err)
+	}
+
+	if !c.Globals.AutoYes() {
+		text.Break(out)
+	}
+	prompt := fmt.Sprintf("Activate version %d of service %s?", clone.Number, c.toService)
+	if err := text.Confirm(out, in, c.Globals.AutoYes(), prompt, ""); err != nil {
+		text.Info(out, "Version %d of service %s was left inactive", clone.Number, c.toService)
+		return err
+	}
+
+	for _, fn := range deferred {
+		if err := fn(); err != nil {
+			return fmt.Errorf("error promoting dictionary items: %w", err)
+		}
+	}
+
+	if _, err := client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: c.toService, ServiceVersion: clone.Number}); err != nil {
+		return err
+	}
+
+	text.Success(out, "Promoted service %s version %d to service %s version %d", c.fromService, c.fromVersion, c.toService, clone.Number)
+	return nil
+}
+
+// promotable is a kind of resource which can be promoted. The fields of its
+// create input determine which of the resource's fields are replayed.
+type promotable struct {
+	kind   string
+	input  interface{}
+	list   func(c api.Interface, serviceID string, version int) (interface{}, error)
+	create func(c api.Interface, serviceID string, version int, resource interface{}) (interface{}, error)
+	update func(c api.Interface, serviceID string, version int, resource interface{}) error
+	delete func(c api.Interface, serviceID string, version int, name string) error
+}
+
+// promotables are in dependency order: resources are created and updated in
+// this order, and deleted in reverse, as backends refer to health checks.
+// Logging endpoints are added by init, from logging.Lists.
+var promotables = []*promotable{
+	{
+		kind:  "domain",
+		input: fastly.CreateDomainInput{},
+		list: func(c api.Interface, id string, v int) (interface{}, error) {
+			return c.ListDomains(&fastly.ListDomainsInput{ServiceID: id, ServiceVersion: v})
+		},
+		create: func(c api.Interface, id string, v int, r interface{}) (interface{}, error) {
+			i := &fastly.CreateDomainInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			return c.CreateDomain(i)
+		},
+		update: func(c api.Interface, id string, v int, r interface{}) error {
+			i := &fastly.UpdateDomainInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			i.NewName = i.Name // always sent, so it mustn't be empty
+			_, err := c.UpdateDomain(i)
+			return err
+		},
+		delete: func(c api.Interface, id string, v int, name string) error {
+			return c.DeleteDomain(&fastly.DeleteDomainInput{ServiceID: id, ServiceVersion: v, Name: name})
+		},
+	},
+	{
+		kind:  "healthcheck",
+		input: fastly.CreateHealthCheckInput{},
+		list: func(c api.Interface, id string, v int) (interface{}, error) {
+			return c.ListHealthChecks(&fastly.ListHealthChecksInput{ServiceID: id, ServiceVersion: v})
+		},
+		create: func(c api.Interface, id string, v int, r interface{}) (interface{}, error) {
+			i := &fastly.CreateHealthCheckInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			return c.CreateHealthCheck(i)
+		},
+		update: func(c api.Interface, id string, v int, r interface{}) error {
+			i := &fastly.UpdateHealthCheckInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			_, err := c.UpdateHealthCheck(i)
+			return err
+		},
+		delete: func(c api.Interface, id string, v int, name string) error {
+			return c.DeleteHealthCheck(&fastly.DeleteHealthCheckInput{ServiceID: id, ServiceVersion: v, Name: name})
+		},
+	},
+	{
+		kind:  "backend",
+		input: fastly.CreateBackendInput{},
+		list: func(c api.Interface, id string, v int) (interface{}, error) {
+			return c.ListBackends(&fastly.ListBackendsInput{ServiceID: id, ServiceVersion: v})
+		},
+		create: func(c api.Interface, id string, v int, r interface{}) (interface{}, error) {
+			i := &fastly.CreateBackendInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			return c.CreateBackend(i)
+		},
+		update: func(c api.Interface, id string, v int, r interface{}) error {
+			i := &fastly.UpdateBackendInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			_, err := c.UpdateBackend(i)
+			return err
+		},
+		delete: func(c api.Interface, id string, v int, name string) error {
+			return c.DeleteBackend(&fastly.DeleteBackendInput{ServiceID: id, ServiceVersion: v, Name: name})
+		},
+	},
+	{
+		kind:  "dictionary",
+		input: fastly.CreateDictionaryInput{},
+		list: func(c api.Interface, id string, v int) (interface{}, error) {
+			return c.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: id, ServiceVersion: v})
+		},
+		create: func(c api.Interface, id string, v int, r interface{}) (interface{}, error) {
+			i := &fastly.CreateDictionaryInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			return c.CreateDictionary(i)
+		},
+		update: func(c api.Interface, id string, v int, r interface{}) error {
+			i := &fastly.UpdateDictionaryInput{ServiceID: id, ServiceVersion: v}
+			api.SetFields(i, r)
+			_, err := c.UpdateDictionary(i)
+			return err
+		},
+		delete: func(c api.Interface, id string, v int, name string) error {
+			return c.DeleteDictionary(&fastly.DeleteDictionaryInput{ServiceID: id, ServiceVersion: v, Name: name})
+		},
+	},
+}
+
+func init() {
+	providers := make([]string, 0, len(logging.Lists))
+	for provider := range logging.Lists {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	for _, provider := range providers {
+		promotables = append(promotables, loggingPromotable(provider, logging.Lists[provider]))
+	}
+}
+
+// loggingPromotable returns the kind of logging endpoint listed by list. Its
+// endpoints are created, updated and deleted via the client's methods for
+// their go-fastly type, e.g. CreateS3, as service restore does.
+func loggingPromotable(provider string, list logging.ListFunc) *promotable {
+	// list yields a typed slice even when the API returns nothing, which
+	// gives the type without calling the API.
+	stub := api.NewMiddleware(nil, func(string, interface{}, func() (interface{}, error)) (interface{}, error) {
+		return nil, nil
+	})
+	resources, _ := list(stub, "", 0)
+	typeName := reflect.TypeOf(resources).Elem().Elem().Name()
+
+	call := func(c api.Interface, verb, id string, v int, set func(input interface{})) (interface{}, error) {
+		method := reflect.ValueOf(c).MethodByName(verb + typeName)
+		input := reflect.New(method.Type().In(0).Elem())
+		set(input.Interface())
+		input.Elem().FieldByName("ServiceID").SetString(id)
+		input.Elem().FieldByName("ServiceVersion").SetInt(int64(v))
+		out := method.Call([]reflect.Value{input})
+		err, _ := out[len(out)-1].Interface().(error)
+		if len(out) == 1 {
+			return nil, err
+		}
+		return out[0].Interface(), err
+	}
+	create, _ := reflect.TypeOf((*api.Interface)(nil)).Elem().MethodByName("Create" + typeName)
+
+	return &promotable{
+		kind:  "logging " + provider,
+		input: reflect.Zero(create.Type.In(0).Elem()).Interface(),
+		list:  list,
+		create: func(c api.Interface, id string, v int, r interface{}) (interface{}, error) {
+			return call(c, "Create", id, v, func(i interface{}) { api.SetFields(i, r) })
+		},
+		update: func(c api.Interface, id string, v int, r interface{}) error {
+			_, err := call(c, "Update", id, v, func(i interface{}) { api.SetFields(i, r) })
+			return err
+		},
+		delete: func(c api.Interface, id string, v int, name string) error {
+			_, err := call(c, "Delete", id, v, func(i interface{}) {
+				reflect.ValueOf(i).Elem().FieldByName("Name").SetString(name)
+			})
+			return err
+		},
+	}
+}
+
+// state is the promotable configuration of a service version.
+type state struct {
+	resources map[string]map[string]interface{} // by kind, then name
+	items     map[string]map[string]string      // by dictionary name, then key
+	ids       map[string]string                 // dictionary IDs, by name
+}
+
+func loadState(client api.Interface, serviceID string, version int) (*state, error) {
+	s := &state{
+		resources: make(map[string]map[string]interface{}),
+		items:     make(map[string]map[string]string),
+		ids:       make(map[string]string),
+	}
+	for _, p := range promotables {
+		resources, err := p.list(client, serviceID, version)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources of service %s version %d: %w", p.kind, serviceID, version, err)
+		}
+		s.resources[p.kind] = make(map[string]interface{})
+		v := reflect.ValueOf(resources)
+		for i := 0; i < v.Len(); i++ {
+			r := v.Index(i).Interface()
+			s.resources[p.kind][resourceName(r)] = r
+		}
+	}
+
+	for name, r := range s.resources["dictionary"] {
+		d := r.(*fastly.Dictionary)
+		s.ids[name] = d.ID
+		// The items of write-only dictionaries can't be read, so they're left
+		// as they are.
+		if d.WriteOnly {
+			continue
+		}
+		items, err := client.ListDictionaryItems(&fastly.ListDictionaryItemsInput{ServiceID: serviceID, DictionaryID: d.ID})
+		if err != nil {
+			return nil, fmt.Errorf("error listing items of dictionary %s: %w", name, err)
+		}
+		s.items[name] = make(map[string]string)
+		for _, item := range items {
+			s.items[name][item.ItemKey] = item.ItemValue
+		}
+	}
+	return s, nil
+}
+
+// rewrite applies m to the state of the source version.
+func (s *state) rewrite(m Mapping) {
+	remap := func(values map[string]string, v *string) {
+		if to, ok := values[*v]; ok {
+			*v = to
+		}
+	}
+
+	domains := make(map[string]interface{})
+	for _, r := range s.resources["domain"] {
+		d := r.(*fastly.Domain)
+		remap(m.Domains, &d.Name)
+		domains[d.Name] = d
+	}
+	s.resources["domain"] = domains
+
+	for _, r := range s.resources["healthcheck"] {
+		remap(m.Domains, &r.(*fastly.HealthCheck).Host)
+	}
+	for _, r := range s.resources["backend"] {
+		b := r.(*fastly.Backend)
+		for _, v := range []*string{&b.Address, &b.OverrideHost, &b.SSLCertHostname, &b.SSLSNIHostname} {
+			remap(m.Backends, v)
+		}
+	}
+	for name, items := range m.Dictionaries {
+		if s.items[name] == nil {
+			continue
+		}
+		for k, v := range items {
+			s.items[name][k] = v
+		}
+	}
+}
+
+// change is a single change to a resource, or to a dictionary item.
+type change struct {
+	op       byte // '+', '~' or '-'
+	kind     *promotable
+	name     string
+	resource interface{}
+	fields   []string // changed fields of an updated resource
+
+	item *fastly.BatchDictionaryItem
+}
+
+func (c change) String() string {
+	if c.item != nil {
+		return fmt.Sprintf("%c dictionary item %s/%s", c.op, c.name, c.item.ItemKey)
+	}
+	s := fmt.Sprintf("%c %s %s", c.op, c.kind.kind, c.name)
+	if len(c.fields) > 0 {
+		s += fmt.Sprintf(" (%s)", strings.Join(c.fields, ", "))
+	}
+	return s
+}
+
+// plan returns the changes which make current match desired.
+func plan(current, desired *state) []change {
+	var changes []change
+	for _, p := range promotables {
+		for _, name := range sortedKeys(desired.resources[p.kind]) {
+			want := desired.resources[p.kind][name]
+			have, ok := current.resources[p.kind][name]
+			if !ok {
+				changes = append(changes, change{op: '+', kind: p, name: name, resource: want})
+				continue
+			}
+			if fields := changedFields(p.input, have, want); len(fields) > 0 {
+				changes = append(changes, change{op: '~', kind: p, name: name, resource: want, fields: fields})
+			}
+		}
+		for _, name := range sortedKeys(current.resources[p.kind]) {
+			if _, ok := desired.resources[p.kind][name]; !ok {
+				changes = append(changes, change{op: '-', kind: p, name: name})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(desired.items))
+	for name := range desired.items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		want, have := desired.items[name], current.items[name]
+		keys := make(map[string]bool)
+		for k := range want {
+			keys[k] = true
+		}
+		for k := range have {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			w, inWant := want[k]
+			h, inHave := have[k]
+			switch {
+			case inWant && !inHave:
+				changes = append(changes, change{op: '+', name: name, item: &fastly.BatchDictionaryItem{Operation: fastly.UpsertBatchOperation, ItemKey: k, ItemValue: w}})
+			case inWant && w != h:
+				changes = append(changes, change{op: '~', name: name, item: &fastly.BatchDictionaryItem{Operation: fastly.UpsertBatchOperation, ItemKey: k, ItemValue: w}})
+			case !inWant:
+				changes = append(changes, change{op: '-', name: name, item: &fastly.BatchDictionaryItem{Operation: fastly.DeleteBatchOperation, ItemKey: k}})
+			}
+		}
+	}
+	return changes
+}
+
+// apply makes changes to a service version. Changes to the items of
+// dictionaries which already exist, whose IDs are given by ids, are returned
+// as functions to be called later, as they take effect immediately.
+func apply(client api.Interface, serviceID string, version int, changes []change, ids map[string]string) (deferred []func() error, err error) {
+	created := make(map[string]string) // IDs of created dictionaries, by name
+	for _, ch := range changes {
+		switch {
+		case ch.item != nil:
+		case ch.op == '+':
+			r, err := ch.kind.create(client, serviceID, version, ch.resource)
+			if err != nil {
+				return nil, fmt.Errorf("error creating %s %s: %w", ch.kind.kind, ch.name, err)
+			}
+			if d, ok := r.(*fastly.Dictionary); ok {
+				created[d.Name] = d.ID
+			}
+		case ch.op == '~':
+			if err := ch.kind.update(client, serviceID, version, ch.resource); err != nil {
+				return nil, fmt.Errorf("error updating %s %s: %w", ch.kind.kind, ch.name, err)
+			}
+		}
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		if ch := changes[i]; ch.item == nil && ch.op == '-' {
+			if err := ch.kind.delete(client, serviceID, version, ch.name); err != nil {
+				return nil, fmt.Errorf("error deleting %s %s: %w", ch.kind.kind, ch.name, err)
+			}
+		}
+	}
+
+	items := make(map[string][]*fastly.BatchDictionaryItem)
+	var names []string
+	for _, ch := range changes {
+		if ch.item != nil {
+			if items[ch.name] == nil {
+				names = append(names, ch.name)
+			}
+			items[ch.name] = append(items[ch.name], ch.item)
+		}
+	}
+	for _, name := range names {
+		id, isNew := created[name]
+		if !isNew {
+			id = ids[name]
+		}
+		batch := batchItems(client, serviceID, id, items[name])
+		if isNew {
+			if err := batch(); err != nil {
+				return nil, fmt.Errorf("error creating items of dictionary %s: %w", name, err)
+			}
+			continue
+		}
+		deferred = append(deferred, batch)
+	}
+	return deferred, nil
+}
+
+// batchItems returns a function which applies items to a dictionary, in
+// batches no larger than the API allows.
+func batchItems(client api.Interface, serviceID, dictionaryID string, items []*fastly.BatchDictionaryItem) func() error {
+	return func() error {
+		for len(items) > 0 {
+			n := len(items)
+			if n > fastly.BatchModifyMaximumOperations {
+				n = fastly.BatchModifyMaximumOperations
+			}
+			if err := client.BatchModifyDictionaryItems(&fastly.BatchModifyDictionaryItemsInput{
+				ServiceID:    serviceID,
+				DictionaryID: dictionaryID,
+				Items:        items[:n],
+			}); err != nil {
+				return err
+			}
+			items = items[n:]
+		}
+		return nil
+	}
+}
+
+// resourceName returns the Name field of a go-fastly resource.
+func resourceName(resource interface{}) string {
+	return reflect.Indirect(reflect.ValueOf(resource)).FieldByName("Name").String()
+}
+
+// promotedFields are the fields of a resource which are replayed: those which
+// can be set via input, other than the service and version.
+func promotedFields(input, resource interface{}) map[string]interface{} {
+	t := reflect.TypeOf(input)
+	r := reflect.Indirect(reflect.ValueOf(resource))
+	fields := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		if name == "ServiceID" || name == "ServiceVersion" {
+			continue
+		}
+		if f := r.FieldByName(name); f.IsValid() {
+			fields[name] = f.Interface()
+		}
+	}
+	return fields
+}
+
+// changedFields returns the names of the promoted fields which differ between
+// two resources, in the form used by the API.
+func changedFields(input, have, want interface{}) []string {
+	h, w := promotedFields(input, have), promotedFields(input, want)
+	t := reflect.TypeOf(input)
+	var changed []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		hv, ok := h[f.Name]
+		if !ok {
+			continue
+		}
+		a, _ := json.Marshal(hv)
+		b, _ := json.Marshal(w[f.Name])
+		if string(a) != string(b) {
+			changed = append(changed, strings.Split(f.Tag.Get("form"), ",")[0])
+		}
+	}
+	return changed
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,300 +1,388 @@
 package serviceversion_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
+	"os"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/fakeapi"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/apptest"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestVersionClone(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service-version", "clone", "--version", "1"},
+			API:  mock.API{CloneVersionFn: cloneVersionOK},
+		},
+		{
+			Args: []string{"service-version", "clone", "--service-id", "123"},
+			API:  mock.API{CloneVersionFn: cloneVersionOK},
+		},
 		{
-			args:      []string{"service-version", "clone", "--version", "1"},
-			api:       mock.API{CloneVersionFn: cloneVersionOK},
-			wantError: "error reading service: no service ID found",
+			Args: []string{"service-version", "clone", "--service-id", "123", "--version", "1"},
+			API:  mock.API{CloneVersionFn: cloneVersionOK},
 		},
 		{
-			args:      []string{"service-version", "clone", "--service-id", "123"},
-			api:       mock.API{CloneVersionFn: cloneVersionOK},
-			wantError: "error parsing arguments: required flag --version not provided",
+			Args: []string{"service-version", "clone", "--service-id", "123", "--version", "1"},
+			API:  mock.API{CloneVersionFn: cloneVersionError},
 		},
 		{
-			args:       []string{"service-version", "clone", "--service-id", "123", "--version", "1"},
-			api:        mock.API{CloneVersionFn: cloneVersionOK},
-			wantOutput: "Cloned service 123 version 1 to version 2",
+			Args: []string{"service-version", "clone", "--service-id", "123", "--version", "1", "--comment", "Add backend"},
+			API:  mock.API{CloneVersionFn: cloneVersionOK, UpdateVersionFn: updateVersionOK},
 		},
 		{
-			args:      []string{"service-version", "clone", "--service-id", "123", "--version", "1"},
-			api:       mock.API{CloneVersionFn: cloneVersionError},
-			wantError: errTest.Error(),
+			Args: []string{"service-version", "clone", "--service-id", "123", "--version", "1", "--comment", "Add backend"},
+			API:  mock.API{CloneVersionFn: cloneVersionOK, UpdateVersionFn: updateVersionError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestVersionList(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"service-version", "list", "--service-id", "123"},
-			api:        mock.API{ListVersionsFn: listVersionsOK},
-			wantOutput: listVersionsShortOutput,
+			Args: []string{"service-version", "list", "--service-id", "123"},
+			API:  mock.API{ListVersionsFn: listVersionsOK},
 		},
 		{
-			args:       []string{"service-version", "list", "--service-id", "123", "--verbose"},
-			api:        mock.API{ListVersionsFn: listVersionsOK},
-			wantOutput: listVersionsVerboseOutput,
+			Args: []string{"service-version", "list", "--service-id", "123", "--verbose"},
+			API:  mock.API{ListVersionsFn: listVersionsOK},
 		},
 		{
-			args:       []string{"service-version", "list", "--service-id", "123", "-v"},
-			api:        mock.API{ListVersionsFn: listVersionsOK},
-			wantOutput: listVersionsVerboseOutput,
+			Args: []string{"service-version", "list", "--service-id", "123", "-v"},
+			API:  mock.API{ListVersionsFn: listVersionsOK},
 		},
 		{
-			args:       []string{"service-version", "--verbose", "list", "--service-id", "123"},
-			api:        mock.API{ListVersionsFn: listVersionsOK},
-			wantOutput: listVersionsVerboseOutput,
+			Args: []string{"service-version", "--verbose", "list", "--service-id", "123"},
+			API:  mock.API{ListVersionsFn: listVersionsOK},
 		},
 		{
-			args:       []string{"-v", "service-version", "list", "--service-id", "123"},
-			api:        mock.API{ListVersionsFn: listVersionsOK},
-			wantOutput: listVersionsVerboseOutput,
+			Args: []string{"-v", "service-version", "list", "--service-id", "123"},
+			API:  mock.API{ListVersionsFn: listVersionsOK},
 		},
 		{
-			args:      []string{"service-version", "list", "--service-id", "123"},
-			api:       mock.API{ListVersionsFn: listVersionsError},
-			wantError: errTest.Error(),
+			Args: []string{"service-version", "list", "--service-id", "123"},
+			API:  mock.API{ListVersionsFn: listVersionsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertString(t, testcase.wantOutput, out.String())
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestVersionUpdate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args: []string{"service-version", "update", "--service-id", "123", "--version", "1", "--comment", "foo"},
-			api: mock.API{
+			Args: []string{"service-version", "update", "--service-id", "123", "--version", "1", "--comment", "foo"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateVersionFn: updateVersionOK,
 			},
-			wantOutput: "Updated service 123 version 1",
 		},
 		{
-			args:      []string{"service-version", "update", "--service-id", "123", "--version", "2"},
-			api:       mock.API{UpdateVersionFn: updateVersionOK},
-			wantError: "error parsing arguments: required flag --comment not provided",
+			Args: []string{"service-version", "update", "--service-id", "123", "--version", "2"},
+			API:  mock.API{UpdateVersionFn: updateVersionOK},
 		},
 		{
-			args: []string{"service-version", "update", "--service-id", "123", "--version", "1", "--comment", "foo"},
-			api: mock.API{
+			Args: []string{"service-version", "update", "--service-id", "123", "--version", "1", "--comment", "foo"},
+			API: mock.API{
 				GetServiceFn:    getServiceOK,
 				UpdateVersionFn: updateVersionError,
 			},
-			wantError: errTest.Error(),
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestVersionActivate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service-version", "activate", "--service-id", "123"},
+			API:  mock.API{ActivateVersionFn: activateVersionOK},
+		},
+		{
+			Args: []string{"service-version", "activate", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ActivateVersionFn: activateVersionOK},
+		},
 		{
-			args:      []string{"service-version", "activate", "--service-id", "123"},
-			api:       mock.API{ActivateVersionFn: activateVersionOK},
-			wantError: "error parsing arguments: required flag --version not provided",
+			Args: []string{"service-version", "activate", "--service-id", "123", "--version", "1"},
+			API:  mock.API{ActivateVersionFn: activateVersionError},
 		},
 		{
-			args:       []string{"service-version", "activate", "--service-id", "123", "--version", "1"},
-			api:        mock.API{ActivateVersionFn: activateVersionOK},
-			wantOutput: "Activated service 123 version 1",
+			Args: []string{"service-version", "activate", "--service-id", "123", "--version", "1", "--comment", "Fix caching"},
+			API:  mock.API{ActivateVersionFn: activateVersionOK, UpdateVersionFn: updateVersionOK},
 		},
 		{
-			args:      []string{"service-version", "activate", "--service-id", "123", "--version", "1"},
-			api:       mock.API{ActivateVersionFn: activateVersionError},
-			wantError: errTest.Error(),
+			Args: []string{"service-version", "activate", "--service-id", "123", "--version", "1", "--comment", "Fix caching"},
+			API:  mock.API{ActivateVersionFn: activateVersionOK, UpdateVersionFn: updateVersionError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestVersionDeactivate(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service-version", "deactivate", "--service-id", "123", "--auto-yes"},
+			API:  mock.API{DeactivateVersionFn: deactivateVersionOK},
+		},
 		{
-			args:      []string{"service-version", "deactivate", "--service-id", "123"},
-			api:       mock.API{DeactivateVersionFn: deactivateVersionOK},
-			wantError: "error parsing arguments: required flag --version not provided",
+			Args: []string{"service-version", "deactivate", "--service-id", "123", "--version", "1", "--auto-yes"},
+			API:  mock.API{DeactivateVersionFn: deactivateVersionOK},
 		},
 		{
-			args:       []string{"service-version", "deactivate", "--service-id", "123", "--version", "1"},
-			api:        mock.API{DeactivateVersionFn: deactivateVersionOK},
-			wantOutput: "Deactivated service 123 version 1",
+			Args: []string{"service-version", "deactivate", "--service-id", "123", "--version", "1", "--auto-yes"},
+			API:  mock.API{DeactivateVersionFn: deactivateVersionError},
 		},
 		{
-			args:      []string{"service-version", "deactivate", "--service-id", "123", "--version", "1"},
-			api:       mock.API{DeactivateVersionFn: deactivateVersionError},
-			wantError: errTest.Error(),
+			Args:  []string{"service-version", "deactivate", "--service-id", "123", "--version", "1"},
+			API:   mock.API{DeactivateVersionFn: deactivateVersionOK},
+			Stdin: "y\n",
+		},
+		{
+			Args:  []string{"service-version", "deactivate", "--service-id", "123", "--version", "1"},
+			API:   mock.API{DeactivateVersionFn: deactivateVersionOK},
+			Stdin: "n\n",
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
 func TestVersionLock(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service-version", "lock", "--service-id", "123"},
+			API:  mock.API{LockVersionFn: lockVersionOK},
+		},
+		{
+			Args: []string{"service-version", "lock", "--service-id", "123", "--version", "1"},
+			API:  mock.API{LockVersionFn: lockVersionOK},
+		},
+		{
+			Args: []string{"service-version", "lock", "--service-id", "123", "--version", "1"},
+			API:  mock.API{LockVersionFn: lockVersionError},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+var errTest = errors.New("fixture error")
+
+func TestVersionLog(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service-version", "log"},
+			API:  mock.API{ListVersionsFn: listVersionsOK},
+		},
+		{
+			Args: []string{"service-version", "log", "--service-id", "123"},
+			API:  mock.API{ListVersionsFn: listVersionsError},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+// TestVersionLogChanges builds up a history of versions against a fake of the
+// Fastly API, as the changes shown by the log depend on the configuration of
+// every version.
+func TestVersionLogChanges(t *testing.T) {
+	server := fakeapi.New()
+	defer server.Close()
+	serviceID := server.AddService("example", "vcl")
+
+	must := func(args ...string) {
+		t.Helper()
+		if _, err := apptest.Run(t, server.URL, nil, append(args, "--service-id", serviceID)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must("domain", "create", "--version", "1", "--name", "www.example.com")
+	must("backend", "create", "--version", "1", "--name", "origin", "--address", "example.com")
+	must("service-version", "activate", "--version", "1", "--comment", "Initial configuration")
+	must("service-version", "clone", "--version", "1", "--comment", "Check the health of the origin")
+	must("healthcheck", "create", "--version", "2", "--name", "origin", "--path", "/health")
+	must("backend", "update", "--version", "2", "--name", "origin", "--healthcheck", "origin")
+	must("service-version", "clone", "--version", "2")
+
+	for _, args := range [][]string{
+		{"service-version", "log", "--service-id", serviceID},
+		{"service-version", "log", "--service-id", serviceID, "--limit", "1"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			out, err := apptest.Run(t, server.URL, nil, args...)
+			golden.Assert(t, golden.Format(out, err))
+		})
+	}
+}
+
+func TestVersionPromote(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"service-version", "promote", "--from-service", "123", "--from-version", "1"},
+		},
+		{
+			Args: []string{"service-version", "promote", "--from-service", "123", "--from-version", "1", "--to-service", "456"},
+		},
+		{
+			Args: []string{"service-version", "promote", "--from-service", "123", "--from-version", "1", "--to-service", "456", "-y"},
+			API:  mock.API{ListDomainsFn: listDomainsError},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+// TestVersionPromoteChanges promotes a staging service to a production service
+// served by a fake of the Fastly API, and checks the production service's
+// configuration afterwards.
+func TestVersionPromoteChanges(t *testing.T) {
+	mapping := testutil.MakeTempFile(t, `
+[domains]
+"staging.example.com" = "www.example.com"
+
+[backends]
+"staging-origin.example.com" = "origin.example.com"
+
+[dictionaries.settings]
+environment = "production"
+`)
+	defer os.Remove(mapping)
+
 	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
+		name      string
+		args      []string
+		stdin     string
+		activated bool
 	}{
 		{
-			args:      []string{"service-version", "lock", "--service-id", "123"},
-			api:       mock.API{LockVersionFn: lockVersionOK},
-			wantError: "error parsing arguments: required flag --version not provided",
+			name:      "confirmed",
+			args:      []string{"--mapping", mapping},
+			stdin:     "y",
+			activated: true,
 		},
 		{
-			args:       []string{"service-version", "lock", "--service-id", "123", "--version", "1"},
-			api:        mock.API{LockVersionFn: lockVersionOK},
-			wantOutput: "Locked service 123 version 1",
+			name:  "declined",
+			args:  []string{"--mapping", mapping},
+			stdin: "n",
 		},
 		{
-			args:      []string{"service-version", "lock", "--service-id", "123", "--version", "1"},
-			api:       mock.API{LockVersionFn: lockVersionError},
-			wantError: errTest.Error(),
+			name:      "without mapping",
+			args:      []string{"--auto-yes"},
+			activated: true,
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                           = testcase.args
-				env                            = config.Environment{}
-				file                           = config.File{}
-				appConfigFile                  = "/dev/null"
-				clientFactory                  = mock.APIClient(testcase.api)
-				httpClient                     = http.DefaultClient
-				versioner     update.Versioner = nil
-				in            io.Reader        = nil
-				out           bytes.Buffer
-			)
-			err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(testcase.name, func(t *testing.T) {
+			server := fakeapi.New()
+			defer server.Close()
+			client, err := fastly.NewClientForEndpoint("fake", server.URL)
+			testutil.AssertNoError(t, err)
+
+			staging := server.AddService("staging", "vcl")
+			production := server.AddService("production", "vcl")
+			setUpService(t, client, staging, "staging.example.com", "staging-origin.example.com", map[string]string{
+				"environment": "staging",
+				"feature":     "on",
+			})
+			setUpService(t, client, production, "www.example.com", "origin.example.com", map[string]string{
+				"environment": "production",
+				"retired":     "yes",
+			})
+			_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: production, ServiceVersion: 1, Name: "legacy", Address: "legacy.example.com"})
+			testutil.AssertNoError(t, err)
+			_, err = client.CreateSyslog(&fastly.CreateSyslogInput{ServiceID: staging, ServiceVersion: 1, Name: "logs", Address: "logs.example.com", Port: 514})
+			testutil.AssertNoError(t, err)
+			for name, port := range map[string]uint{"logs": 6514, "legacy-logs": 514} {
+				_, err = client.CreateSyslog(&fastly.CreateSyslogInput{ServiceID: production, ServiceVersion: 1, Name: name, Address: "logs.example.com", Port: port})
+				testutil.AssertNoError(t, err)
+			}
+			for _, id := range []string{staging, production} {
+				_, err = client.ActivateVersion(&fastly.ActivateVersionInput{ServiceID: id, ServiceVersion: 1})
+				testutil.AssertNoError(t, err)
+			}
+
+			args := append([]string{"service-version", "promote", "--from-service", staging, "--from-version", "1", "--to-service", production}, testcase.args...)
+			out, err := apptest.Run(t, server.URL, strings.NewReader(testcase.stdin), args...)
+			golden.Assert(t, golden.Format(strings.ReplaceAll(out, mapping, "mapping.toml"), err))
+
+			versions, err := client.ListVersions(&fastly.ListVersionsInput{ServiceID: production})
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, 2, len(versions))
+			testutil.AssertEqual(t, testcase.activated, versions[1].Active)
+
+			backends, err := client.ListBackends(&fastly.ListBackendsInput{ServiceID: production, ServiceVersion: 2})
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, 1, len(backends))
+
+			syslogs, err := client.ListSyslogs(&fastly.ListSyslogsInput{ServiceID: production, ServiceVersion: 2})
+			testutil.AssertNoError(t, err)
+			testutil.AssertEqual(t, 1, len(syslogs))
+			testutil.AssertEqual(t, uint(514), syslogs[0].Port)
+
+			dictionary, err := client.GetDictionary(&fastly.GetDictionaryInput{ServiceID: production, ServiceVersion: 2, Name: "settings"})
+			testutil.AssertNoError(t, err)
+			items, err := client.ListDictionaryItems(&fastly.ListDictionaryItemsInput{ServiceID: production, DictionaryID: dictionary.ID})
+			testutil.AssertNoError(t, err)
+			have := make(map[string]string)
+			for _, item := range items {
+				have[item.ItemKey] = item.ItemValue
+			}
+			want := map[string]string{"environment": "production", "retired": "yes"}
+			if testcase.activated {
+				want = map[string]string{"environment": "production", "feature": "on"}
+				if testcase.stdin == "" {
+					want["environment"] = "staging"
+				}
+			}
+			testutil.AssertEqual(t, want, have)
 		})
 	}
 }
 
-var errTest = errors.New("fixture error")
+// setUpService creates a domain, a backend and its health check, and a
+// dictionary of items in version 1 of a service.
+func setUpService(t *testing.T, client *fastly.Client, serviceID, domain, origin string, items map[string]string) {
+	t.Helper()
+	_, err := client.CreateDomain(&fastly.CreateDomainInput{ServiceID: serviceID, ServiceVersion: 1, Name: domain})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateHealthCheck(&fastly.CreateHealthCheckInput{ServiceID: serviceID, ServiceVersion: 1, Name: "origin", Host: domain, Path: "/health"})
+	testutil.AssertNoError(t, err)
+	_, err = client.CreateBackend(&fastly.CreateBackendInput{ServiceID: serviceID, ServiceVersion: 1, Name: "origin", Address: origin, Port: 443, HealthCheck: "origin"})
+	testutil.AssertNoError(t, err)
+	dictionary, err := client.CreateDictionary(&fastly.CreateDictionaryInput{ServiceID: serviceID, ServiceVersion: 1, Name: "settings"})
+	testutil.AssertNoError(t, err)
+	for k, v := range items {
+		_, err = client.CreateDictionaryItem(&fastly.CreateDictionaryItemInput{ServiceID: serviceID, DictionaryID: dictionary.ID, ItemKey: k, ItemValue: v})
+		testutil.AssertNoError(t, err)
+	}
+}
 
 func cloneVersionOK(i *fastly.CloneVersionInput) (*fastly.Version, error) {
 	return &fastly.Version{
@@ -335,40 +423,6 @@ func listVersionsError(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
 	return nil, errTest
 }
 
-var listVersionsShortOutput = strings.TrimSpace(`
-NUMBER  ACTIVE  LAST EDITED (UTC)
-1       false   2010-11-15 19:01
-2       true    2015-03-14 12:59
-`) + "\n"
-
-var listVersionsVerboseOutput = strings.TrimSpace(`
-Fastly API token not provided
-Fastly API endpoint: https://api.fastly.com
-Versions: 2
-	Version 1/2
-		Number: 1
-		Comment: a
-		Service ID: b
-		Active: false
-		Locked: false
-		Deployed: false
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-02-03 04:05
-		Last edited (UTC): 2010-11-15 19:01
-	Version 2/2
-		Number: 2
-		Comment: c
-		Service ID: b
-		Active: true
-		Locked: false
-		Deployed: true
-		Staging: false
-		Testing: false
-		Created (UTC): 2001-03-03 04:05
-		Last edited (UTC): 2015-03-14 12:59
-`) + "\n\n"
-
 func getServiceOK(i *fastly.GetServiceInput) (*fastly.Service, error) {
 	return &fastly.Service{
 		ID:      "12345",
@@ -437,3 +491,7 @@ func lockVersionOK(i *fastly.LockVersionInput) (*fastly.Version, error) {
 func lockVersionError(i *fastly.LockVersionInput) (*fastly.Version, error) {
 	return nil, errTest
 }
+
+func listDomainsError(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+	return nil, errTest
+}
@@ -43,6 +43,8 @@ func NewHistoricalCommand(parent common.Registerer, globals *config.Data) *Histo
 
 // Exec implements the command interface.
 func (c *HistoricalCommand) Exec(in io.Reader, out io.Writer) error {
+	c.formatFlag = c.Globals.CommandFormat(c.formatFlag)
+
 	service, source := c.manifest.ServiceID()
 	if source == manifest.SourceUndefined {
 		return errors.ErrNoServiceID
@@ -1,95 +1,36 @@
 package stats_test
 
 import (
-	"bytes"
 	"encoding/json"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestHistorical(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"stats", "historical", "--service-id=123"},
-			api:        mock.API{GetStatsJSONFn: getStatsJSONOK},
-			wantOutput: historicalOK,
+			Args: []string{"stats", "historical", "--service-id=123"},
+			API:  mock.API{GetStatsJSONFn: getStatsJSONOK},
 		},
 		{
-			args:      []string{"stats", "historical", "--service-id=123"},
-			api:       mock.API{GetStatsJSONFn: getStatsJSONError},
-			wantError: errTest.Error(),
+			Args: []string{"stats", "historical", "--service-id=123"},
+			API:  mock.API{GetStatsJSONFn: getStatsJSONError},
 		},
 		{
-			args:       []string{"stats", "historical", "--service-id=123", "--format=json"},
-			api:        mock.API{GetStatsJSONFn: getStatsJSONOK},
-			wantOutput: historicalJSONOK,
+			Args: []string{"stats", "historical", "--service-id=123", "--format=json"},
+			API:  mock.API{GetStatsJSONFn: getStatsJSONOK},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                            = testcase.args
-				env                             = config.Environment{}
-				file                            = config.File{}
-				configFileName                  = "/dev/null"
-				clientFactory                   = mock.APIClient(testcase.api)
-				httpClient                      = http.DefaultClient
-				versioner      update.Versioner = nil
-				in             io.Reader        = nil
-				out            bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFileName, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
 
-var historicalOK = `From: Wed May 15 20:08:35 UTC 2013
-To: Thu May 16 20:08:35 UTC 2013
-By: day
-Region: all
----
-Service ID:                                    123
-Start Time:          1970-01-01 00:00:00 +0000 UTC
---------------------------------------------------
-Hit Rate:                                    0.00%
-Avg Hit Time:                               0.00µs
-Avg Miss Time:                              0.00µs
-
-Request BW:                                      0
-  Headers:                                       0
-  Body:                                          0
-
-Response BW:                                     0
-  Headers:                                       0
-  Body:                                          0
-
-Requests:                                        0
-  Hit:                                           0
-  Miss:                                          0
-  Pass:                                          0
-  Synth:                                         0
-  Error:                                         0
-  Uncacheable:                                   0
-`
-
-var historicalJSONOK = `{"start_time":0}
-`
-
 func getStatsJSONOK(i *fastly.GetStatsInput, o interface{}) error {
 	msg := []byte(`
 {
@@ -36,6 +36,8 @@ func NewRealtimeCommand(parent common.Registerer, globals *config.Data) *Realtim
 
 // Exec implements the command interface.
 func (c *RealtimeCommand) Exec(in io.Reader, out io.Writer) error {
+	c.formatFlag = c.Globals.CommandFormat(c.formatFlag)
+
 	service, source := c.manifest.ServiceID()
 	if source == manifest.SourceUndefined {
 		return errors.ErrNoServiceID
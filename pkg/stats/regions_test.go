@@ -1,54 +1,28 @@
 package stats_test
 
 import (
-	"bytes"
 	"errors"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 
-	"github.com/fastly/cli/pkg/app"
-	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/mock"
-	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/go-fastly/v2/fastly"
 )
 
 func TestRegions(t *testing.T) {
-	for _, testcase := range []struct {
-		args       []string
-		api        mock.API
-		wantError  string
-		wantOutput string
-	}{
+	for _, scenario := range []golden.Scenario{
 		{
-			args:       []string{"stats", "regions"},
-			api:        mock.API{GetRegionsFn: getRegionsOK},
-			wantOutput: "foo\nbar\nbaz\n",
+			Args: []string{"stats", "regions"},
+			API:  mock.API{GetRegionsFn: getRegionsOK},
 		},
 		{
-			args:      []string{"stats", "regions"},
-			api:       mock.API{GetRegionsFn: getRegionsError},
-			wantError: errTest.Error(),
+			Args: []string{"stats", "regions"},
+			API:  mock.API{GetRegionsFn: getRegionsError},
 		},
 	} {
-		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
-			var (
-				args                            = testcase.args
-				env                             = config.Environment{}
-				file                            = config.File{}
-				configFileName                  = "/dev/null"
-				clientFactory                   = mock.APIClient(testcase.api)
-				httpClient                      = http.DefaultClient
-				versioner      update.Versioner = nil
-				in             io.Reader        = nil
-				out            bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFileName, clientFactory, httpClient, versioner, in, &out)
-			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
 		})
 	}
 }
@@ -0,0 +1,31 @@
+// Package apptest runs the CLI in tests against an API server, such as the fake
+// Fastly API of package fakeapi, rather than a mock API client.
+package apptest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/update"
+)
+
+// Run runs the CLI via app.Run with args and stdin, which may be nil, against
+// the API at endpoint, and returns its stdout.
+func Run(t *testing.T, endpoint string, stdin io.Reader, args ...string) (string, error) {
+	t.Helper()
+	var (
+		env                            = config.Environment{Token: "fake", Endpoint: endpoint}
+		file                           = config.File{}
+		appConfigFile                  = "/dev/null"
+		clientFactory                  = app.FastlyAPIClient
+		httpClient                     = http.DefaultClient
+		versioner     update.Versioner = nil
+		out           bytes.Buffer
+	)
+	err := app.Run(args, env, file, appConfigFile, clientFactory, httpClient, versioner, stdin, &out)
+	return out.String(), err
+}
@@ -0,0 +1,6 @@
+// Package golden provides a harness for testing the output of commands against
+// golden files. A scenario is run through app.Run with a mock API, and its
+// stdout and error are compared against a file under the test package's
+// testdata directory. Passing -update to go test rewrites the golden files
+// with the current output, e.g. go test ./pkg/backend -update.
+package golden
@@ -0,0 +1,146 @@
+package golden
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/update"
+	"github.com/google/go-cmp/cmp"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files with the current output")
+
+// Scenario is a single invocation of the CLI.
+type Scenario struct {
+	Args  []string
+	API   mock.API
+	Env   config.Environment
+	File  config.File
+	Stdin string
+
+	// ConfigFile is the path of the app config file, /dev/null if empty.
+	ConfigFile string
+	// ClientFactory replaces the mock client of API, e.g. with a spy.
+	ClientFactory app.APIClientFactory
+	// HTTPClient makes requests other than via the API client, and defaults
+	// to http.DefaultClient.
+	HTTPClient api.HTTPClient
+	Versioner  update.Versioner
+}
+
+// Run runs the scenario through app.Run, and compares its output and error
+// against the golden file for the current test.
+func Run(t *testing.T, s Scenario) {
+	t.Helper()
+	out, err := Exec(s)
+	Assert(t, Format(out, err))
+}
+
+// Exec runs the scenario through app.Run, and returns its output and error,
+// for tests which check them other than against a golden file.
+func Exec(s Scenario) (string, error) {
+	var (
+		env           = s.Env
+		file          = s.File
+		appConfigFile = s.ConfigFile
+		clientFactory = s.ClientFactory
+		httpClient    = s.HTTPClient
+		versioner     = s.Versioner
+		in            io.Reader
+		out           bytes.Buffer
+	)
+	if appConfigFile == "" {
+		appConfigFile = "/dev/null"
+	}
+	if clientFactory == nil {
+		clientFactory = mock.APIClient(s.API)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if s.Stdin != "" {
+		in = strings.NewReader(s.Stdin)
+	}
+	err := app.Run(s.Args, env, file, appConfigFile, clientFactory, httpClient, versioner, in, &out)
+	return out.String(), err
+}
+
+// Format renders a command's output and error in the golden file format. The
+// error section is only present when err is non-nil.
+func Format(output string, err error) string {
+	var b strings.Builder
+	b.WriteString(Normalize(output))
+	if err != nil {
+		b.WriteString("-- error --\n")
+		b.WriteString(Normalize(err.Error()))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+var ansi = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// Normalize strips the ANSI escape sequences used by the text package for
+// colours and styles, so golden files don't depend on the terminal.
+func Normalize(s string) string {
+	return ansi.ReplaceAllString(s, "")
+}
+
+// Assert compares have against the golden file for the current test, or
+// rewrites the golden file if the -update flag was passed.
+func Assert(t *testing.T, have string) {
+	t.Helper()
+
+	path := Path(t)
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(have), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading golden file (run go test with -update to create it): %v", err)
+	}
+	if diff := cmp.Diff(string(want), have); diff != "" {
+		t.Fatalf("output doesn't match %s (-want +have):\n%s", path, diff)
+	}
+}
+
+// maxNameLength keeps golden file names well within filesystem limits, as
+// subtest names are derived from command arguments and can be long.
+const maxNameLength = 100
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._#=,@+-]+`)
+
+// Path returns the golden file path for the current test. Each level of
+// subtest maps to a directory, e.g. testdata/TestBackendList/<subtest>.golden.
+func Path(t *testing.T) string {
+	parts := strings.Split(t.Name(), "/")
+	for i, part := range parts {
+		name := unsafeChars.ReplaceAllString(part, "_")
+		if len(name) > maxNameLength {
+			name = fmt.Sprintf("%s-%x", name[:maxNameLength-9], sha1.Sum([]byte(part)))[:maxNameLength]
+		}
+		parts[i] = name
+	}
+	return filepath.Join("testdata", filepath.Join(parts...)+".golden")
+}
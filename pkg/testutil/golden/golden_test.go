@@ -0,0 +1,27 @@
+package golden_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
+)
+
+func TestFormat(t *testing.T) {
+	testutil.AssertString(t, "SUCCESS: Done\n", golden.Format("\x1b[1;32mSUCCESS\x1b[0m: Done\n", nil))
+	testutil.AssertString(t, "Doing\n-- error --\nfailed\n", golden.Format("Doing\n", errors.New("\x1b[31mfailed\x1b[0m")))
+}
+
+func TestPath(t *testing.T) {
+	t.Run("backend list --service-id 123", func(t *testing.T) {
+		testutil.AssertString(t, filepath.Join("testdata", "TestPath", "backend_list_--service-id_123.golden"), golden.Path(t))
+	})
+	t.Run(strings.Repeat("a", 200), func(t *testing.T) {
+		name := filepath.Base(golden.Path(t))
+		testutil.AssertBool(t, true, len(name) <= 107)
+		testutil.AssertBool(t, true, strings.HasPrefix(name, strings.Repeat("a", 91)+"-"))
+	})
+}
@@ -2,6 +2,7 @@ package text
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -67,16 +68,15 @@ func Output(w io.Writer, format string, args ...interface{}) {
 //
 // Input is intended to be used to take interactive input from the user.
 func Input(w io.Writer, prefix string, r io.Reader, validators ...func(string) error) (string, error) {
-	s := bufio.NewScanner(r)
-
 outer:
 	for {
 		fmt.Fprint(w, Bold(prefix))
-		if ok := s.Scan(); !ok {
-			return "", s.Err()
+		line, ok, err := readLine(r)
+		if !ok {
+			return "", err
 		}
 
-		line := strings.TrimSpace(s.Text())
+		line = strings.TrimSpace(line)
 		for _, validate := range validators {
 			if err := validate(line); err != nil {
 				fmt.Fprintln(w, err.Error())
@@ -88,6 +88,31 @@ outer:
 	}
 }
 
+// readLine reads a single line from r a byte at a time, rather than through a
+// buffer, so nothing beyond the line is consumed and r can be used for further
+// prompts. ok is false if there's no line to read.
+func readLine(r io.Reader) (line string, ok bool, err error) {
+	var (
+		buf []byte
+		b   = make([]byte, 1)
+	)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				return string(buf), true, nil
+			}
+			buf = append(buf, b[0])
+		}
+		if err == io.EOF {
+			return string(buf), len(buf) > 0, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+	}
+}
+
 // InputSecure is like Input but doesn't echo input back to the terminal,
 // if and only if r is os.Stdin.
 func InputSecure(w io.Writer, prefix string, r io.Reader, validators ...func(string) error) (string, error) {
@@ -127,6 +152,65 @@ outer:
 	}
 }
 
+// ErrNonInteractive is returned by Confirm when confirmation is required but
+// the input isn't an interactive terminal.
+var ErrNonInteractive = errors.New("refusing to run a destructive operation without confirmation from a non-interactive session")
+
+// ErrNotConfirmed is returned by Confirm when the user declines.
+var ErrNotConfirmed = errors.New("operation not confirmed")
+
+// IsInteractive reports whether r can be used to prompt the user. Files, like
+// os.Stdin, are interactive only if they're a terminal. Any other non-nil
+// reader, such as those used in tests, is assumed to be interactive.
+func IsInteractive(r io.Reader) bool {
+	if r == nil {
+		return false
+	}
+	if f, ok := r.(*os.File); ok {
+		return terminal.IsTerminal(int(f.Fd()))
+	}
+	return true
+}
+
+// Confirm asks the user to confirm a destructive operation, described by
+// prompt. If expected is non-empty, the user must type it exactly, e.g. the
+// name of the resource about to be deleted. Otherwise, answering "y" or "yes"
+// is enough, which is intended for lower-risk operations.
+//
+// If autoYes is true, the operation is confirmed without prompting. If r isn't
+// interactive, ErrNonInteractive is returned rather than blocking on input
+// that will never arrive.
+func Confirm(w io.Writer, r io.Reader, autoYes bool, prompt, expected string) error {
+	if autoYes {
+		return nil
+	}
+	if !IsInteractive(r) {
+		return ErrNonInteractive
+	}
+
+	if expected == "" {
+		answer, err := Input(w, strings.TrimSpace(prompt)+" [y/N] ", r)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return nil
+		}
+		return ErrNotConfirmed
+	}
+
+	Output(w, "%s", strings.TrimSpace(prompt))
+	answer, err := Input(w, fmt.Sprintf("Type '%s' to confirm: ", expected), r)
+	if err != nil {
+		return err
+	}
+	if answer != expected {
+		return ErrNotConfirmed
+	}
+	return nil
+}
+
 // Break simply writes a newline to the writer. It's intended to be used between
 // blocks of text that would otherwise be adjacent, a sort of semantic markup.
 func Break(w io.Writer) {
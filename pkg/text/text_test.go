@@ -74,6 +74,66 @@ func TestInput(t *testing.T) {
 	}
 }
 
+func TestConfirm(t *testing.T) {
+	for _, testcase := range []struct {
+		name       string
+		in         io.Reader
+		autoYes    bool
+		prompt     string
+		expected   string
+		wantError  error
+		wantOutput string
+	}{
+		{
+			name:    "auto yes",
+			autoYes: true,
+			prompt:  "Delete it?",
+		},
+		{
+			name:      "non-interactive",
+			prompt:    "Delete it?",
+			wantError: text.ErrNonInteractive,
+		},
+		{
+			name:       "yes",
+			in:         strings.NewReader("y\n"),
+			prompt:     "Delete it?",
+			wantOutput: "Delete it? [y/N] ",
+		},
+		{
+			name:       "default no",
+			in:         strings.NewReader("\n"),
+			prompt:     "Delete it?",
+			wantError:  text.ErrNotConfirmed,
+			wantOutput: "Delete it? [y/N] ",
+		},
+		{
+			name:       "typed name",
+			in:         strings.NewReader("foo\n"),
+			prompt:     "Delete foo?",
+			expected:   "foo",
+			wantOutput: "Delete foo?\nType 'foo' to confirm: ",
+		},
+		{
+			name:       "typed name mismatch",
+			in:         strings.NewReader("y\n"),
+			prompt:     "Delete foo?",
+			expected:   "foo",
+			wantError:  text.ErrNotConfirmed,
+			wantOutput: "Delete foo?\nType 'foo' to confirm: ",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := text.Confirm(&buf, testcase.in, testcase.autoYes, testcase.prompt, testcase.expected)
+			if !errors.Is(err, testcase.wantError) {
+				t.Fatalf("want error %v, have %v", testcase.wantError, err)
+			}
+			testutil.AssertString(t, testcase.wantOutput, buf.String())
+		})
+	}
+}
+
 func TestPrefixes(t *testing.T) {
 	for _, testcase := range []struct {
 		name   string
@@ -0,0 +1,31 @@
+package text
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/go-fastly/v2/fastly"
+	"github.com/segmentio/textio"
+)
+
+// PrintWAF pretty prints a fastly.WAF structure in verbose format to a given
+// io.Writer. Consumers can provide a prefix string which will be used as a
+// prefix to each line, useful for indentation.
+func PrintWAF(out io.Writer, prefix string, w *fastly.WAF) {
+	out = textio.NewPrefixWriter(out, prefix)
+
+	fmt.Fprintf(out, "ID: %s\n", w.ID)
+	fmt.Fprintf(out, "Disabled: %v\n", w.Disabled)
+	fmt.Fprintf(out, "Response: %s\n", w.Response)
+	fmt.Fprintf(out, "Prefetch condition: %s\n", w.PrefetchCondition)
+	fmt.Fprintf(out, "Active rules (Fastly): %d log, %d block\n", w.ActiveRulesFastlyLogCount, w.ActiveRulesFastlyBlockCount)
+	fmt.Fprintf(out, "Active rules (OWASP): %d log, %d block, %d score\n", w.ActiveRulesOWASPLogCount, w.ActiveRulesOWASPBlockCount, w.ActiveRulesOWASPScoreCount)
+	fmt.Fprintf(out, "Active rules (Trustwave): %d log, %d block\n", w.ActiveRulesTrustwaveLogCount, w.ActiveRulesTrustwaveBlockCount)
+	if w.CreatedAt != nil {
+		fmt.Fprintf(out, "Created (UTC): %s\n", w.CreatedAt.UTC().Format(common.TimeFormat))
+	}
+	if w.UpdatedAt != nil {
+		fmt.Fprintf(out, "Last edited (UTC): %s\n", w.UpdatedAt.UTC().Format(common.TimeFormat))
+	}
+}
@@ -11,14 +11,15 @@ import (
 	"github.com/fastly/cli/pkg/config"
 )
 
-// Check if the CLI can be updated.
-func Check(ctx context.Context, currentVersion string, v Versioner) (current, latest semver.Version, shouldUpdate bool, err error) {
+// Check if the CLI can be updated to the latest version in the release
+// channel.
+func Check(ctx context.Context, currentVersion, channel string, v Versioner) (current, latest semver.Version, shouldUpdate bool, err error) {
 	current, err = semver.Parse(strings.TrimPrefix(currentVersion, "v"))
 	if err != nil {
 		return current, latest, false, fmt.Errorf("error reading current version: %w", err)
 	}
 
-	latest, err = v.LatestVersion(ctx)
+	latest, err = v.LatestVersion(ctx, channel)
 	if err != nil {
 		return current, latest, false, fmt.Errorf("error fetching latest version: %w", err)
 	}
@@ -35,8 +36,9 @@ type checkResult struct {
 
 // CheckAsync is a helper function for Check. If the LastVersionCheck time is
 // more than 24 hours ago, launch a goroutine to perform the Check using the
-// provided context. Return a function that will print an informative message to
-// the writer if there is a newer version available.
+// provided context and the release channel from the config file. Return a
+// function that will print an informative message to the writer if there is a
+// newer version available.
 //
 // Callers should invoke CheckAsync via
 //
@@ -50,7 +52,7 @@ func CheckAsync(ctx context.Context, file config.File, configFilePath string, cu
 
 	results := make(chan checkResult, 1)
 	go func() {
-		current, latest, shouldUpdate, err := Check(ctx, currentVersion, v)
+		current, latest, shouldUpdate, err := Check(ctx, currentVersion, file.UpdateChannel, v)
 		results <- checkResult{current, latest, shouldUpdate, err}
 	}()
 
@@ -21,6 +21,7 @@ func TestCheck(t *testing.T) {
 	for _, testcase := range []struct {
 		name        string
 		current     string
+		channel     string
 		latest      update.Versioner
 		wantError   string
 		wantCurrent semver.Version
@@ -61,9 +62,27 @@ func TestCheck(t *testing.T) {
 			wantLatest:  semver.MustParse("1.2.4"),
 			wantUpdate:  true,
 		},
+		{
+			name:        "stable channel ignores prerelease",
+			current:     "v1.2.3",
+			channel:     update.ChannelStable,
+			latest:      mock.Versioner{Version: "v1.2.3", PrereleaseVersion: "v1.3.0-rc.1"},
+			wantCurrent: semver.MustParse("1.2.3"),
+			wantLatest:  semver.MustParse("1.2.3"),
+			wantUpdate:  false,
+		},
+		{
+			name:        "prerelease channel",
+			current:     "v1.2.3",
+			channel:     update.ChannelPrerelease,
+			latest:      mock.Versioner{Version: "v1.2.3", PrereleaseVersion: "v1.3.0-rc.1"},
+			wantCurrent: semver.MustParse("1.2.3"),
+			wantLatest:  semver.MustParse("1.3.0-rc.1"),
+			wantUpdate:  true,
+		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
-			current, latest, shouldUpdate, err := update.Check(context.Background(), testcase.current, testcase.latest)
+			current, latest, shouldUpdate, err := update.Check(context.Background(), testcase.current, testcase.channel, testcase.latest)
 			if testcase.wantError != "" {
 				if want, have := testcase.wantError, err; want != have.Error() {
 					t.Fatalf("error: want %q, have %q", want, have.Error())
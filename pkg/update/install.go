@@ -0,0 +1,48 @@
+package update
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fastly/cli/pkg/common"
+)
+
+// BackupPath returns the location of the backup made of the binary at path
+// when it's replaced by Install.
+func BackupPath(path string) string {
+	return path + ".bak"
+}
+
+// Install replaces the binary at currentPath with the one at latestPath,
+// keeping the replaced binary at BackupPath(currentPath) so it can later be
+// restored by Rollback. Any previous backup is overwritten.
+func Install(latestPath, currentPath string) error {
+	backupPath := BackupPath(currentPath)
+	if err := os.Rename(currentPath, backupPath); err != nil {
+		return fmt.Errorf("error backing up current binary: %w", err)
+	}
+
+	if err := os.Rename(latestPath, currentPath); err != nil {
+		if err := common.CopyFile(latestPath, currentPath); err != nil {
+			os.Rename(backupPath, currentPath) // best effort to leave things as we found them
+			return fmt.Errorf("error moving latest binary in place: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Rollback restores the binary at currentPath from the backup made by the
+// most recent Install. The backup is consumed in the process.
+func Rollback(currentPath string) error {
+	backupPath := BackupPath(currentPath)
+	if _, err := os.Stat(backupPath); err != nil {
+		return fmt.Errorf("no backup found at %s", backupPath)
+	}
+
+	if err := os.Rename(backupPath, currentPath); err != nil {
+		return fmt.Errorf("error restoring backup: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package update_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/update"
+)
+
+func TestInstallAndRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fastly-update-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	currentPath := filepath.Join(dir, "fastly")
+	latestPath := filepath.Join(dir, "fastly_latest")
+	writeFile(t, currentPath, "current")
+	writeFile(t, latestPath, "latest")
+
+	testutil.AssertErrorContains(t, update.Rollback(currentPath), "no backup found")
+
+	testutil.AssertNoError(t, update.Install(latestPath, currentPath))
+	testutil.AssertString(t, "latest", readFile(t, currentPath))
+	testutil.AssertString(t, "current", readFile(t, update.BackupPath(currentPath)))
+
+	testutil.AssertNoError(t, update.Rollback(currentPath))
+	testutil.AssertString(t, "current", readFile(t, currentPath))
+	if _, err := os.Stat(update.BackupPath(currentPath)); !os.IsNotExist(err) {
+		t.Fatalf("want backup to be consumed, have %v", err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
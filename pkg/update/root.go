@@ -6,9 +6,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/blang/semver"
 	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/fastly/cli/pkg/version"
 )
@@ -17,65 +20,139 @@ import (
 // It should be installed under the primary root command.
 type RootCommand struct {
 	common.Base
-	versioner Versioner
-	client    api.HTTPClient
+	configFilePath string
+	versioner      Versioner
+	client         api.HTTPClient
+	rollback       bool
+	version        string
+	channel        string
 }
 
 // NewRootCommand returns a new command registered in the parent.
-func NewRootCommand(parent common.Registerer, v Versioner, client api.HTTPClient) *RootCommand {
+func NewRootCommand(parent common.Registerer, configFilePath string, v Versioner, client api.HTTPClient, globals *config.Data) *RootCommand {
 	var c RootCommand
+	c.Globals = globals
 	c.CmdClause = parent.Command("update", "Update the CLI to the latest version")
+	c.configFilePath = configFilePath
 	c.versioner = v
 	c.client = client
+	c.CmdClause.Flag("rollback", "Restore the binary replaced by the most recent update").BoolVar(&c.rollback)
+	c.CmdClause.Flag("version", "Install a specific version, which may be older than the current one").StringVar(&c.version)
+	c.CmdClause.Flag("channel", "Release channel to track, saved for future updates (stable, prerelease)").EnumVar(&c.channel, ChannelStable, ChannelPrerelease)
 	return &c
 }
 
 // Exec implements the command interface.
 func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
-	progress := text.NewQuietProgress(out)
+	if c.rollback {
+		return c.execRollback(out)
+	}
 
-	current, latest, shouldUpdate, err := Check(context.Background(), version.AppVersion, c.versioner)
+	channel, err := c.updateChannel(out)
 	if err != nil {
-		return fmt.Errorf("error checking for latest version: %w", err)
+		return err
 	}
 
-	text.Output(out, "Current version: %s", current)
-	text.Output(out, "Latest version: %s", latest)
-	if !shouldUpdate {
-		text.Output(out, "No update required.")
-		return nil
+	progress := text.NewQuietProgress(out)
+
+	var target semver.Version
+	if c.version != "" {
+		target, err = semver.Parse(strings.TrimPrefix(c.version, "v"))
+		if err != nil {
+			return fmt.Errorf("error parsing version %s: %w", c.version, err)
+		}
+
+		text.Output(out, "Current version: %s", version.AppVersion)
+		text.Output(out, "Target version: %s", target)
+		if current, err := semver.Parse(strings.TrimPrefix(version.AppVersion, "v")); err == nil && current.Equals(target) {
+			text.Output(out, "No update required.")
+			return nil
+		}
+	} else {
+		current, latest, shouldUpdate, err := Check(context.Background(), version.AppVersion, channel, c.versioner)
+		if err != nil {
+			return fmt.Errorf("error checking for latest version: %w", err)
+		}
+
+		text.Output(out, "Current version: %s", current)
+		text.Output(out, "Latest version: %s", latest)
+		if !shouldUpdate {
+			text.Output(out, "No update required.")
+			return nil
+		}
+		target = latest
 	}
 
-	progress.Step("Fetching latest release...")
-	latestPath, err := c.versioner.Download(context.Background(), latest)
+	progress.Step(fmt.Sprintf("Fetching and verifying release %s...", target))
+	latestPath, err := c.versioner.Download(context.Background(), target)
 	if err != nil {
 		progress.Fail()
-		return fmt.Errorf("error downloading latest release: %w", err)
+		return fmt.Errorf("error downloading release: %w", err)
 	}
 	defer os.RemoveAll(latestPath)
 
 	progress.Step("Replacing binary...")
-	currentPath, err := os.Executable()
+	currentPath, err := executablePath()
 	if err != nil {
 		progress.Fail()
-		return fmt.Errorf("error determining executable path: %w", err)
+		return err
 	}
 
-	currentPath, err = filepath.Abs(currentPath)
-	if err != nil {
+	if err := Install(latestPath, currentPath); err != nil {
 		progress.Fail()
-		return fmt.Errorf("error determining absolute target path: %w", err)
+		return err
 	}
 
-	if err := os.Rename(latestPath, currentPath); err != nil {
-		if err := common.CopyFile(latestPath, currentPath); err != nil {
-			progress.Fail()
-			return fmt.Errorf("error moving latest binary in place: %w", err)
+	progress.Done()
+
+	text.Success(out, "Updated %s to %s.", currentPath, target)
+	text.Description(out, "To restore the previous version, run", "fastly update --rollback")
+	return nil
+}
+
+// updateChannel returns the release channel to track. If the --channel flag
+// differs from the channel in the config file, it's saved to the file.
+func (c *RootCommand) updateChannel(out io.Writer) (string, error) {
+	channel := c.Globals.File.UpdateChannel
+	if c.channel == "" || c.channel == channel {
+		if channel == "" {
+			channel = ChannelStable
 		}
+		return channel, nil
 	}
 
-	progress.Done()
+	c.Globals.File.UpdateChannel = c.channel
+	if err := c.Globals.File.Write(c.configFilePath); err != nil {
+		return "", fmt.Errorf("error saving update channel: %w", err)
+	}
+	text.Info(out, "Release channel set to %s.", c.channel)
+	return c.channel, nil
+}
+
+func (c *RootCommand) execRollback(out io.Writer) error {
+	currentPath, err := executablePath()
+	if err != nil {
+		return err
+	}
+
+	if err := Rollback(currentPath); err != nil {
+		return err
+	}
 
-	text.Success(out, "Updated %s to %s.", currentPath, latest)
+	text.Success(out, "Restored %s from backup.", currentPath)
 	return nil
 }
+
+func executablePath() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("error determining executable path: %w", err)
+	}
+
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("error determining absolute target path: %w", err)
+	}
+
+	return path, nil
+}
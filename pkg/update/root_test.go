@@ -0,0 +1,84 @@
+package update_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
+)
+
+func TestUpdate(t *testing.T) {
+	for _, testcase := range []struct {
+		args        []string
+		file        config.File
+		versioner   mock.Versioner
+		wantError   string
+		wantOutput  string
+		wantChannel string
+	}{
+		{
+			args:       []string{"update"},
+			versioner:  mock.Versioner{Version: "v1.2.3", PrereleaseVersion: "v1.3.0-rc.1"},
+			wantError:  "error downloading release: not implemented",
+			wantOutput: "Latest version: 1.2.3",
+		},
+		{
+			args:        []string{"update", "--channel", "prerelease"},
+			versioner:   mock.Versioner{Version: "v1.2.3", PrereleaseVersion: "v1.3.0-rc.1"},
+			wantError:   "error downloading release: not implemented",
+			wantOutput:  "Release channel set to prerelease.\nCurrent version: 0.0.0-unknown\nLatest version: 1.3.0-rc.1",
+			wantChannel: "prerelease",
+		},
+		{
+			args:        []string{"update"},
+			file:        config.File{UpdateChannel: "prerelease"},
+			versioner:   mock.Versioner{Version: "v1.2.3", PrereleaseVersion: "v1.3.0-rc.1"},
+			wantError:   "error downloading release: not implemented",
+			wantOutput:  "Latest version: 1.3.0-rc.1",
+			wantChannel: "prerelease",
+		},
+		{
+			args:      []string{"update", "--channel", "nightly"},
+			versioner: mock.Versioner{Version: "v1.2.3"},
+			wantError: "enum value must be one of stable,prerelease",
+		},
+		{
+			args:       []string{"update", "--version", "0.9.0"},
+			versioner:  mock.Versioner{Version: "v1.2.3"},
+			wantError:  "error downloading release: not implemented",
+			wantOutput: "Target version: 0.9.0",
+		},
+		{
+			args:      []string{"update", "--version", "latest"},
+			versioner: mock.Versioner{Version: "v1.2.3"},
+			wantError: "error parsing version latest",
+		},
+	} {
+		t.Run(strings.Join(testcase.args, " "), func(t *testing.T) {
+			configFile := testutil.MakeTempFile(t, "")
+			defer os.RemoveAll(configFile)
+
+			out, err := golden.Exec(golden.Scenario{
+				Args:       testcase.args,
+				File:       testcase.file,
+				ConfigFile: configFile,
+				Versioner:  testcase.versioner,
+			})
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, out, testcase.wantOutput)
+
+			var saved config.File
+			if b, _ := ioutil.ReadFile(configFile); len(b) > 0 {
+				testutil.AssertNoError(t, saved.Read(configFile))
+			}
+			if testcase.file.UpdateChannel == "" {
+				testutil.AssertString(t, testcase.wantChannel, saved.UpdateChannel)
+			}
+		})
+	}
+}
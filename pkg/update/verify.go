@@ -0,0 +1,51 @@
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// VerifyChecksum verifies the SHA-256 digest of the file at path against the
+// entry for name in checksums, which is in the format produced by sha256sum.
+func VerifyChecksum(checksums []byte, name, path string) error {
+	want, err := findChecksum(checksums, name)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", name, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("error reading %s: %w", name, err)
+	}
+
+	if have := hex.EncodeToString(h.Sum(nil)); have != want {
+		return fmt.Errorf("checksum mismatch for %s: want %s, have %s", name, want, have)
+	}
+	return nil
+}
+
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.TrimPrefix(fields[1], "*") == name { // "*" denotes binary mode
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum found for %s", name)
+}
@@ -0,0 +1,51 @@
+package update_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/update"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	content := []byte("release archive")
+	sum := sha256.Sum256(content)
+	digest := hex.EncodeToString(sum[:])
+
+	path := testutil.MakeTempFile(t, string(content))
+	defer os.RemoveAll(path)
+
+	for _, testcase := range []struct {
+		name      string
+		checksums string
+		wantError string
+	}{
+		{
+			name:      "match",
+			checksums: fmt.Sprintf("abc123  fastly_v1.2.3_darwin-amd64.tar.gz\n%s  fastly_v1.2.3_linux-amd64.tar.gz\n", digest),
+		},
+		{
+			name:      "match binary mode",
+			checksums: fmt.Sprintf("%s *fastly_v1.2.3_linux-amd64.tar.gz\n", digest),
+		},
+		{
+			name:      "mismatch",
+			checksums: "abc123  fastly_v1.2.3_linux-amd64.tar.gz\n",
+			wantError: "checksum mismatch for fastly_v1.2.3_linux-amd64.tar.gz",
+		},
+		{
+			name:      "missing",
+			checksums: fmt.Sprintf("%s  fastly_v1.2.3_darwin-amd64.tar.gz\n", digest),
+			wantError: "no checksum found for fastly_v1.2.3_linux-amd64.tar.gz",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := update.VerifyChecksum([]byte(testcase.checksums), "fastly_v1.2.3_linux-amd64.tar.gz", path)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -16,29 +18,64 @@ import (
 	"github.com/mholt/archiver"
 )
 
+// Release channels which can be tracked by a Versioner.
+const (
+	// ChannelStable tracks the latest full release.
+	ChannelStable = "stable"
+
+	// ChannelPrerelease tracks the latest release, including pre-releases.
+	ChannelPrerelease = "prerelease"
+)
+
+// DefaultBaseURL is the base URL of the GitHub API, from which releases are
+// fetched unless another is configured.
+const DefaultBaseURL = "https://api.github.com/"
+
 // Versioner describes a source of CLI release artifacts.
 type Versioner interface {
-	LatestVersion(context.Context) (semver.Version, error)
+	LatestVersion(ctx context.Context, channel string) (semver.Version, error)
 	Download(context.Context, semver.Version) (filename string, err error)
 }
 
 // GitHub is a versioner that uses GitHub releases.
 type GitHub struct {
 	client *github.Client
+	err    error
 }
 
-// NewGitHub returns a usable GitHub versioner utilizing the provided token.
-func NewGitHub(ctx context.Context) *GitHub {
+// NewGitHub returns a usable GitHub versioner. If baseURL is non-empty, it's
+// used in place of the GitHub API, e.g. to fetch releases from an internal
+// mirror.
+func NewGitHub(ctx context.Context, baseURL string) *GitHub {
 	var (
 		githubClient = github.NewClient(nil)
+		err          error
 	)
+	if baseURL != "" {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/" // required by the GitHub client
+		}
+		githubClient.BaseURL, err = url.Parse(baseURL)
+		if err != nil {
+			err = fmt.Errorf("error parsing release base URL: %w", err)
+		}
+	}
 	return &GitHub{
 		client: githubClient,
+		err:    err,
 	}
 }
 
 // LatestVersion implements the Versioner interface.
-func (g GitHub) LatestVersion(ctx context.Context) (semver.Version, error) {
+func (g GitHub) LatestVersion(ctx context.Context, channel string) (semver.Version, error) {
+	if g.err != nil {
+		return semver.Version{}, g.err
+	}
+
+	if channel == ChannelPrerelease {
+		return g.latestPrerelease(ctx)
+	}
+
 	release, _, err := g.client.Repositories.GetLatestRelease(ctx, "fastly", "cli")
 	if err != nil {
 		return semver.Version{}, err
@@ -46,8 +83,47 @@ func (g GitHub) LatestVersion(ctx context.Context) (semver.Version, error) {
 	return semver.Parse(strings.TrimPrefix(release.GetName(), "v"))
 }
 
+// latestPrerelease returns the highest version of all published releases,
+// including pre-releases, which GetLatestRelease ignores.
+func (g GitHub) latestPrerelease(ctx context.Context) (latest semver.Version, err error) {
+	var page int
+	for {
+		releases, resp, err := g.client.Repositories.ListReleases(ctx, "fastly", "cli", &github.ListOptions{
+			Page:    page,
+			PerPage: 100,
+		})
+		if err != nil {
+			return latest, err
+		}
+		for _, release := range releases {
+			if release.GetDraft() {
+				continue
+			}
+			v, err := semver.Parse(strings.TrimPrefix(release.GetName(), "v"))
+			if err != nil {
+				continue // ignore releases not named by version
+			}
+			if v.GT(latest) {
+				latest = v
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		page = resp.NextPage
+	}
+	if latest.Equals(semver.Version{}) {
+		return latest, fmt.Errorf("no releases found")
+	}
+	return latest, nil
+}
+
 // Download implements the Versioner interface.
 func (g GitHub) Download(ctx context.Context, version semver.Version) (filename string, err error) {
+	if g.err != nil {
+		return filename, g.err
+	}
+
 	releaseID, err := g.getReleaseID(ctx, version)
 	if err != nil {
 		return filename, err
@@ -58,45 +134,26 @@ func (g GitHub) Download(ctx context.Context, version semver.Version) (filename
 		return filename, fmt.Errorf("error fetching release: %w", err)
 	}
 
-	assetID, err := g.getAssetID(release.Assets, version)
+	assetName := fmt.Sprintf("fastly_v%s_%s-%s.tar.gz", version, runtime.GOOS, runtime.GOARCH)
+	assetID, err := getAssetID(release.Assets, assetName)
 	if err != nil {
-		return filename, err
-	}
-
-	rc, redir, err := g.client.Repositories.DownloadReleaseAsset(ctx, "fastly", "cli", assetID)
-	if err != nil {
-		return filename, err
-	}
-	if redir != "" {
-		// gosec flagged this:
-		// G107 (CWE-88): Potential HTTP request made with variable url.
-		// Disabling as we trust the source of the URL variable.
-		/* #nosec */
-		resp, err := http.Get(redir)
-		if err != nil {
-			return filename, err
-		}
-		if resp.StatusCode != http.StatusOK {
-			return filename, fmt.Errorf("GitHub gave %s", resp.Status)
-		}
-		rc = resp.Body
+		return filename, fmt.Errorf("no asset found for your OS (%s) and architecture (%s)", runtime.GOOS, runtime.GOARCH)
 	}
-	defer rc.Close()
 
 	archivePath := filepath.Join(os.TempDir(), fmt.Sprintf("fastly_%s.tgz", version))
-	dst, err := os.Create(archivePath)
-	if err != nil {
-		return filename, fmt.Errorf("error creating temp file: %w", err)
-	}
 	defer os.RemoveAll(archivePath)
+	if err := g.downloadAsset(ctx, assetID, archivePath); err != nil {
+		return filename, fmt.Errorf("error downloading release: %w", err)
+	}
 
-	_, err = io.Copy(dst, rc)
+	checksumsName := fmt.Sprintf("fastly_v%s_SHA256SUMS", version)
+	checksums, err := g.readAsset(ctx, release.Assets, checksumsName)
 	if err != nil {
-		return filename, fmt.Errorf("error downloading release: %w", err)
+		return filename, fmt.Errorf("error downloading checksums: %w", err)
 	}
 
-	if err := dst.Close(); err != nil {
-		return filename, fmt.Errorf("error closing release file: %w", err)
+	if err := VerifyChecksum(checksums, assetName, archivePath); err != nil {
+		return filename, err
 	}
 
 	binaryPath := filepath.Join(os.TempDir(), fmt.Sprintf("fastly_%s_%d", version, time.Now().UnixNano()))
@@ -134,12 +191,65 @@ func (g GitHub) getReleaseID(ctx context.Context, version semver.Version) (id in
 	return id, fmt.Errorf("no matching release found")
 }
 
-func (g GitHub) getAssetID(assets []github.ReleaseAsset, version semver.Version) (id int64, err error) {
-	target := fmt.Sprintf("fastly_v%s_%s-%s.tar.gz", version, runtime.GOOS, runtime.GOARCH)
+// readAsset downloads the named release asset into memory.
+func (g GitHub) readAsset(ctx context.Context, assets []github.ReleaseAsset, name string) ([]byte, error) {
+	id, err := getAssetID(assets, name)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("%s_%d", name, time.Now().UnixNano()))
+	defer os.RemoveAll(path)
+	if err := g.downloadAsset(ctx, id, path); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(path)
+}
+
+// downloadAsset downloads the release asset with the given ID to path.
+func (g GitHub) downloadAsset(ctx context.Context, id int64, path string) error {
+	rc, redir, err := g.client.Repositories.DownloadReleaseAsset(ctx, "fastly", "cli", id)
+	if err != nil {
+		return err
+	}
+	if redir != "" {
+		// gosec flagged this:
+		// G107 (CWE-88): Potential HTTP request made with variable url.
+		// Disabling as we trust the source of the URL variable.
+		/* #nosec */
+		resp, err := http.Get(redir)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return fmt.Errorf("GitHub gave %s", resp.Status)
+		}
+		rc = resp.Body
+	}
+	defer rc.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating temp file: %w", err)
+	}
+
+	if _, err := io.Copy(dst, rc); err != nil {
+		dst.Close()
+		return err
+	}
+
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("error closing temp file: %w", err)
+	}
+	return nil
+}
+
+func getAssetID(assets []github.ReleaseAsset, name string) (id int64, err error) {
 	for _, asset := range assets {
-		if asset.GetName() == target {
+		if asset.GetName() == name {
 			return asset.GetID(), nil
 		}
 	}
-	return id, fmt.Errorf("no asset found for your OS (%s) and architecture (%s)", runtime.GOOS, runtime.GOARCH)
+	return id, fmt.Errorf("no asset named %s found", name)
 }
@@ -0,0 +1,3 @@
+// Package waf contains commands to inspect and manipulate Fastly web
+// application firewalls, their rules, and the versions of their rulesets.
+package waf
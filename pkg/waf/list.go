@@ -0,0 +1,74 @@
+package waf
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// ListCommand calls the Fastly API to list the firewalls of a service.
+type ListCommand struct {
+	common.Base
+	manifest manifest.Data
+	Input    fastly.ListWAFsInput
+}
+
+// NewListCommand returns a usable command registered under the parent.
+func NewListCommand(parent common.Registerer, globals *config.Data) *ListCommand {
+	var c ListCommand
+	c.Globals = globals
+	c.manifest.File.Read(manifest.Filename)
+	c.CmdClause = parent.Command("list", "List the web application firewalls of a Fastly service")
+	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
+	c.CmdClause.Flag("version", "Only list the firewalls of this service version").IntVar(&c.Input.FilterVersion)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ListCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source := c.manifest.ServiceID()
+	if source == manifest.SourceUndefined {
+		return errors.ErrNoServiceID
+	}
+	c.Input.FilterService = serviceID
+
+	var wafs []*fastly.WAF
+	input := c.Input
+	input.PageSize = fastly.WAFPaginationPageSize
+	for input.PageNumber = 1; ; input.PageNumber++ {
+		resp, err := c.Globals.Client.ListWAFs(&input)
+		if err != nil {
+			return err
+		}
+		wafs = append(wafs, resp.Items...)
+		if resp.Info.Links.Next == "" || len(resp.Items) == 0 {
+			break
+		}
+	}
+
+	if !c.Globals.Verbose() {
+		tw := text.NewTable(out)
+		tw.AddHeader("SERVICE", "VERSION", "ID", "DISABLED", "RESPONSE", "PREFETCH CONDITION")
+		for _, waf := range wafs {
+			tw.AddLine(waf.ServiceID, waf.ServiceVersion, waf.ID, waf.Disabled, waf.Response, waf.PrefetchCondition)
+		}
+		tw.Print()
+		return nil
+	}
+
+	fmt.Fprintf(out, "Service ID: %s\n", serviceID)
+	for i, waf := range wafs {
+		fmt.Fprintf(out, "\tWAF %d/%d\n", i+1, len(wafs))
+		fmt.Fprintf(out, "\t\tVersion: %d\n", waf.ServiceVersion)
+		text.PrintWAF(out, "\t\t", waf)
+	}
+	fmt.Fprintln(out)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package waf
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+)
+
+// RootCommand is the parent command for all subcommands in this package.
+// It should be installed under the primary root command.
+type RootCommand struct {
+	common.Base
+	// no flags
+}
+
+// NewRootCommand returns a new command registered in the parent.
+func NewRootCommand(parent common.Registerer, globals *config.Data) *RootCommand {
+	var c RootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("waf", "Manipulate Fastly web application firewalls")
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
+	panic("unreachable")
+}
+
+// RuleRootCommand is the parent command for the rule subcommands. It should be
+// installed under the waf command.
+type RuleRootCommand struct {
+	common.Base
+	// no flags
+}
+
+// NewRuleRootCommand returns a new command registered in the parent.
+func NewRuleRootCommand(parent common.Registerer, globals *config.Data) *RuleRootCommand {
+	var c RuleRootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("rule", "Manipulate the rules of Fastly web application firewalls")
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RuleRootCommand) Exec(in io.Reader, out io.Writer) error {
+	panic("unreachable")
+}
+
+// RuleStatusRootCommand is the parent command for the rule status
+// subcommands. It should be installed under the waf rule command.
+type RuleStatusRootCommand struct {
+	common.Base
+	// no flags
+}
+
+// NewRuleStatusRootCommand returns a new command registered in the parent.
+func NewRuleStatusRootCommand(parent common.Registerer, globals *config.Data) *RuleStatusRootCommand {
+	var c RuleStatusRootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("status", "Manipulate the status of the rules of a firewall")
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RuleStatusRootCommand) Exec(in io.Reader, out io.Writer) error {
+	panic("unreachable")
+}
+
+// RulesetRootCommand is the parent command for the ruleset subcommands. It
+// should be installed under the waf command.
+type RulesetRootCommand struct {
+	common.Base
+	// no flags
+}
+
+// NewRulesetRootCommand returns a new command registered in the parent.
+func NewRulesetRootCommand(parent common.Registerer, globals *config.Data) *RulesetRootCommand {
+	var c RulesetRootCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("ruleset", "Manipulate the versions of the rulesets of Fastly web application firewalls")
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *RulesetRootCommand) Exec(in io.Reader, out io.Writer) error {
+	panic("unreachable")
+}
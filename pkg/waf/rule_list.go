@@ -0,0 +1,65 @@
+package waf
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// RuleListCommand calls the Fastly API to list the active rules of a version
+// of a firewall.
+type RuleListCommand struct {
+	common.Base
+	wafID      string
+	wafVersion int
+	status     string
+}
+
+// NewRuleListCommand returns a usable command registered under the parent.
+func NewRuleListCommand(parent common.Registerer, globals *config.Data) *RuleListCommand {
+	var c RuleListCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("list", "List the rules of a firewall, and their status")
+	c.CmdClause.Flag("id", "ID of the firewall").Required().StringVar(&c.wafID)
+	c.CmdClause.Flag("waf-version", "Number of firewall version, defaulting to the active version").IntVar(&c.wafVersion)
+	c.CmdClause.Flag("status", "Only list rules with this status").EnumVar(&c.status, statuses...)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *RuleListCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := findVersion(c.Globals.Client, c.wafID, c.wafVersion, true)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Globals.Client.ListAllWAFActiveRules(&fastly.ListAllWAFActiveRulesInput{
+		WAFID:            c.wafID,
+		WAFVersionNumber: v.Number,
+		FilterStatus:     c.status,
+	})
+	if err != nil {
+		return err
+	}
+	rules := resp.Items
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ModSecID < rules[j].ModSecID
+	})
+
+	if c.Globals.Verbose() {
+		fmt.Fprintf(out, "WAF ID: %s\n", c.wafID)
+		fmt.Fprintf(out, "WAF version: %d\n", v.Number)
+	}
+	tw := text.NewTable(out)
+	tw.AddHeader("RULE ID", "STATUS", "REVISION", "LATEST REVISION")
+	for _, r := range rules {
+		tw.AddLine(r.ModSecID, r.Status, r.Revision, r.LatestRevision)
+	}
+	tw.Print()
+	return nil
+}
@@ -0,0 +1,62 @@
+package waf
+
+import (
+	"io"
+	"sort"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// RuleSearchCommand calls the Fastly API to search the rules which can be
+// added to firewalls.
+type RuleSearchCommand struct {
+	common.Base
+	tags  []string
+	owasp bool
+}
+
+// NewRuleSearchCommand returns a usable command registered under the parent.
+func NewRuleSearchCommand(parent common.Registerer, globals *config.Data) *RuleSearchCommand {
+	var c RuleSearchCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("search", "Search the rules which can be added to firewalls")
+	c.CmdClause.Flag("tag", "Only list rules with this tag, e.g. attack-sqli (repeatable)").StringsVar(&c.tags)
+	c.CmdClause.Flag("owasp", "Only list OWASP rules").BoolVar(&c.owasp)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *RuleSearchCommand) Exec(in io.Reader, out io.Writer) error {
+	input := fastly.ListAllWAFRulesInput{
+		FilterTagNames: c.tags,
+		Include:        "waf_rule_revisions",
+	}
+	if c.owasp {
+		input.FilterPublishers = []string{"owasp"}
+	}
+	resp, err := c.Globals.Client.ListAllWAFRules(&input)
+	if err != nil {
+		return err
+	}
+	rules := resp.Items
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ModSecID < rules[j].ModSecID
+	})
+
+	tw := text.NewTable(out)
+	tw.AddHeader("RULE ID", "PUBLISHER", "TYPE", "REVISION", "SEVERITY", "MESSAGE")
+	for _, r := range rules {
+		var latest fastly.WAFRuleRevision
+		for _, rev := range r.Revisions {
+			if rev.Revision > latest.Revision {
+				latest = *rev
+			}
+		}
+		tw.AddLine(r.ModSecID, r.Publisher, r.Type, latest.Revision, latest.Severity, latest.Status)
+	}
+	tw.Print()
+	return nil
+}
@@ -0,0 +1,164 @@
+package waf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// statuses are the statuses a rule can be given. Rules which are disabled are
+// removed from the firewall version.
+var statuses = []string{"log", "block", "score", "disabled"}
+
+// RuleStatus is the status of a rule, as given in a --file of rule statuses.
+type RuleStatus struct {
+	RuleID   int    `json:"rule_id"`
+	Status   string `json:"status"`
+	Revision int    `json:"revision,omitempty"` // the latest revision if 0
+}
+
+// StatusUpdateCommand calls the Fastly API to change the status of rules of a
+// firewall.
+type StatusUpdateCommand struct {
+	common.Base
+	wafID      string
+	wafVersion int
+	ruleID     common.OptionalInt
+	status     common.OptionalString
+	revision   int
+	file       common.OptionalString
+}
+
+// NewStatusUpdateCommand returns a usable command registered under the parent.
+func NewStatusUpdateCommand(parent common.Registerer, globals *config.Data) *StatusUpdateCommand {
+	var c StatusUpdateCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("update", "Change the status of a rule of a firewall, or of many rules from a file")
+	c.CmdClause.Flag("id", "ID of the firewall").Required().StringVar(&c.wafID)
+	c.CmdClause.Flag("waf-version", "Number of firewall version, defaulting to the latest version").IntVar(&c.wafVersion)
+	c.CmdClause.Flag("rule-id", "ModSecurity ID of the rule").Action(c.ruleID.Set).IntVar(&c.ruleID.Value)
+	c.CmdClause.Flag("status", "New status of the rule").Action(c.status.Set).EnumVar(&c.status.Value, statuses...)
+	c.CmdClause.Flag("revision", "Revision of the rule, defaulting to the latest revision").IntVar(&c.revision)
+	c.CmdClause.Flag("file", "Path to a JSON file of rule statuses, e.g. {\"rules\": [{\"rule_id\": 1010010, \"status\": \"block\"}]}").Action(c.file.Set).StringVar(&c.file.Value)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *StatusUpdateCommand) Exec(in io.Reader, out io.Writer) error {
+	rules, err := c.rules()
+	if err != nil {
+		return err
+	}
+
+	client := c.Globals.Client
+	v, err := findVersion(client, c.wafID, c.wafVersion, false)
+	if err != nil {
+		return err
+	}
+	// Locked versions, such as those which have been deployed, can't be
+	// changed, so the changes are made to a clone.
+	if v.Locked {
+		clone, err := client.CloneWAFVersion(&fastly.CloneWAFVersionInput{WAFID: c.wafID, WAFVersionNumber: v.Number})
+		if err != nil {
+			return fmt.Errorf("error cloning WAF %s version %d: %w", c.wafID, v.Number, err)
+		}
+		text.Info(out, "WAF version %d is locked, so it was cloned to version %d", v.Number, clone.Number)
+		v = clone
+	}
+
+	var upserts, deletes []*fastly.WAFActiveRule
+	for _, r := range rules {
+		if r.Status == "disabled" {
+			deletes = append(deletes, &fastly.WAFActiveRule{ModSecID: r.RuleID})
+			continue
+		}
+		upserts = append(upserts, &fastly.WAFActiveRule{ModSecID: r.RuleID, Status: r.Status, Revision: r.Revision})
+	}
+	for _, batch := range []struct {
+		op    fastly.BatchOperation
+		rules []*fastly.WAFActiveRule
+	}{
+		{fastly.UpsertBatchOperation, upserts},
+		{fastly.DeleteBatchOperation, deletes},
+	} {
+		for pending := batch.rules; len(pending) > 0; {
+			n := len(pending)
+			if n > fastly.BatchModifyMaximumOperations {
+				n = fastly.BatchModifyMaximumOperations
+			}
+			if _, err := client.BatchModificationWAFActiveRules(&fastly.BatchModificationWAFActiveRulesInput{
+				WAFID:            c.wafID,
+				WAFVersionNumber: v.Number,
+				Rules:            pending[:n],
+				OP:               batch.op,
+			}); err != nil {
+				return fmt.Errorf("error updating rules of WAF %s version %d: %w", c.wafID, v.Number, err)
+			}
+			pending = pending[n:]
+		}
+	}
+
+	text.Success(out, "Updated the status of %d rule(s) of WAF %s version %d", len(rules), c.wafID, v.Number)
+	if !v.Active {
+		text.Info(out, "Version %d isn't deployed; deploy it via waf ruleset deploy", v.Number)
+	}
+	return nil
+}
+
+// rules returns the rule statuses to set, from either the flags or the file.
+func (c *StatusUpdateCommand) rules() ([]RuleStatus, error) {
+	if c.file.WasSet == (c.ruleID.WasSet || c.status.WasSet) {
+		return nil, errors.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: must provide either --file, or --rule-id and --status"),
+			Remediation: "Pass either --file, or --rule-id and --status, but not both.",
+		}
+	}
+	if !c.file.WasSet {
+		if !c.ruleID.WasSet || !c.status.WasSet {
+			return nil, errors.RemediationError{
+				Inner:       fmt.Errorf("error parsing arguments: --rule-id and --status must be provided together"),
+				Remediation: "Pass both --rule-id and --status.",
+			}
+		}
+		return []RuleStatus{{RuleID: c.ruleID.Value, Status: c.status.Value, Revision: c.revision}}, nil
+	}
+
+	data, err := ioutil.ReadFile(c.file.Value)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rule statuses: %w", err)
+	}
+	var file struct {
+		Rules []RuleStatus `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error reading rule statuses: %w", err)
+	}
+	if len(file.Rules) == 0 {
+		return nil, fmt.Errorf("error reading rule statuses: no rules found in file %s", c.file.Value)
+	}
+	for i, r := range file.Rules {
+		if r.RuleID == 0 || !validStatus(r.Status) {
+			return nil, errors.RemediationError{
+				Inner:       fmt.Errorf("error reading rule statuses: rule %d has an invalid rule_id or status", i+1),
+				Remediation: "Give every rule a rule_id, and a status of log, block, score or disabled.",
+			}
+		}
+	}
+	return file.Rules, nil
+}
+
+func validStatus(status string) bool {
+	for _, s := range statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
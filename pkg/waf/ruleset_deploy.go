@@ -0,0 +1,48 @@
+package waf
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// RulesetDeployCommand calls the Fastly API to deploy a version of the ruleset
+// of a firewall.
+type RulesetDeployCommand struct {
+	common.Base
+	wafID      string
+	wafVersion int
+}
+
+// NewRulesetDeployCommand returns a usable command registered under the parent.
+func NewRulesetDeployCommand(parent common.Registerer, globals *config.Data) *RulesetDeployCommand {
+	var c RulesetDeployCommand
+	c.Globals = globals
+	c.CmdClause = parent.Command("deploy", "Deploy a version of the ruleset of a firewall")
+	c.CmdClause.Flag("id", "ID of the firewall").Required().StringVar(&c.wafID)
+	c.CmdClause.Flag("waf-version", "Number of firewall version, defaulting to the latest version").IntVar(&c.wafVersion)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *RulesetDeployCommand) Exec(in io.Reader, out io.Writer) error {
+	v, err := findVersion(c.Globals.Client, c.wafID, c.wafVersion, false)
+	if err != nil {
+		return err
+	}
+	if v.Active {
+		return fmt.Errorf("WAF %s version %d is already deployed", c.wafID, v.Number)
+	}
+
+	if err := c.Globals.Client.DeployWAFVersion(&fastly.DeployWAFVersionInput{WAFID: c.wafID, WAFVersionNumber: v.Number}); err != nil {
+		return err
+	}
+
+	text.Success(out, "Deployed WAF %s version %d", c.wafID, v.Number)
+	text.Info(out, "The deployment completes in the background, which can take a few minutes")
+	return nil
+}
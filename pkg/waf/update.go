@@ -0,0 +1,85 @@
+package waf
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/common"
+	"github.com/fastly/cli/pkg/compute/manifest"
+	"github.com/fastly/cli/pkg/config"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// UpdateCommand calls the Fastly API to update a firewall.
+type UpdateCommand struct {
+	common.Base
+	manifest manifest.Data
+	version  int
+	id       string
+
+	prefetchCondition common.OptionalString
+	response          common.OptionalString
+	disable           bool
+	enable            bool
+}
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent common.Registerer, globals *config.Data) *UpdateCommand {
+	var c UpdateCommand
+	c.Globals = globals
+	c.manifest.File.Read(manifest.Filename)
+	c.CmdClause = parent.Command("update", "Update a web application firewall")
+	c.CmdClause.Flag("service-id", "Service ID").Short('s').StringVar(&c.manifest.Flag.ServiceID)
+	c.CmdClause.Flag("version", "Number of service version").Required().IntVar(&c.version)
+	c.CmdClause.Flag("id", "ID of the firewall").Required().StringVar(&c.id)
+	c.CmdClause.Flag("prefetch-condition", "Name of the condition under which requests are inspected").Action(c.prefetchCondition.Set).StringVar(&c.prefetchCondition.Value)
+	c.CmdClause.Flag("response", "Name of the response object sent when a request is blocked").Action(c.response.Set).StringVar(&c.response.Value)
+	c.CmdClause.Flag("disable", "Disable the firewall").BoolVar(&c.disable)
+	c.CmdClause.Flag("enable", "Enable the firewall").BoolVar(&c.enable)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source := c.manifest.ServiceID()
+	if source == manifest.SourceUndefined {
+		return errors.ErrNoServiceID
+	}
+
+	input := fastly.UpdateWAFInput{
+		ServiceID:      fastly.String(serviceID),
+		ServiceVersion: fastly.Int(c.version),
+		ID:             c.id,
+	}
+	if c.prefetchCondition.WasSet {
+		input.PrefetchCondition = fastly.String(c.prefetchCondition.Value)
+	}
+	if c.response.WasSet {
+		input.Response = fastly.String(c.response.Value)
+	}
+	switch {
+	case c.disable && c.enable:
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: --disable and --enable can't be used together"),
+			Remediation: "Pass either --disable, or --enable, but not both.",
+		}
+	case c.disable, c.enable:
+		input.Disabled = fastly.Bool(c.disable)
+	}
+	if input.PrefetchCondition == nil && input.Response == nil && input.Disabled == nil {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("error parsing arguments: nothing to update"),
+			Remediation: "Pass at least one of --prefetch-condition, --response, --disable or --enable.",
+		}
+	}
+
+	waf, err := c.Globals.Client.UpdateWAF(&input)
+	if err != nil {
+		return err
+	}
+
+	text.Success(out, "Updated WAF %s (service %s version %d)", waf.ID, waf.ServiceID, waf.ServiceVersion)
+	return nil
+}
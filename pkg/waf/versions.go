@@ -0,0 +1,35 @@
+package waf
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+// findVersion returns a version of a firewall: the numbered version, or if
+// number is 0, the active version or, failing that, the latest version.
+func findVersion(client api.Interface, wafID string, number int, active bool) (*fastly.WAFVersion, error) {
+	resp, err := client.ListAllWAFVersions(&fastly.ListAllWAFVersionsInput{WAFID: wafID})
+	if err != nil {
+		return nil, fmt.Errorf("error listing versions of WAF %s: %w", wafID, err)
+	}
+	versions := resp.Items
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("WAF %s has no versions", wafID)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Number < versions[j].Number
+	})
+
+	for _, v := range versions {
+		if (number != 0 && v.Number == number) || (number == 0 && active && v.Active) {
+			return v, nil
+		}
+	}
+	if number != 0 {
+		return nil, fmt.Errorf("WAF %s has no version %d", wafID, number)
+	}
+	return versions[len(versions)-1], nil
+}
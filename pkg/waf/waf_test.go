@@ -0,0 +1,344 @@
+package waf_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/cli/pkg/testutil/golden"
+	"github.com/fastly/cli/pkg/waf"
+	"github.com/fastly/go-fastly/v2/fastly"
+)
+
+func TestWAFList(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"waf", "list", "--service-id", "123"},
+			API:  mock.API{ListWAFsFn: listWAFsOK},
+		},
+		{
+			Args: []string{"waf", "list", "--service-id", "123", "--version", "2"},
+			API:  mock.API{ListWAFsFn: listWAFsOK},
+		},
+		{
+			Args: []string{"waf", "list", "--service-id", "123", "--verbose"},
+			API:  mock.API{ListWAFsFn: listWAFsOK},
+		},
+		{
+			Args: []string{"waf", "list", "--service-id", "123", "-v"},
+			API:  mock.API{ListWAFsFn: listWAFsError},
+		},
+		{
+			Args: []string{"waf", "list"},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func TestWAFDescribe(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"waf", "describe", "--service-id", "123", "--version", "2", "--id", "waf1"},
+			API:  mock.API{GetWAFFn: getWAFOK},
+		},
+		{
+			Args: []string{"waf", "describe", "--service-id", "123", "--version", "2", "--id", "nope"},
+			API:  mock.API{GetWAFFn: getWAFOK},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func TestWAFUpdate(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"waf", "update", "--service-id", "123", "--version", "2", "--id", "waf1", "--response", "blocked"},
+			API:  mock.API{UpdateWAFFn: updateWAFOK},
+		},
+		{
+			Args: []string{"waf", "update", "--service-id", "123", "--version", "2", "--id", "waf1", "--disable"},
+			API:  mock.API{UpdateWAFFn: updateWAFOK},
+		},
+		{
+			Args: []string{"waf", "update", "--service-id", "123", "--version", "2", "--id", "waf1", "--enable", "--prefetch-condition", "prefetch"},
+			API:  mock.API{UpdateWAFFn: updateWAFOK},
+		},
+		{
+			Args: []string{"waf", "update", "--service-id", "123", "--version", "2", "--id", "waf1", "--enable", "--disable"},
+			API:  mock.API{UpdateWAFFn: updateWAFOK},
+		},
+		{
+			Args: []string{"waf", "update", "--service-id", "123", "--version", "2", "--id", "waf1"},
+			API:  mock.API{UpdateWAFFn: updateWAFOK},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func TestWAFRuleList(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"waf", "rule", "list", "--id", "waf1"},
+			API:  mock.API{ListAllWAFVersionsFn: listWAFVersionsOK, ListAllWAFActiveRulesFn: listWAFActiveRulesOK},
+		},
+		{
+			Args: []string{"waf", "rule", "list", "--id", "waf1", "--waf-version", "3", "--status", "block", "--verbose"},
+			API:  mock.API{ListAllWAFVersionsFn: listWAFVersionsOK, ListAllWAFActiveRulesFn: listWAFActiveRulesOK},
+		},
+		{
+			Args: []string{"waf", "rule", "list", "--id", "waf1", "--waf-version", "9"},
+			API:  mock.API{ListAllWAFVersionsFn: listWAFVersionsOK, ListAllWAFActiveRulesFn: listWAFActiveRulesOK},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func TestWAFRuleSearch(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"waf", "rule", "search", "--tag", "attack-sqli"},
+			API:  mock.API{ListAllWAFRulesFn: listWAFRulesOK},
+		},
+		{
+			Args: []string{"waf", "rule", "search", "--owasp", "--tag", "attack-sqli", "--tag", "attack-xss"},
+			API:  mock.API{ListAllWAFRulesFn: listWAFRulesOK},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+func TestWAFRuleStatusUpdate(t *testing.T) {
+	invalid := testutil.MakeTempFile(t, `{"rules": [{"rule_id": 1010010, "status": "block"}, {"rule_id": 1010020, "status": "blocked"}]}`)
+	defer os.Remove(invalid)
+	empty := testutil.MakeTempFile(t, `{"rules": []}`)
+	defer os.Remove(empty)
+	paths := strings.NewReplacer(invalid, "invalid.json", empty, "empty.json")
+
+	api := mock.API{
+		ListAllWAFVersionsFn:              listWAFVersionsOK,
+		CloneWAFVersionFn:                 cloneWAFVersionOK,
+		BatchModificationWAFActiveRulesFn: batchModificationWAFActiveRulesOK,
+	}
+	for _, testcase := range []struct {
+		name string
+		args []string
+	}{
+		{"latest version", []string{"waf", "rule", "status", "update", "--id", "waf1", "--rule-id", "1010010", "--status", "block"}},
+		{"locked version", []string{"waf", "rule", "status", "update", "--id", "waf1", "--waf-version", "2", "--rule-id", "1010010", "--status", "disabled"}},
+		{"no rules", []string{"waf", "rule", "status", "update", "--id", "waf1"}},
+		{"rule without status", []string{"waf", "rule", "status", "update", "--id", "waf1", "--rule-id", "1010010"}},
+		{"rule and file", []string{"waf", "rule", "status", "update", "--id", "waf1", "--rule-id", "1010010", "--status", "log", "--file", invalid}},
+		{"invalid file", []string{"waf", "rule", "status", "update", "--id", "waf1", "--file", invalid}},
+		{"empty file", []string{"waf", "rule", "status", "update", "--id", "waf1", "--file", empty}},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			out, err := golden.Exec(golden.Scenario{Args: testcase.args, API: api})
+			golden.Assert(t, paths.Replace(golden.Format(out, err)))
+		})
+	}
+}
+
+// TestWAFRuleStatusUpdateFile changes the status of more rules than fit in a
+// batch.
+func TestWAFRuleStatusUpdateFile(t *testing.T) {
+	var statuses struct {
+		Rules []waf.RuleStatus `json:"rules"`
+	}
+	for i := 0; i < fastly.BatchModifyMaximumOperations+1; i++ {
+		statuses.Rules = append(statuses.Rules, waf.RuleStatus{RuleID: 1000000 + i, Status: "block"})
+	}
+	statuses.Rules = append(statuses.Rules,
+		waf.RuleStatus{RuleID: 2000000, Status: "disabled"},
+		waf.RuleStatus{RuleID: 2000001, Status: "score", Revision: 2},
+	)
+	data, err := json.Marshal(statuses)
+	testutil.AssertNoError(t, err)
+	path := testutil.MakeTempFile(t, string(data))
+	defer os.Remove(path)
+
+	spy := mock.NewSpy(mock.API{
+		ListAllWAFVersionsFn:              listWAFVersionsOK,
+		BatchModificationWAFActiveRulesFn: batchModificationWAFActiveRulesOK,
+	})
+	out, err := golden.Exec(golden.Scenario{
+		Args:          []string{"waf", "rule", "status", "update", "--id", "waf1", "--file", path},
+		ClientFactory: mock.SpyClient(spy),
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, out, "Updated the status of 1003 rule(s) of WAF waf1 version 3")
+
+	var batches []string
+	for _, call := range spy.Calls() {
+		if call.Method != "BatchModificationWAFActiveRules" {
+			continue
+		}
+		i := call.Input.(*fastly.BatchModificationWAFActiveRulesInput)
+		testutil.AssertEqual(t, 3, i.WAFVersionNumber)
+		last := i.Rules[len(i.Rules)-1]
+		batches = append(batches, fmt.Sprintf("%s %d %d:%s:%d", i.OP, len(i.Rules), last.ModSecID, last.Status, last.Revision))
+	}
+	testutil.AssertEqual(t, []string{
+		"upsert 1000 1000999:block:0",
+		"upsert 2 2000001:score:2",
+		"delete 1 2000000::0",
+	}, batches)
+}
+
+func TestWAFRulesetDeploy(t *testing.T) {
+	for _, scenario := range []golden.Scenario{
+		{
+			Args: []string{"waf", "ruleset", "deploy", "--id", "waf1"},
+			API:  mock.API{ListAllWAFVersionsFn: listWAFVersionsOK, DeployWAFVersionFn: deployWAFVersionOK},
+		},
+		{
+			Args: []string{"waf", "ruleset", "deploy", "--id", "waf1", "--waf-version", "2"},
+			API:  mock.API{ListAllWAFVersionsFn: listWAFVersionsOK, DeployWAFVersionFn: deployWAFVersionOK},
+		},
+		{
+			Args: []string{"waf", "ruleset", "deploy", "--id", "waf1", "--waf-version", "1"},
+			API:  mock.API{ListAllWAFVersionsFn: listWAFVersionsOK, DeployWAFVersionFn: deployWAFVersionError},
+		},
+	} {
+		t.Run(strings.Join(scenario.Args, " "), func(t *testing.T) {
+			golden.Run(t, scenario)
+		})
+	}
+}
+
+var testWAFs = []*fastly.WAF{
+	{ID: "waf1", ServiceID: "123", ServiceVersion: 1, Response: "blocked", PrefetchCondition: "prefetch", ActiveRulesOWASPBlockCount: 10, ActiveRulesOWASPScoreCount: 2},
+	{ID: "waf2", ServiceID: "123", ServiceVersion: 2, Disabled: true, ActiveRulesFastlyLogCount: 3},
+}
+
+func listWAFsOK(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+	if i.FilterService != "123" {
+		return nil, fmt.Errorf("unexpected service %s", i.FilterService)
+	}
+	var resp fastly.WAFResponse
+	for _, w := range testWAFs {
+		if i.FilterVersion == 0 || i.FilterVersion == w.ServiceVersion {
+			resp.Items = append(resp.Items, w)
+		}
+	}
+	return &resp, nil
+}
+
+func listWAFsError(i *fastly.ListWAFsInput) (*fastly.WAFResponse, error) {
+	return nil, errTest
+}
+
+func getWAFOK(i *fastly.GetWAFInput) (*fastly.WAF, error) {
+	created := testutil.MustParseTimeRFC3339("2021-01-02T03:04:05Z")
+	if i.ID != "waf1" {
+		return nil, errTest
+	}
+	w := *testWAFs[0]
+	w.ServiceVersion = i.ServiceVersion
+	w.CreatedAt = created
+	w.UpdatedAt = created
+	return &w, nil
+}
+
+func updateWAFOK(i *fastly.UpdateWAFInput) (*fastly.WAF, error) {
+	return &fastly.WAF{ID: i.ID, ServiceID: *i.ServiceID, ServiceVersion: *i.ServiceVersion}, nil
+}
+
+// listWAFVersionsOK lists the versions of a firewall: version 2 is deployed,
+// and version 3 is a draft.
+func listWAFVersionsOK(i *fastly.ListAllWAFVersionsInput) (*fastly.WAFVersionResponse, error) {
+	return &fastly.WAFVersionResponse{Items: []*fastly.WAFVersion{
+		{Number: 3},
+		{Number: 1, Locked: true},
+		{Number: 2, Locked: true, Active: true},
+	}}, nil
+}
+
+func cloneWAFVersionOK(i *fastly.CloneWAFVersionInput) (*fastly.WAFVersion, error) {
+	return &fastly.WAFVersion{Number: 4}, nil
+}
+
+func deployWAFVersionOK(i *fastly.DeployWAFVersionInput) error {
+	return nil
+}
+
+func deployWAFVersionError(i *fastly.DeployWAFVersionInput) error {
+	return errTest
+}
+
+func listWAFActiveRulesOK(i *fastly.ListAllWAFActiveRulesInput) (*fastly.WAFActiveRuleResponse, error) {
+	rules := map[int][]*fastly.WAFActiveRule{
+		2: {
+			{ModSecID: 1010020, Status: "log", Revision: 1, LatestRevision: 2, Outdated: true},
+			{ModSecID: 1010010, Status: "block", Revision: 2, LatestRevision: 2},
+		},
+		3: {
+			{ModSecID: 1010010, Status: "block", Revision: 2, LatestRevision: 2},
+			{ModSecID: 1010030, Status: "score", Revision: 1, LatestRevision: 1},
+		},
+	}
+	var resp fastly.WAFActiveRuleResponse
+	for _, r := range rules[i.WAFVersionNumber] {
+		if i.FilterStatus == "" || i.FilterStatus == r.Status {
+			resp.Items = append(resp.Items, r)
+		}
+	}
+	return &resp, nil
+}
+
+func batchModificationWAFActiveRulesOK(i *fastly.BatchModificationWAFActiveRulesInput) ([]*fastly.WAFActiveRule, error) {
+	if i.WAFVersionNumber == 2 {
+		return nil, errors.New("version 2 is locked")
+	}
+	return i.Rules, nil
+}
+
+func listWAFRulesOK(i *fastly.ListAllWAFRulesInput) (*fastly.WAFRuleResponse, error) {
+	if i.Include != "waf_rule_revisions" {
+		return nil, fmt.Errorf("unexpected include %q", i.Include)
+	}
+	rules := []*fastly.WAFRule{
+		{ModSecID: 942100, Publisher: "owasp", Type: "score", Revisions: []*fastly.WAFRuleRevision{
+			{Revision: 1, Severity: 2, Status: "SQL Injection Attack Detected via libinjection"},
+			{Revision: 2, Severity: 2, Status: "SQL Injection Attack Detected via libinjection (revised)"},
+		}},
+		{ModSecID: 1010010, Publisher: "fastly", Type: "strict", Revisions: []*fastly.WAFRuleRevision{
+			{Revision: 1, Severity: 3, Status: "SQL injection in a query string"},
+		}},
+		{ModSecID: 941100, Publisher: "owasp", Type: "score", Revisions: []*fastly.WAFRuleRevision{
+			{Revision: 1, Severity: 2, Status: "XSS Attack Detected via libinjection"},
+		}},
+	}
+	var resp fastly.WAFRuleResponse
+	for _, r := range rules {
+		if len(i.FilterPublishers) > 0 && r.Publisher != i.FilterPublishers[0] {
+			continue
+		}
+		if r.ModSecID == 941100 && len(i.FilterTagNames) < 2 {
+			continue // the only rule tagged attack-xss
+		}
+		resp.Items = append(resp.Items, r)
+	}
+	return &resp, nil
+}
+
+var errTest = errors.New("fixture error")
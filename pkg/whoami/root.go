@@ -40,6 +40,9 @@ func (c *RootCommand) Exec(in io.Reader, out io.Writer) error {
 		return fmt.Errorf("error constructing API request: %w", err)
 	}
 
+	if err := c.Globals.ResolveToken(); err != nil {
+		return err
+	}
 	token, source := c.Globals.Token()
 	if source == config.SourceUndefined {
 		return errors.ErrNoToken
@@ -1,22 +1,18 @@
 package whoami_test
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/fastly/cli/pkg/api"
-	"github.com/fastly/cli/pkg/app"
 	"github.com/fastly/cli/pkg/config"
-	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
-	"github.com/fastly/cli/pkg/update"
+	"github.com/fastly/cli/pkg/testutil/golden"
 	"github.com/fastly/cli/pkg/whoami"
 )
 
@@ -81,20 +77,14 @@ func TestWhoami(t *testing.T) {
 		},
 	} {
 		t.Run(testcase.name, func(t *testing.T) {
-			var (
-				args                            = testcase.args
-				env                             = testcase.env
-				file                            = testcase.file
-				configFileName                  = "/dev/null"
-				clientFactory                   = mock.APIClient(mock.API{})
-				httpClient                      = testcase.client
-				versioner      update.Versioner = nil
-				in             io.Reader        = nil
-				out            bytes.Buffer
-			)
-			err := app.Run(args, env, file, configFileName, clientFactory, httpClient, versioner, in, &out)
+			out, err := golden.Exec(golden.Scenario{
+				Args:       testcase.args,
+				Env:        testcase.env,
+				File:       testcase.file,
+				HTTPClient: testcase.client,
+			})
 			testutil.AssertErrorContains(t, err, testcase.wantError)
-			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+			testutil.AssertStringContains(t, out, testcase.wantOutput)
 		})
 	}
 }